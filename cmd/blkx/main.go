@@ -0,0 +1,180 @@
+// Command blkx builds an import plan from a directory of War Thunder
+// datamined .blkx weapon files and prints it.
+//
+//	blkx [-o plan.json] [-check] dir
+//	blkx -apply [-mode insert|upsert|replace] [-dry-run] dir|plan.json
+//
+// -o writes the plan as JSON, to be reviewed and edited. -check instead
+// fails when the file differs from the plan, which keeps the parser honest
+// against the samples in internal/blkx/testdata. -apply imports a directory
+// or an edited plan into the MongoDB store configured in .env.
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/joho/godotenv"
+
+	"github.com/zeze322/wt-guided-weaponry/internal/blkx"
+	"github.com/zeze322/wt-guided-weaponry/internal/db/mongodb"
+	"github.com/zeze322/wt-guided-weaponry/internal/importer"
+)
+
+func main() {
+	var (
+		out    = flag.String("o", "", "write the plan as JSON to this file")
+		check  = flag.Bool("check", false, "fail if the -o file differs from the plan instead of writing it")
+		apply  = flag.Bool("apply", false, "import the plan into the store")
+		mode   = flag.String("mode", "insert", "insert, upsert or replace, with -apply")
+		dryRun = flag.Bool("dry-run", false, "validate and report without writing, with -apply")
+	)
+
+	flag.Parse()
+
+	if flag.NArg() != 1 {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	plan, err := load(flag.Arg(0))
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	switch {
+	case *apply:
+		applyPlan(plan, *mode, *dryRun)
+	case *out != "":
+		writePlan(plan, *out, *check)
+	default:
+		printPlan(os.Stdout, plan)
+	}
+}
+
+// load parses a datamine directory or reads a plan written by -o.
+func load(name string) (*blkx.Plan, error) {
+	info, err := os.Stat(name)
+	if err != nil {
+		return nil, err
+	}
+
+	if info.IsDir() {
+		return blkx.ParseDir(name)
+	}
+
+	data, err := os.ReadFile(name)
+	if err != nil {
+		return nil, err
+	}
+
+	plan := new(blkx.Plan)
+	if err := json.Unmarshal(data, plan); err != nil {
+		return nil, fmt.Errorf("%s: %w", name, err)
+	}
+
+	return plan, nil
+}
+
+func writePlan(plan *blkx.Plan, out string, check bool) {
+	data, err := json.MarshalIndent(plan, "", "  ")
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	data = append(data, '\n')
+
+	if check {
+		current, err := os.ReadFile(out)
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		if !bytes.Equal(current, data) {
+			log.Fatalf("%s differs from the parsed plan", out)
+		}
+
+		return
+	}
+
+	if err := os.WriteFile(out, data, 0o644); err != nil {
+		log.Fatal(err)
+	}
+}
+
+func printPlan(w io.Writer, plan *blkx.Plan) {
+	tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+
+	fmt.Fprintln(tw, "FILE\tNAME\tCATEGORY\tNOTE")
+
+	for _, entry := range plan.Entries {
+		if entry.Error != "" {
+			fmt.Fprintf(tw, "%s\t\t\terror: %s\n", entry.File, entry.Error)
+			continue
+		}
+
+		note := strings.Join(append([]string{entry.Reason}, entry.Warnings...), "; ")
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\n", entry.File, entry.Params.Name, entry.Params.Category, note)
+	}
+
+	tw.Flush()
+}
+
+func applyPlan(plan *blkx.Plan, mode string, dryRun bool) {
+	opts := importer.Options{DryRun: dryRun}
+
+	var err error
+	if opts.Mode, err = importer.ParseMode(mode); err != nil {
+		log.Fatal(err)
+	}
+
+	if err := godotenv.Load(); err != nil {
+		log.Fatal("failed to load env file")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Minute)
+	defer cancel()
+
+	store, err := mongodb.New(ctx, os.Getenv("MONGO_URI"), os.Getenv("MONGODB_DATABASE"), os.Getenv("MONGODB_COLLECTION"))
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	defer store.Close(ctx)
+
+	report, err := plan.Apply(ctx, store, opts)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	tw := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
+
+	fmt.Fprintln(tw, "#\tNAME\tSTATUS\tMESSAGE")
+
+	for _, res := range report.Records {
+		msg := res.Msg
+		for _, fe := range res.Errors {
+			msg += fmt.Sprintf("; %s: %s", fe.Field, fe.Msg)
+		}
+
+		fmt.Fprintf(tw, "%d\t%s\t%s\t%s\n", res.Index, res.Name, res.Status, msg)
+	}
+
+	tw.Flush()
+
+	fmt.Printf("\n%s: %d created, %d updated, %d skipped, %d failed\n",
+		report.Mode, report.Summary.Created, report.Summary.Updated, report.Summary.Skipped, report.Summary.Failed)
+
+	if report.Summary.Failed > 0 {
+		os.Exit(1)
+	}
+}
@@ -0,0 +1,122 @@
+// Command flight simulates the flight of the weapons in a blkx import plan
+// or a JSON array of weapons and prints their speed and range.
+//
+//	flight [-speed m/s] [-altitude m] [-pitch deg] [-time s] [-no-drag] [-no-gravity] [-trace] file
+//
+// -trace prints the speed, altitude and range over time instead of a
+// summary.
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"text/tabwriter"
+
+	"github.com/zeze322/wt-guided-weaponry/internal/blkx"
+	"github.com/zeze322/wt-guided-weaponry/internal/flight"
+	"github.com/zeze322/wt-guided-weaponry/models"
+)
+
+func main() {
+	var (
+		cfg   flight.Config
+		trace = flag.Bool("trace", false, "print the samples of every flight")
+	)
+
+	flag.Float64Var(&cfg.Speed, "speed", 0, "launch speed in m/s")
+	flag.Float64Var(&cfg.Altitude, "altitude", 1000, "launch altitude in m")
+	flag.Float64Var(&cfg.Pitch, "pitch", 0, "launch angle above the horizon in degrees")
+	flag.Float64Var(&cfg.Duration, "time", 60, "longest flight in s")
+	flag.BoolVar(&cfg.NoDrag, "no-drag", false, "fly without drag")
+	flag.BoolVar(&cfg.NoGravity, "no-gravity", false, "fly without gravity, along the launch line")
+
+	flag.Parse()
+
+	if flag.NArg() != 1 {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	weapons, err := load(flag.Arg(0))
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	tw := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
+
+	if !*trace {
+		fmt.Fprintln(tw, "NAME\tMAX SPEED\tMAX MACH\tTIME\tSPEED\tALTITUDE\tRANGE")
+	}
+
+	for _, params := range weapons {
+		m, err := flight.FromParams(params)
+		if err != nil {
+			log.Print(err)
+			continue
+		}
+
+		res, err := flight.Simulate(m, cfg)
+		if err != nil {
+			log.Print(err)
+			continue
+		}
+
+		if *trace {
+			printTrace(tw, m.Name, res)
+			continue
+		}
+
+		last := res.Last()
+
+		var mach float64
+		for _, s := range res.Samples {
+			mach = max(mach, s.Mach)
+		}
+
+		fmt.Fprintf(tw, "%s\t%.0f m/s\t%.2f\t%.1f s\t%.0f m/s\t%.0f m\t%.2f km\n",
+			m.Name, res.MaxSpeed, mach, last.Time, last.Speed, last.Altitude, last.Range/1000)
+	}
+
+	tw.Flush()
+}
+
+// load reads a plan written by blkx -o or a JSON array of weapons.
+func load(name string) ([]*models.Params, error) {
+	data, err := os.ReadFile(name)
+	if err != nil {
+		return nil, err
+	}
+
+	plan := new(blkx.Plan)
+	if err := json.Unmarshal(data, plan); err == nil && plan.Entries != nil {
+		var weapons []*models.Params
+		for _, entry := range plan.Entries {
+			if entry.Params != nil {
+				weapons = append(weapons, entry.Params)
+			}
+		}
+		return weapons, nil
+	}
+
+	var weapons []*models.Params
+	if err := json.Unmarshal(data, &weapons); err != nil {
+		return nil, fmt.Errorf("%s: %w", name, err)
+	}
+
+	return weapons, nil
+}
+
+func printTrace(w io.Writer, name string, res *flight.Result) {
+	fmt.Fprintf(w, "%s\n", name)
+	fmt.Fprintln(w, "TIME\tSPEED\tMACH\tALTITUDE\tRANGE")
+
+	for _, s := range res.Samples {
+		fmt.Fprintf(w, "%.2f\t%.1f\t%.3f\t%.1f\t%.1f\n", s.Time, s.Speed, s.Mach, s.Altitude, s.Range)
+	}
+
+	fmt.Fprintln(w)
+}
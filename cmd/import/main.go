@@ -0,0 +1,141 @@
+// Command import loads weapons from JSON, NDJSON or CSV files into the
+// MongoDB store configured in .env, the same as the server uses.
+//
+//	import [-mode insert|upsert|replace] [-dry-run] [-format json|ndjson|csv] [-json] file...
+//
+// A file named "-" is read from stdin and needs -format. The command exits
+// with status 1 when any record failed.
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"text/tabwriter"
+	"time"
+
+	"github.com/joho/godotenv"
+
+	"github.com/zeze322/wt-guided-weaponry/internal/db/mongodb"
+	"github.com/zeze322/wt-guided-weaponry/internal/importer"
+)
+
+func main() {
+	var (
+		mode   = flag.String("mode", "insert", "insert, upsert or replace")
+		dryRun = flag.Bool("dry-run", false, "validate and report without writing")
+		format = flag.String("format", "", "input format, guessed from the file extension when empty")
+		asJSON = flag.Bool("json", false, "print the report as JSON")
+	)
+
+	flag.Parse()
+
+	if flag.NArg() == 0 {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	opts := importer.Options{DryRun: *dryRun}
+
+	var err error
+	if opts.Mode, err = importer.ParseMode(*mode); err != nil {
+		log.Fatal(err)
+	}
+
+	var records []importer.Record
+
+	for _, name := range flag.Args() {
+		recs, err := decodeFile(name, importer.Format(*format))
+		if err != nil {
+			log.Fatalf("%s: %s", name, err)
+		}
+
+		records = append(records, recs...)
+	}
+
+	if err := godotenv.Load(); err != nil {
+		log.Fatal("failed to load env file")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Minute)
+	defer cancel()
+
+	store, err := mongodb.New(ctx, os.Getenv("MONGO_URI"), os.Getenv("MONGODB_DATABASE"), os.Getenv("MONGODB_COLLECTION"))
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	defer store.Close(ctx)
+
+	report, err := importer.Run(ctx, store, records, opts)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	if *asJSON {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		if err := enc.Encode(report); err != nil {
+			log.Fatal(err)
+		}
+	} else {
+		printReport(os.Stdout, report)
+	}
+
+	if report.Summary.Failed > 0 {
+		os.Exit(1)
+	}
+}
+
+func decodeFile(name string, format importer.Format) ([]importer.Record, error) {
+	var err error
+	if format == "" {
+		if format, err = importer.FormatOfFile(name); err != nil {
+			return nil, err
+		}
+	}
+
+	var r io.Reader = os.Stdin
+
+	if name != "-" {
+		f, err := os.Open(name)
+		if err != nil {
+			return nil, err
+		}
+
+		defer f.Close()
+
+		r = f
+	}
+
+	return importer.Decode(r, format)
+}
+
+func printReport(w io.Writer, report *importer.Report) {
+	tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+
+	fmt.Fprintln(tw, "#\tLINE\tNAME\tSTATUS\tMESSAGE")
+
+	for _, res := range report.Records {
+		msg := res.Msg
+		for _, fe := range res.Errors {
+			msg += fmt.Sprintf("; %s: %s", fe.Field, fe.Msg)
+		}
+
+		fmt.Fprintf(tw, "%d\t%d\t%s\t%s\t%s\n", res.Index, res.Line, res.Name, res.Status, msg)
+	}
+
+	tw.Flush()
+
+	dry := ""
+	if report.DryRun {
+		dry = " (dry run, nothing was written)"
+	}
+
+	fmt.Fprintf(w, "\n%s: %d created, %d updated, %d skipped, %d failed%s\n",
+		report.Mode, report.Summary.Created, report.Summary.Updated, report.Summary.Skipped, report.Summary.Failed, dry)
+}
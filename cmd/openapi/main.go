@@ -0,0 +1,47 @@
+// Command openapi writes the OpenAPI document of the versioned API to a file.
+// With -check it instead fails when the file is out of date, so a route or
+// model change can't land without the committed document.
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"flag"
+	"log"
+	"os"
+
+	"github.com/zeze322/wt-guided-weaponry/internal/api"
+)
+
+func main() {
+	var (
+		out   = flag.String("o", "docs/openapi.json", "output file")
+		check = flag.Bool("check", false, "fail if the output file is out of date instead of writing it")
+	)
+
+	flag.Parse()
+
+	data, err := json.MarshalIndent(api.OpenAPI(), "", "  ")
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	data = append(data, '\n')
+
+	if *check {
+		current, err := os.ReadFile(*out)
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		if !bytes.Equal(current, data) {
+			log.Fatalf("%s is out of date, run make openapi", *out)
+		}
+
+		return
+	}
+
+	if err := os.WriteFile(*out, data, 0o644); err != nil {
+		log.Fatal(err)
+	}
+}
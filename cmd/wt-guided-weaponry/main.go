@@ -2,6 +2,8 @@ package main
 
 import (
 	"context"
+	"errors"
+	"io/fs"
 	"log"
 	"os"
 	"time"
@@ -9,36 +11,81 @@ import (
 	"github.com/joho/godotenv"
 
 	"github.com/zeze322/wt-guided-weaponry/internal/api"
+	"github.com/zeze322/wt-guided-weaponry/internal/db/memory"
 	"github.com/zeze322/wt-guided-weaponry/internal/db/mongodb"
+	"github.com/zeze322/wt-guided-weaponry/models"
 )
 
+const (
+	backendMongo  = "mongo"
+	backendMemory = "memory"
+)
+
+// stepTimeout bounds each startup step that talks to the database.
+const stepTimeout = 10 * time.Second
+
+// withTimeout runs a startup step under its own deadline, so a slow step
+// doesn't eat into the budget of the next.
+func withTimeout(step func(context.Context) error) error {
+	ctx, cancel := context.WithTimeout(context.Background(), stepTimeout)
+	defer cancel()
+
+	return step(ctx)
+}
+
 func main() {
-	if err := godotenv.Load(); err != nil {
-		log.Fatal("failed to load env file")
+	// The environment can come from the process alone, e.g. in a container.
+	if err := godotenv.Load(); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		log.Fatalf("failed to load env file: %s", err)
 	}
 
 	var (
 		port            = os.Getenv("PORT")
+		storeBackend    = os.Getenv("STORE_BACKEND")
 		mongoURI        = os.Getenv("MONGO_URI")
 		mongoDatabase   = os.Getenv("MONGODB_DATABASE")
 		mongoCollection = os.Getenv("MONGODB_COLLECTION")
 	)
 
-	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
-	defer cancel()
+	var store mongodb.Store
 
-	mongoClient, err := mongodb.New(ctx, mongoURI, mongoDatabase, mongoCollection)
-	if err != nil {
-		log.Fatal(err)
+	switch storeBackend {
+	case backendMemory:
+		store = memory.New()
+	case backendMongo, "":
+		var mongoClient *mongodb.MongoClient
+
+		err := withTimeout(func(ctx context.Context) (err error) {
+			mongoClient, err = mongodb.New(ctx, mongoURI, mongoDatabase, mongoCollection)
+			return err
+		})
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		if err := withTimeout(mongoClient.Migrate); err != nil {
+			log.Fatal(err)
+		}
+
+		if err := withTimeout(mongoClient.CreateIndex); err != nil {
+			log.Fatal(err)
+		}
+
+		defer withTimeout(mongoClient.Close)
+
+		store = mongoClient
+	default:
+		log.Fatalf("unknown store backend: %s", storeBackend)
 	}
 
-	if err := mongoClient.CreateIndex(ctx); err != nil {
+	err := withTimeout(func(ctx context.Context) error {
+		return mongodb.SeedCategories(ctx, store, models.DefaultCategories)
+	})
+	if err != nil {
 		log.Fatal(err)
 	}
 
-	defer mongoClient.Close(ctx)
-
-	server := api.NewServer(port, mongoClient)
+	server := api.NewServer(port, store)
 
 	if err := server.Run(); err != nil {
 		log.Fatal(err)
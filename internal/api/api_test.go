@@ -0,0 +1,73 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/zeze322/wt-guided-weaponry/internal/db/memory"
+	"github.com/zeze322/wt-guided-weaponry/internal/db/mongodb"
+	"github.com/zeze322/wt-guided-weaponry/models"
+)
+
+// newTestServer serves a memory store holding the default categories and
+// the weapons in testdata/weapons.json.
+func newTestServer(t *testing.T) (http.Handler, *memory.MemoryStore) {
+	t.Helper()
+
+	data, err := os.ReadFile("testdata/weapons.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var weapons []*models.Params
+	if err := json.Unmarshal(data, &weapons); err != nil {
+		t.Fatal(err)
+	}
+
+	store := memory.New()
+
+	if err := mongodb.SeedCategories(context.Background(), store, models.DefaultCategories); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, weapon := range weapons {
+		if err := store.InsertWeapon(context.Background(), weapon); err != nil {
+			t.Fatalf("InsertWeapon(%q): %v", weapon.Name, err)
+		}
+	}
+
+	handler, err := NewServer(":0", store).Handler()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return handler, store
+}
+
+// do sends a request to handler and returns the response with its body.
+func do(t *testing.T, handler http.Handler, method, target, contentType, body string) (*http.Response, string) {
+	t.Helper()
+
+	req := httptest.NewRequest(method, target, strings.NewReader(body))
+	if contentType != "" {
+		req.Header.Set("Content-Type", contentType)
+	}
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+
+	res := rec.Result()
+
+	data, err := io.ReadAll(res.Body)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return res, string(data)
+}
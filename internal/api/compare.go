@@ -0,0 +1,68 @@
+package api
+
+import (
+	"net/http"
+	"slices"
+	"strings"
+
+	"github.com/zeze322/wt-guided-weaponry/lib"
+	"github.com/zeze322/wt-guided-weaponry/models"
+	"github.com/zeze322/wt-guided-weaponry/views/compare"
+)
+
+// handleCompare renders a comparison of any weapons, picked with w=name
+// (repeated) whatever their category. The table shows the parameters of
+// all their categories, and the URL alone reproduces the comparison.
+// Names that don't match a weapon are listed as missing.
+func (s *Server) handleCompare(w http.ResponseWriter, r *http.Request) error {
+	ctx := r.Context()
+
+	categories, err := s.mongo.Categories(ctx)
+	if err != nil {
+		return err
+	}
+
+	all, err := s.mongo.Weapons(ctx)
+	if err != nil {
+		return err
+	}
+
+	names := make([]models.Name, 0, len(all))
+	for _, weapon := range all {
+		names = append(names, models.Name{Name: weapon.Name, Category: weapon.Category})
+	}
+
+	var (
+		selected []string
+		weapons  []*models.Params
+		missing  []string
+		shown    []string
+	)
+
+	for _, name := range r.URL.Query()["w"] {
+		name = strings.TrimSpace(name)
+		if name == "" || slices.Contains(selected, name) {
+			continue
+		}
+
+		selected = append(selected, name)
+
+		weapon, err := s.mongo.Weapon(ctx, name)
+		if err != nil {
+			missing = append(missing, name)
+			continue
+		}
+
+		weapons = append(weapons, weapon)
+		shown = append(shown, weapon.Category)
+	}
+
+	units, err := pageUnits(r)
+	if err != nil {
+		return err
+	}
+
+	fields := units.Fields(models.FieldsForAll(shown))
+
+	return lib.Render(w, r, compare.Compare(categories, names, selected, missing, fields, units.Weapons(weapons), units))
+}
@@ -0,0 +1,20 @@
+package api
+
+import (
+	"net/http"
+	"sync"
+
+	"github.com/zeze322/wt-guided-weaponry/lib"
+	"github.com/zeze322/wt-guided-weaponry/views/docs"
+)
+
+// openAPI is built once, the route table doesn't change at runtime.
+var openAPI = sync.OnceValue(OpenAPI)
+
+func (s *Server) handleOpenAPI(w http.ResponseWriter, r *http.Request) error {
+	return lib.WriteJSON(w, http.StatusOK, openAPI())
+}
+
+func (s *Server) handleDocs(w http.ResponseWriter, r *http.Request) error {
+	return lib.Render(w, r, docs.Docs(openAPI()))
+}
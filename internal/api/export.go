@@ -0,0 +1,161 @@
+package api
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/zeze322/wt-guided-weaponry/internal/db/mongodb"
+	"github.com/zeze322/wt-guided-weaponry/internal/export"
+	"github.com/zeze322/wt-guided-weaponry/lib"
+	"github.com/zeze322/wt-guided-weaponry/models"
+)
+
+var exportTypes = map[string]string{
+	"csv":  "text/csv; charset=UTF-8",
+	"xlsx": "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet",
+}
+
+// handleExport downloads a comparison table as CSV or XLSX. The weapons
+// are those of a category (category=slug), a search (search=keyword) or
+// a custom selection (w=name, repeated). A category is exported as it was
+// in a game version with version=. Values are in the units of the tables,
+// see pageUnits. transpose=true lists the weapons as rows instead of
+// columns.
+func (s *Server) handleExport(w http.ResponseWriter, r *http.Request) error {
+	format := chi.URLParam(r, "format")
+
+	contentType, ok := exportTypes[format]
+	if !ok {
+		return lib.NewApiError(http.StatusNotFound, fmt.Errorf("unknown export format %s", format))
+	}
+
+	title, weapons, err := s.exportSelection(r)
+	if err != nil {
+		return err
+	}
+
+	units, err := pageUnits(r)
+	if err != nil {
+		return err
+	}
+
+	categories := make([]string, 0, len(weapons))
+	for _, weapon := range weapons {
+		categories = append(categories, weapon.Category)
+	}
+
+	snapshot := time.Now()
+	table := export.New(title, units.Fields(models.FieldsForAll(categories)), units.Weapons(weapons), snapshot)
+
+	if transpose, _ := strconv.ParseBool(r.FormValue("transpose")); transpose {
+		table = table.Transpose()
+	}
+
+	w.Header().Set("Content-Type", contentType)
+	w.Header().Set("Content-Disposition", fmt.Sprintf(`attachment; filename="%s-%s.%s"`, fileName(title), snapshot.UTC().Format("2006-01-02"), format))
+
+	if format == "csv" {
+		return export.WriteCSV(w, table)
+	}
+
+	return export.WriteXLSX(w, table)
+}
+
+func (s *Server) exportSelection(r *http.Request) (string, []*models.Params, error) {
+	ctx := r.Context()
+
+	if slug := r.FormValue("category"); slug != "" {
+		category, err := s.mongo.Category(ctx, slug)
+		if err != nil {
+			return "", nil, lib.NotFound(slug)
+		}
+
+		version := r.FormValue("version")
+		if version == "" {
+			weapons, err := s.mongo.WeaponsByCategory(ctx, slug)
+			if err != nil && !errors.Is(err, mongodb.ErrNothingFound) {
+				return "", nil, err
+			}
+
+			return category.Name, weapons, nil
+		}
+
+		weapons, err := s.mongo.WeaponsByCategoryAt(ctx, slug, version)
+		if errors.Is(err, mongodb.ErrUnknownVersion) {
+			return "", nil, unknownVersion(err)
+		}
+
+		if err != nil && !errors.Is(err, mongodb.ErrNothingFound) {
+			return "", nil, err
+		}
+
+		return fmt.Sprintf("%s %s", category.Name, version), weapons, nil
+	}
+
+	var (
+		title string
+		names []string
+	)
+
+	switch {
+	case r.FormValue("search") != "":
+		keyWord := r.FormValue("search")
+		title = fmt.Sprintf("Search %q", keyWord)
+
+		found, err := s.mongo.SearchWeapon(ctx, keyWord)
+		if err != nil && !errors.Is(err, mongodb.ErrNothingFound) {
+			return "", nil, err
+		}
+
+		for _, name := range found {
+			names = append(names, name.Name)
+		}
+	case len(r.Form["w"]) > 0:
+		title = "Selection"
+		names = r.Form["w"]
+	default:
+		return "", nil, lib.InvalidFields([]lib.FieldError{{Field: "category", Msg: "category, search or w is required"}})
+	}
+
+	weapons := make([]*models.Params, 0, len(names))
+
+	for _, name := range names {
+		weapon, err := s.mongo.Weapon(ctx, name)
+		if err != nil {
+			return "", nil, lib.NotFound(name)
+		}
+
+		weapons = append(weapons, weapon)
+	}
+
+	return title, weapons, nil
+}
+
+// fileName turns title into a file name such as "aam-arh".
+func fileName(title string) string {
+	name := strings.Map(func(r rune) rune {
+		switch {
+		case r >= 'a' && r <= 'z', r >= '0' && r <= '9':
+			return r
+		case r >= 'A' && r <= 'Z':
+			return r - 'A' + 'a'
+		}
+		return '-'
+	}, title)
+
+	for strings.Contains(name, "--") {
+		name = strings.ReplaceAll(name, "--", "-")
+	}
+
+	if name = strings.Trim(name, "-"); name == "" {
+		return "weapons"
+	}
+
+	return name
+}
@@ -0,0 +1,89 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/graphql-go/graphql"
+	"github.com/graphql-go/graphql/language/ast"
+	"github.com/graphql-go/graphql/language/parser"
+
+	"github.com/zeze322/wt-guided-weaponry/lib"
+)
+
+// GraphQLRequest is the body of a GraphQL request. GET requests send the
+// same fields as query parameters, with variables encoded as JSON.
+type GraphQLRequest struct {
+	Query         string         `json:"query"`
+	OperationName string         `json:"operationName,omitempty"`
+	Variables     map[string]any `json:"variables,omitempty"`
+}
+
+func (s *Server) handleGraphQL(w http.ResponseWriter, r *http.Request) error {
+	req := new(GraphQLRequest)
+
+	switch r.Method {
+	case http.MethodGet:
+		req.Query = r.FormValue("query")
+		req.OperationName = r.FormValue("operationName")
+
+		if variables := r.FormValue("variables"); variables != "" {
+			if err := json.Unmarshal([]byte(variables), &req.Variables); err != nil {
+				return lib.NewApiError(http.StatusBadRequest, fmt.Errorf("invalid variables: %s", err))
+			}
+		}
+
+		// GET requests can be forged from other sites, so they may only
+		// read.
+		if !readOnly(req.Query, req.OperationName) {
+			w.Header().Set("Allow", http.MethodPost)
+			return lib.NewApiError(http.StatusMethodNotAllowed, errors.New("mutations must be sent with POST"))
+		}
+	default:
+		if err := decodeBody(r, req); err != nil {
+			return err
+		}
+	}
+
+	if req.Query == "" {
+		return lib.InvalidFields([]lib.FieldError{{Field: "query", Msg: "query is required"}})
+	}
+
+	result := graphql.Do(graphql.Params{
+		Schema:         s.graphql,
+		RequestString:  req.Query,
+		OperationName:  req.OperationName,
+		VariableValues: req.Variables,
+		Context:        r.Context(),
+	})
+
+	return lib.WriteJSON(w, http.StatusOK, result)
+}
+
+// readOnly reports whether the operation a request runs is a query. Queries
+// that don't parse are left for graphql.Do to report.
+func readOnly(query, operationName string) bool {
+	doc, err := parser.Parse(parser.ParseParams{Source: query})
+	if err != nil {
+		return true
+	}
+
+	for _, def := range doc.Definitions {
+		op, ok := def.(*ast.OperationDefinition)
+		if !ok {
+			continue
+		}
+
+		if operationName != "" && (op.Name == nil || op.Name.Value != operationName) {
+			continue
+		}
+
+		if op.Operation != ast.OperationTypeQuery {
+			return false
+		}
+	}
+
+	return true
+}
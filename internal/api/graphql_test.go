@@ -0,0 +1,60 @@
+package api
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"testing"
+)
+
+func TestGraphQLGetRejectsMutations(t *testing.T) {
+	handler, store := newTestServer(t)
+
+	tests := []struct {
+		name   string
+		query  url.Values
+		status int
+	}{
+		{"query", url.Values{"query": {`{ categories { slug } }`}}, http.StatusOK},
+		{"mutation", url.Values{"query": {`mutation { createCategory(input: {slug: "evil", name: "Evil"}) { slug } }`}}, http.StatusMethodNotAllowed},
+		{
+			"named mutation",
+			url.Values{
+				"query":         {`query Q { categories { slug } } mutation M { createCategory(input: {slug: "evil", name: "Evil"}) { slug } }`},
+				"operationName": {"M"},
+			},
+			http.StatusMethodNotAllowed,
+		},
+		{
+			"named query beside a mutation",
+			url.Values{
+				"query":         {`query Q { categories { slug } } mutation M { createCategory(input: {slug: "evil", name: "Evil"}) { slug } }`},
+				"operationName": {"Q"},
+			},
+			http.StatusOK,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			res, body := do(t, handler, http.MethodGet, "/api/graphql?"+tt.query.Encode(), "", "")
+			if res.StatusCode != tt.status {
+				t.Fatalf("GET returned %d, want %d: %s", res.StatusCode, tt.status, body)
+			}
+		})
+	}
+
+	if _, err := store.Category(context.Background(), "evil"); err == nil {
+		t.Fatal("a mutation sent with GET created a category")
+	}
+
+	res, body := do(t, handler, http.MethodPost, "/api/graphql", "application/json",
+		`{"query": "mutation { createCategory(input: {slug: \"evil\", name: \"Evil\"}) { slug } }"}`)
+	if res.StatusCode != http.StatusOK {
+		t.Fatalf("POST returned %d: %s", res.StatusCode, body)
+	}
+
+	if _, err := store.Category(context.Background(), "evil"); err != nil {
+		t.Fatalf("a mutation sent with POST didn't create the category: %v", err)
+	}
+}
@@ -1,57 +1,24 @@
 package api
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
+	"mime"
 	"net/http"
+	"net/url"
 
 	"github.com/go-chi/chi/v5"
 
+	"github.com/zeze322/wt-guided-weaponry/internal/db/mongodb"
+	"github.com/zeze322/wt-guided-weaponry/internal/validation"
 	"github.com/zeze322/wt-guided-weaponry/lib"
 	"github.com/zeze322/wt-guided-weaponry/models"
-	"github.com/zeze322/wt-guided-weaponry/views/aamarh"
-	"github.com/zeze322/wt-guided-weaponry/views/aammcloslosbr"
-	"github.com/zeze322/wt-guided-weaponry/views/aamsarh"
-	"github.com/zeze322/wt-guided-weaponry/views/agmautomatic"
-	"github.com/zeze322/wt-guided-weaponry/views/agmlosbr"
-	"github.com/zeze322/wt-guided-weaponry/views/agmmclos"
-	"github.com/zeze322/wt-guided-weaponry/views/agmsaclos"
-	"github.com/zeze322/wt-guided-weaponry/views/agmsalh"
-	"github.com/zeze322/wt-guided-weaponry/views/allaspect"
-	"github.com/zeze322/wt-guided-weaponry/views/ashm"
-	"github.com/zeze322/wt-guided-weaponry/views/atgmautomatic"
-	"github.com/zeze322/wt-guided-weaponry/views/atgmlosbr"
-	"github.com/zeze322/wt-guided-weaponry/views/atgmmclos"
-	"github.com/zeze322/wt-guided-weaponry/views/atgmsaclos"
 	"github.com/zeze322/wt-guided-weaponry/views/components/search"
-	"github.com/zeze322/wt-guided-weaponry/views/gbu"
 	"github.com/zeze322/wt-guided-weaponry/views/home"
-	"github.com/zeze322/wt-guided-weaponry/views/irheli"
-	"github.com/zeze322/wt-guided-weaponry/views/rearaspect"
-	"github.com/zeze322/wt-guided-weaponry/views/samir"
-	"github.com/zeze322/wt-guided-weaponry/views/samsacloslosbr"
-)
-
-const (
-	irRearAspect   = "ir-rear-aspect"
-	irAllAspect    = "ir-all-aspect"
-	irHeli         = "ir-heli"
-	aamSarh        = "aam-sarh"
-	aamArh         = "aam-arh"
-	aamMclosLosbr  = "aam-mclos-losbr"
-	agmAutomatic   = "agm-automatic"
-	agmSalh        = "agm-salh"
-	agmSaclos      = "agm-saclos"
-	agmMclos       = "agm-mclos"
-	agmLosbr       = "agm-losbr"
-	gbuc           = "gbu"
-	samIr          = "sam-ir"
-	samSaclosLosbr = "sam-saclos-losbr"
-	atgmMclos      = "atgm-mclos"
-	atgmSaclos     = "atgm-saclos"
-	atgmLosbr      = "atgm-losbr"
-	atgmAutomatic  = "atgm-automatic"
-	ashms          = "ashm"
+	"github.com/zeze322/wt-guided-weaponry/views/table"
 )
 
 type SearchResponse struct {
@@ -62,9 +29,33 @@ type WeaponsResponse struct {
 	Weapons []*models.Params `json:"weapons"`
 }
 
+type DeleteWeaponRequest struct {
+	Reason string `json:"reason"`
+}
+
+type FieldsResponse struct {
+	Fields []models.Field `json:"fields"`
+}
+
 func (s *Server) handleHome(w http.ResponseWriter, r *http.Request) error {
+	categories, err := s.mongo.Categories(r.Context())
+	if err != nil {
+		return err
+	}
+
+	// Links such as "/?category=aam-arh" open with the table of a category,
+	// which is where saving the units goes back to.
+	var table string
+	if category := r.FormValue("category"); category != "" {
+		query := url.Values{"name": {category}}
+		if version := r.FormValue("version"); version != "" {
+			query.Set("version", version)
+		}
 
-	return lib.Render(w, r, home.Home())
+		table = "/category?" + query.Encode()
+	}
+
+	return lib.Render(w, r, home.Home(categories, table))
 }
 
 func (s *Server) handleCategories(w http.ResponseWriter, r *http.Request) error {
@@ -76,6 +67,79 @@ func (s *Server) handleCategories(w http.ResponseWriter, r *http.Request) error
 	return lib.WriteJSON(w, http.StatusOK, categories)
 }
 
+func (s *Server) handleInsertCategory(w http.ResponseWriter, r *http.Request) error {
+	req := new(models.Category)
+	if err := decodeBody(r, req); err != nil {
+		return err
+	}
+
+	if err := validateCategory(req); err != nil {
+		return err
+	}
+
+	err := s.mongo.InsertCategory(r.Context(), req)
+	if errors.Is(err, mongodb.ErrConflict) {
+		return lib.Conflict(req.Slug)
+	}
+
+	if err != nil {
+		return err
+	}
+
+	return lib.WriteJSON(w, http.StatusOK, struct{}{})
+}
+
+func (s *Server) handleUpdateCategory(w http.ResponseWriter, r *http.Request) error {
+	slug := chi.URLParam(r, "slug")
+
+	req := new(models.Category)
+	if err := decodeBody(r, req); err != nil {
+		return err
+	}
+
+	if err := validateCategory(req); err != nil {
+		return err
+	}
+
+	err := s.mongo.UpdateCategory(r.Context(), slug, req)
+	if errors.Is(err, mongodb.ErrNothingFound) {
+		return lib.NotFound(slug)
+	}
+
+	if errors.Is(err, mongodb.ErrConflict) {
+		return lib.Conflict(req.Slug)
+	}
+
+	if err != nil {
+		return err
+	}
+
+	return lib.WriteJSON(w, http.StatusOK, struct{}{})
+}
+
+func (s *Server) handleDeleteCategory(w http.ResponseWriter, r *http.Request) error {
+	slug := chi.URLParam(r, "slug")
+
+	err := s.mongo.DeleteCategory(r.Context(), slug)
+	if errors.Is(err, mongodb.ErrNothingFound) {
+		return lib.NotFound(slug)
+	}
+
+	if err != nil {
+		return err
+	}
+
+	return lib.WriteJSON(w, http.StatusOK, struct{}{})
+}
+
+func (s *Server) handleFields(w http.ResponseWriter, r *http.Request) error {
+	if category := r.FormValue("category"); category != "" {
+		return lib.WriteJSON(w, http.StatusOK, FieldsResponse{Fields: models.FieldsFor(category)})
+	}
+
+	return lib.WriteJSON(w, http.StatusOK, FieldsResponse{Fields: models.Fields()})
+}
+
 func (s *Server) handleWeapons(w http.ResponseWriter, r *http.Request) error {
 	if r.FormValue("search") != "" {
 		return s.handleSearchWeapon(w, r)
@@ -92,129 +156,65 @@ func (s *Server) handleWeapons(w http.ResponseWriter, r *http.Request) error {
 func (s *Server) handleWeaponsByCategory(w http.ResponseWriter, r *http.Request) error {
 	category := r.FormValue("name")
 
-	switch category {
-	case irRearAspect:
-		weapons, err := s.mongo.WeaponsByCategory(r.Context(), category)
-		if err != nil {
-			return err
-		}
-		return lib.Render(w, r, rearaspect.RearAspect(weapons))
-	case irAllAspect:
-		weapons, err := s.mongo.WeaponsByCategory(r.Context(), category)
-		if err != nil {
-			return err
-		}
-		return lib.Render(w, r, allaspect.AllAspect(weapons))
-	case irHeli:
-		weapons, err := s.mongo.WeaponsByCategory(r.Context(), category)
-		if err != nil {
-			return err
-		}
-		return lib.Render(w, r, irheli.IrHeli(weapons))
-	case aamSarh:
-		weapons, err := s.mongo.WeaponsByCategory(r.Context(), category)
-		if err != nil {
-			return err
-		}
-		return lib.Render(w, r, aamsarh.AamSarh(weapons))
-	case aamArh:
-		weapons, err := s.mongo.WeaponsByCategory(r.Context(), category)
-		if err != nil {
-			return err
-		}
-		return lib.Render(w, r, aamarh.AamArh(weapons))
-	case aamMclosLosbr:
-		weapons, err := s.mongo.WeaponsByCategory(r.Context(), category)
-		if err != nil {
-			return err
-		}
-		return lib.Render(w, r, aammcloslosbr.AamMclosLosbr(weapons))
-	case agmAutomatic:
-		weapons, err := s.mongo.WeaponsByCategory(r.Context(), category)
-		if err != nil {
-			return err
-		}
-		return lib.Render(w, r, agmautomatic.AgmAutomatic(weapons))
-	case agmSalh:
-		weapons, err := s.mongo.WeaponsByCategory(r.Context(), category)
-		if err != nil {
-			return err
-		}
-		return lib.Render(w, r, agmsalh.AgmSalh(weapons))
-	case agmSaclos:
-		weapons, err := s.mongo.WeaponsByCategory(r.Context(), category)
-		if err != nil {
-			return err
-		}
-		return lib.Render(w, r, agmsaclos.AgmSaclos(weapons))
-	case agmMclos:
-		weapons, err := s.mongo.WeaponsByCategory(r.Context(), category)
-		if err != nil {
-			return err
-		}
-		return lib.Render(w, r, agmmclos.AgmMclos(weapons))
-	case agmLosbr:
-		weapons, err := s.mongo.WeaponsByCategory(r.Context(), category)
-		if err != nil {
-			return err
-		}
-		return lib.Render(w, r, agmlosbr.AgmLosbr(weapons))
-	case gbuc:
-		weapons, err := s.mongo.WeaponsByCategory(r.Context(), category)
-		if err != nil {
-			return err
-		}
-		return lib.Render(w, r, gbu.Gbu(weapons))
-	case samIr:
-		weapons, err := s.mongo.WeaponsByCategory(r.Context(), category)
-		if err != nil {
-			return err
-		}
-		return lib.Render(w, r, samir.SamIr(weapons))
-	case samSaclosLosbr:
-		weapons, err := s.mongo.WeaponsByCategory(r.Context(), category)
-		if err != nil {
-			return err
-		}
-		return lib.Render(w, r, samsacloslosbr.SamSaclosLosbr(weapons))
-	case atgmMclos:
-		weapons, err := s.mongo.WeaponsByCategory(r.Context(), category)
-		if err != nil {
-			return err
-		}
-		return lib.Render(w, r, atgmmclos.AtgmMclos(weapons))
-	case atgmSaclos:
-		weapons, err := s.mongo.WeaponsByCategory(r.Context(), category)
-		if err != nil {
-			return err
-		}
-		return lib.Render(w, r, atgmsaclos.AtgmSaclos(weapons))
-	case atgmLosbr:
-		weapons, err := s.mongo.WeaponsByCategory(r.Context(), category)
-		if err != nil {
-			return err
-		}
-		return lib.Render(w, r, atgmlosbr.AtgmLosbr(weapons))
-	case atgmAutomatic:
-		weapons, err := s.mongo.WeaponsByCategory(r.Context(), category)
-		if err != nil {
-			return err
-		}
-		return lib.Render(w, r, atgmautomatic.AtgmAutomatic(weapons))
-	case ashms:
+	_, err := s.mongo.Category(r.Context(), category)
+	if errors.Is(err, mongodb.ErrNothingFound) {
+		return lib.InvalidRequest(category)
+	}
+
+	if err != nil {
+		return err
+	}
+
+	// Categories added without a field list show every parameter.
+	fields := models.FieldsFor(category)
+	if len(fields) == 0 {
+		fields = models.Fields()
+	}
+
+	versions, err := s.mongo.GameVersions(r.Context())
+	if err != nil {
+		return err
+	}
+
+	units, err := pageUnits(r)
+	if err != nil {
+		return err
+	}
+
+	fields = units.Fields(fields)
+
+	query := url.Values{"category": {category}}
+
+	// A registered category may have no weapons yet, or may have had none
+	// in an old version. The table is still rendered so the version picker
+	// stays on the page.
+	version := r.FormValue("version")
+	if version == "" {
 		weapons, err := s.mongo.WeaponsByCategory(r.Context(), category)
-		if err != nil {
+		if err != nil && !errors.Is(err, mongodb.ErrNothingFound) {
 			return err
 		}
-		return lib.Render(w, r, ashm.Ashm(weapons))
+
+		return lib.Render(w, r, table.Table(fields, units.Weapons(weapons), query, versions, units))
 	}
 
-	return nil
+	query.Set("version", version)
+
+	weapons, err := s.mongo.WeaponsByCategoryAt(r.Context(), category, version)
+	if errors.Is(err, mongodb.ErrUnknownVersion) {
+		return lib.InvalidRequest(version)
+	}
+
+	if err != nil && !errors.Is(err, mongodb.ErrNothingFound) {
+		return err
+	}
+
+	return lib.Render(w, r, table.Table(fields, units.Weapons(weapons), query, versions, units))
 }
 
 func (s *Server) handleInsertWeapon(w http.ResponseWriter, r *http.Request) error {
 	if r.Method != "POST" {
-		return fmt.Errorf("method not allowed: %s", r.Method)
+		return lib.MethodNotAllowed(r.Method)
 	}
 
 	req := new(models.Params)
@@ -222,8 +222,17 @@ func (s *Server) handleInsertWeapon(w http.ResponseWriter, r *http.Request) erro
 		return err
 	}
 
-	if err := s.mongo.InsertWeapon(r.Context(), req); err != nil {
-		return lib.InvalidInsertData(req.Name)
+	if err := s.validateWeapon(r.Context(), req); err != nil {
+		return err
+	}
+
+	err := s.mongo.InsertWeapon(r.Context(), req)
+	if errors.Is(err, mongodb.ErrConflict) {
+		return lib.Conflict(req.Name)
+	}
+
+	if err != nil {
+		return err
 	}
 
 	return lib.WriteJSON(w, http.StatusOK, struct{}{})
@@ -231,23 +240,187 @@ func (s *Server) handleInsertWeapon(w http.ResponseWriter, r *http.Request) erro
 
 func (s *Server) handleUpdateWeapon(w http.ResponseWriter, r *http.Request) error {
 	if r.Method != "PUT" {
-		return fmt.Errorf("method not allowed: %s", r.Method)
+		return lib.MethodNotAllowed(r.Method)
 	}
 
-	name := chi.URLParam(r, "name")
+	name, err := s.weaponName(r.Context(), urlParam(r, "slug"))
+	if err != nil {
+		return err
+	}
 
 	req := new(models.Params)
 	if err := json.NewDecoder(r.Body).Decode(req); err != nil {
 		return err
 	}
 
-	if err := s.mongo.UpdateWeapon(r.Context(), name, req); err != nil {
-		return lib.InvalidUpdateData(name)
+	if err := s.validateWeapon(r.Context(), req); err != nil {
+		return err
+	}
+
+	err = s.mongo.UpdateWeapon(r.Context(), name, req)
+	if errors.Is(err, mongodb.ErrNothingFound) {
+		return lib.NotFound(name)
+	}
+
+	if errors.Is(err, mongodb.ErrConflict) {
+		return lib.Conflict(req.Name)
+	}
+
+	if err != nil {
+		return err
+	}
+
+	return lib.WriteJSON(w, http.StatusOK, struct{}{})
+}
+
+func (s *Server) handlePatchWeapon(w http.ResponseWriter, r *http.Request) error {
+	name, err := s.weaponName(r.Context(), urlParam(r, "slug"))
+	if err != nil {
+		return err
+	}
+
+	weapon, err := s.patchWeapon(r, name)
+	if err != nil {
+		return err
+	}
+
+	return lib.WriteJSON(w, http.StatusOK, weapon)
+}
+
+// patchWeapon applies the merge patch or JSON Patch in the request body to
+// the named weapon, validates the result and saves it.
+func (s *Server) patchWeapon(r *http.Request, name string) (*models.Params, error) {
+	mediaType, _, err := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	if err != nil {
+		mediaType = lib.MergePatchType
+	}
+
+	var apply func(doc, patch []byte) ([]byte, error)
+
+	switch mediaType {
+	case lib.MergePatchType, "application/json":
+		apply = lib.MergePatch
+	case lib.JSONPatchType:
+		apply = lib.JSONPatch
+	default:
+		return nil, lib.NewApiError(http.StatusUnsupportedMediaType, fmt.Errorf("unsupported patch type %s", mediaType))
+	}
+
+	patch, err := io.ReadAll(r.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	weapon, err := s.mongo.Weapon(r.Context(), name)
+	if errors.Is(err, mongodb.ErrNothingFound) {
+		return nil, lib.NotFound(name)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	doc, err := json.Marshal(weapon)
+	if err != nil {
+		return nil, err
+	}
+
+	patched, err := apply(doc, patch)
+	if err != nil {
+		return nil, lib.NewApiError(http.StatusBadRequest, err)
+	}
+
+	if err := validation.Keys(patched); err != nil {
+		return nil, lib.NewApiError(http.StatusBadRequest, err)
+	}
+
+	req := new(models.Params)
+	if err := json.Unmarshal(patched, req); err != nil {
+		return nil, lib.NewApiError(http.StatusBadRequest, err)
+	}
+
+	if err := s.validateWeapon(r.Context(), req); err != nil {
+		return nil, err
+	}
+
+	err = s.mongo.UpdateWeapon(r.Context(), name, req)
+	if errors.Is(err, mongodb.ErrConflict) {
+		return nil, lib.Conflict(req.Name)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return models.NewWeapon(req), nil
+}
+
+// validateWeapon runs the validation layer and turns its findings into an
+// APIError listing every invalid field.
+func (s *Server) validateWeapon(ctx context.Context, params *models.Params) error {
+	errs, err := validation.Weapon(ctx, s.mongo, params)
+	if err != nil {
+		return err
+	}
+
+	if len(errs) > 0 {
+		return lib.InvalidFields(errs)
+	}
+
+	return nil
+}
+
+func (s *Server) handleDeleteWeapon(w http.ResponseWriter, r *http.Request) error {
+	name, err := s.weaponName(r.Context(), urlParam(r, "slug"))
+	if err != nil {
+		return err
+	}
+
+	// The reason is optional, so an empty body is fine.
+	req := new(DeleteWeaponRequest)
+	if err := json.NewDecoder(r.Body).Decode(req); err != nil && !errors.Is(err, io.EOF) {
+		return err
+	}
+
+	err = s.mongo.DeleteWeapon(r.Context(), name, req.Reason)
+	if errors.Is(err, mongodb.ErrNothingFound) {
+		return lib.NotFound(name)
+	}
+
+	if err != nil {
+		return err
 	}
 
 	return lib.WriteJSON(w, http.StatusOK, struct{}{})
 }
 
+func (s *Server) handleRestoreWeapon(w http.ResponseWriter, r *http.Request) error {
+	name, err := s.deletedWeaponName(r.Context(), urlParam(r, "slug"))
+	if err != nil {
+		return err
+	}
+
+	err = s.mongo.RestoreWeapon(r.Context(), name)
+	if errors.Is(err, mongodb.ErrNothingFound) {
+		return lib.NewApiError(http.StatusNotFound, fmt.Errorf("%s isn't deleted", name))
+	}
+
+	if err != nil {
+		return err
+	}
+
+	return lib.WriteJSON(w, http.StatusOK, struct{}{})
+}
+
+func (s *Server) handleDeletedWeapons(w http.ResponseWriter, r *http.Request) error {
+	weapons, err := s.mongo.DeletedWeapons(r.Context())
+	if err != nil {
+		return err
+	}
+
+	return lib.WriteJSON(w, http.StatusOK, WeaponsResponse{Weapons: weapons})
+}
+
 func (s *Server) handleSearchWeapon(w http.ResponseWriter, r *http.Request) error {
 	keyWord := r.FormValue("search")
 
@@ -0,0 +1,170 @@
+package api
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/zeze322/wt-guided-weaponry/lib"
+	"github.com/zeze322/wt-guided-weaponry/models"
+)
+
+// TestPatchStoredWeapon patches weapons as the store returns them, with
+// the slug the store gave them.
+func TestPatchStoredWeapon(t *testing.T) {
+	handler, store := newTestServer(t)
+
+	for _, target := range []string{"/weapon/AIM-9L", "/api/v1/weapons/AIM-9L"} {
+		res, body := do(t, handler, http.MethodPatch, target, lib.MergePatchType, `{"physicalProp": {"length": "2.9 m"}}`)
+		if res.StatusCode != http.StatusOK {
+			t.Fatalf("PATCH %s returned %d: %s", target, res.StatusCode, body)
+		}
+	}
+
+	weapon, err := store.Weapon(context.Background(), "AIM-9L")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if weapon.Length.String() != "2.9 m" || weapon.Slug != "aim-9l" {
+		t.Fatalf("PATCH stored length %q under slug %q, want 2.9 m under aim-9l", weapon.Length, weapon.Slug)
+	}
+}
+
+// TestPatchIgnoresSlug checks that a patch can't move a weapon's page.
+func TestPatchIgnoresSlug(t *testing.T) {
+	handler, store := newTestServer(t)
+
+	res, body := do(t, handler, http.MethodPatch, "/api/v1/weapons/AIM-9L", lib.MergePatchType, `{"slug": "aim-9b"}`)
+	if res.StatusCode != http.StatusOK {
+		t.Fatalf("PATCH returned %d: %s", res.StatusCode, body)
+	}
+
+	weapon, err := store.WeaponBySlug(context.Background(), "aim-9l")
+	if err != nil || weapon.Name != "AIM-9L" {
+		t.Fatalf("WeaponBySlug(aim-9l) returned %v, %v after patching the slug", weapon, err)
+	}
+}
+
+// TestWeaponPathBySlug checks that every method on /weapon/{slug} finds
+// the weapon the page shows, by slug after a rename and by name.
+func TestWeaponPathBySlug(t *testing.T) {
+	handler, store := newTestServer(t)
+	ctx := context.Background()
+
+	res, body := do(t, handler, http.MethodPatch, "/weapon/aim-9l", lib.MergePatchType, `{"name": "AIM-9L/I"}`)
+	if res.StatusCode != http.StatusOK {
+		t.Fatalf("PATCH by slug returned %d: %s", res.StatusCode, body)
+	}
+
+	res, body = do(t, handler, http.MethodPatch, "/weapon/aim-9l", lib.MergePatchType, `{"physicalProp": {"length": "2.9 m"}}`)
+	if res.StatusCode != http.StatusOK {
+		t.Fatalf("PATCH by slug after a rename returned %d: %s", res.StatusCode, body)
+	}
+
+	weapon, err := store.WeaponBySlug(ctx, "aim-9l")
+	if err != nil || weapon.Name != "AIM-9L/I" || weapon.Length.String() != "2.9 m" {
+		t.Fatalf("WeaponBySlug(aim-9l) returned %+v, %v", weapon, err)
+	}
+
+	res, body = do(t, handler, http.MethodDelete, "/weapon/aim-9l", "application/json", "")
+	if res.StatusCode != http.StatusOK {
+		t.Fatalf("DELETE by slug returned %d: %s", res.StatusCode, body)
+	}
+
+	if _, err := store.Weapon(ctx, "AIM-9L/I"); err == nil {
+		t.Fatal("DELETE by slug left the weapon in place")
+	}
+
+	res, body = do(t, handler, http.MethodPost, "/weapon/aim-9l/restore", "application/json", "")
+	if res.StatusCode != http.StatusOK {
+		t.Fatalf("restore by slug returned %d: %s", res.StatusCode, body)
+	}
+
+	// Names still work, as they do for the page.
+	res, body = do(t, handler, http.MethodDelete, "/weapon/AIM-9B", "application/json", "")
+	if res.StatusCode != http.StatusOK {
+		t.Fatalf("DELETE by name returned %d: %s", res.StatusCode, body)
+	}
+
+	res, _ = do(t, handler, http.MethodDelete, "/weapon/nope", "application/json", "")
+	if res.StatusCode != http.StatusNotFound {
+		t.Fatalf("DELETE of a missing weapon returned %d, want 404", res.StatusCode)
+	}
+}
+
+// TestEmptyCategory renders the table of a registered category without
+// weapons instead of failing.
+func TestEmptyCategory(t *testing.T) {
+	handler, _ := newTestServer(t)
+
+	res, body := do(t, handler, http.MethodGet, "/category?name="+models.CategoryAShM, "", "")
+	if res.StatusCode != http.StatusOK {
+		t.Fatalf("GET an empty category returned %d: %s", res.StatusCode, body)
+	}
+
+	res, _ = do(t, handler, http.MethodGet, "/category?name=nope", "", "")
+	if res.StatusCode != http.StatusBadRequest {
+		t.Fatalf("GET an unknown category returned %d, want 400", res.StatusCode)
+	}
+}
+
+// TestPatchValueObject checks that the object form of a value can't carry a
+// kind or magnitude its text doesn't say.
+func TestPatchValueObject(t *testing.T) {
+	handler, store := newTestServer(t)
+
+	for _, patch := range []string{
+		`{"physicalProp": {"mass": {"text": "abc", "kind": "number", "magnitude": 5}}}`,
+		`{"physicalProp": {"length": {"magnitude": 3}}}`,
+	} {
+		res, body := do(t, handler, http.MethodPatch, "/api/v1/weapons/AIM-9L", lib.MergePatchType, patch)
+		if res.StatusCode != http.StatusBadRequest {
+			t.Errorf("PATCH %s returned %d, want 400: %s", patch, res.StatusCode, body)
+		}
+	}
+
+	weapon, err := store.Weapon(context.Background(), "AIM-9L")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if weapon.Mass.String() != "85.5" || weapon.Length.Magnitude != 2.87 {
+		t.Fatalf("rejected patches stored mass %q and length %g", weapon.Mass, weapon.Length.Magnitude)
+	}
+}
+
+// TestCategoryErrors checks that the category handlers validate their body
+// and tell a taken slug from a missing category.
+func TestCategoryErrors(t *testing.T) {
+	handler, _ := newTestServer(t)
+
+	category := func(slug string) string {
+		return `{"slug": "` + slug + `", "name": "AAM (test)", "class": "AAM", "guidance": "IR", "sortOrder": 100}`
+	}
+
+	tests := []struct {
+		method, target, body string
+		want                 int
+	}{
+		{http.MethodPost, "/dev/category", category("aam-test"), http.StatusOK},
+		{http.MethodPost, "/dev/category", category("aam-test"), http.StatusConflict},
+		{http.MethodPost, "/dev/category", category("AAM test"), http.StatusBadRequest},
+		{http.MethodPost, "/dev/category", category("a&name=b"), http.StatusBadRequest},
+		{http.MethodPost, "/dev/category", `{"slug": "aam-other"}`, http.StatusBadRequest},
+		{http.MethodPost, "/dev/category", `{`, http.StatusBadRequest},
+		{http.MethodPut, "/dev/category/nope", category("nope"), http.StatusNotFound},
+		{http.MethodPut, "/dev/category/aam-test", category(models.CategoryAAMARH), http.StatusConflict},
+		{http.MethodPut, "/dev/category/aam-test", category("aam-Test"), http.StatusBadRequest},
+		{http.MethodPut, "/dev/category/aam-test", category("aam-renamed"), http.StatusOK},
+		{http.MethodDelete, "/dev/category/aam-test", "", http.StatusNotFound},
+		{http.MethodDelete, "/dev/category/aam-renamed", "", http.StatusOK},
+	}
+
+	for _, tt := range tests {
+		res, body := do(t, handler, tt.method, tt.target, "application/json", tt.body)
+		if res.StatusCode != tt.want {
+			t.Errorf("%s %s %s returned %d, want %d: %s", tt.method, tt.target, tt.body, res.StatusCode, tt.want, body)
+		}
+	}
+}
@@ -0,0 +1,51 @@
+package api
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/zeze322/wt-guided-weaponry/internal/importer"
+	"github.com/zeze322/wt-guided-weaponry/lib"
+)
+
+// maxImportSize bounds the body of an import.
+const maxImportSize = 32 << 20
+
+func (s *Server) handleAPIImportWeapons(w http.ResponseWriter, r *http.Request) error {
+	format, err := importer.FormatOf(r.Header.Get("Content-Type"))
+	if err != nil {
+		return lib.NewApiError(http.StatusUnsupportedMediaType, err)
+	}
+
+	query := r.URL.Query()
+	opts := importer.Options{}
+
+	if opts.Mode, err = importer.ParseMode(query.Get("mode")); err != nil {
+		return lib.InvalidFields([]lib.FieldError{{Field: "mode", Msg: err.Error()}})
+	}
+
+	if dryRun := query.Get("dryRun"); dryRun != "" {
+		if opts.DryRun, err = strconv.ParseBool(dryRun); err != nil {
+			return lib.InvalidFields([]lib.FieldError{{Field: "dryRun", Msg: "dryRun must be true or false"}})
+		}
+	}
+
+	records, err := importer.Decode(http.MaxBytesReader(w, r.Body, maxImportSize), format)
+	if err != nil {
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			return lib.NewApiError(http.StatusRequestEntityTooLarge, fmt.Errorf("imports are limited to %d MB", maxImportSize>>20))
+		}
+
+		return lib.NewApiError(http.StatusBadRequest, err)
+	}
+
+	report, err := importer.Run(r.Context(), s.mongo, records, opts)
+	if err != nil {
+		return err
+	}
+
+	return lib.WriteData(w, http.StatusOK, report)
+}
@@ -0,0 +1,339 @@
+package api
+
+import (
+	"net/http"
+	"reflect"
+
+	"github.com/zeze322/wt-guided-weaponry/internal/diff"
+	"github.com/zeze322/wt-guided-weaponry/internal/importer"
+	"github.com/zeze322/wt-guided-weaponry/internal/openapi"
+	"github.com/zeze322/wt-guided-weaponry/lib"
+	"github.com/zeze322/wt-guided-weaponry/models"
+)
+
+// route is an endpoint of the versioned API together with its handler. The
+// same table registers the handlers and documents them, so the OpenAPI
+// document can't miss a route.
+type route struct {
+	openapi.Endpoint
+	handler lib.APIFunc
+}
+
+var listQuery = []openapi.Param{
+	{Name: "category", Description: "Only list weapons of this category."},
+	{Name: "filter", Description: `Filter expression, e.g. flightProp.maximumSpeed > 800 and guidanceProp.IRCCM = Yes.`},
+	{Name: "sort", Description: `Key of a numeric parameter to sort by, prefixed with "-" for descending order. Weapons are listed by name by default.`},
+	{Name: "fields", Description: "Comma-separated keys of the parameters to return besides the name."},
+	{Name: "limit", Type: "integer", Description: "Page size, 50 by default and 200 at most."},
+	{Name: "cursor", Description: "nextCursor of the previous page. The next page starts after the last weapon of the previous one, so weapons added or deleted in between don't shift it."},
+	unitsParam,
+}
+
+var unitsParam = openapi.Param{
+	Name:        "units",
+	Description: `Units to return values in: a unit system (si, imperial, aviation) followed by units per quantity, e.g. "aviation" or "si,speed:mach". See GET /units. Filters and sorting use the stored units.`,
+}
+
+var importQuery = []openapi.Param{
+	{Name: "mode", Description: "insert (default) skips existing weapons, upsert merges into them, replace overwrites them."},
+	{Name: "dryRun", Type: "boolean", Description: "Validate and report without writing."},
+}
+
+func (s *Server) routes() []route {
+	return []route{
+		{openapi.Endpoint{
+			Method:   http.MethodGet,
+			Path:     "/weapons",
+			Summary:  "List weapons",
+			Tag:      "weapons",
+			Query:    listQuery,
+			Response: []models.Params{},
+			Meta:     ListMeta{},
+			Errors:   []int{http.StatusBadRequest, http.StatusNotFound},
+		}, s.handleAPIWeapons},
+		{openapi.Endpoint{
+			Method:   http.MethodPost,
+			Path:     "/weapons",
+			Summary:  "Create a weapon",
+			Tag:      "weapons",
+			Request:  models.Params{},
+			Response: models.Params{},
+			Status:   http.StatusCreated,
+			Errors:   []int{http.StatusBadRequest, http.StatusConflict},
+		}, s.handleAPICreateWeapon},
+		{openapi.Endpoint{
+			Method:       http.MethodPost,
+			Path:         "/weapons/import",
+			Summary:      "Import weapons",
+			Description:  "Imports a JSON array, NDJSON or CSV file of weapons and reports the outcome of every record.",
+			Tag:          "weapons",
+			Query:        importQuery,
+			Request:      []models.Params{},
+			RequestTypes: []string{"application/json", "application/x-ndjson", "text/csv"},
+			Response:     importer.Report{},
+			Errors:       []int{http.StatusBadRequest, http.StatusRequestEntityTooLarge, http.StatusUnsupportedMediaType},
+		}, s.handleAPIImportWeapons},
+		{openapi.Endpoint{
+			Method:  http.MethodGet,
+			Path:    "/weapons/{name}",
+			Summary: "Get a weapon",
+			Tag:     "weapons",
+			Query: []openapi.Param{
+				{Name: "version", Description: "Game version to read the weapon at, the current numbers when empty."},
+				unitsParam,
+			},
+			Response: models.Params{},
+			Errors:   []int{http.StatusBadRequest, http.StatusNotFound},
+		}, s.handleAPIWeapon},
+		{openapi.Endpoint{
+			Method:      http.MethodGet,
+			Path:        "/weapons/by-slug/{slug}",
+			Summary:     "Get the detail of a weapon",
+			Description: "Looks a weapon up by the slug of its page, which stays the same when it's renamed, and returns it with its category, the most similar weapons of the category and links to its pages.",
+			Tag:         "weapons",
+			Query:       []openapi.Param{unitsParam},
+			Response:    WeaponDetail{},
+			Errors:      []int{http.StatusBadRequest, http.StatusNotFound},
+		}, s.handleAPIWeaponBySlug},
+		{openapi.Endpoint{
+			Method:   http.MethodPut,
+			Path:     "/weapons/{name}",
+			Summary:  "Replace a weapon",
+			Tag:      "weapons",
+			Request:  models.Params{},
+			Response: models.Params{},
+			Errors:   []int{http.StatusBadRequest, http.StatusNotFound, http.StatusConflict},
+		}, s.handleAPIUpdateWeapon},
+		{openapi.Endpoint{
+			Method:       http.MethodPatch,
+			Path:         "/weapons/{name}",
+			Summary:      "Patch a weapon",
+			Description:  "Accepts a JSON merge patch (RFC 7386) or a JSON patch (RFC 6902).",
+			Tag:          "weapons",
+			Request:      map[string]any{},
+			RequestTypes: []string{lib.MergePatchType, lib.JSONPatchType},
+			Response:     models.Params{},
+			Errors:       []int{http.StatusBadRequest, http.StatusNotFound, http.StatusConflict, http.StatusUnsupportedMediaType},
+		}, s.handleAPIPatchWeapon},
+		{openapi.Endpoint{
+			Method:  http.MethodDelete,
+			Path:    "/weapons/{name}",
+			Summary: "Delete a weapon",
+			Tag:     "weapons",
+			Status:  http.StatusNoContent,
+			Errors:  []int{http.StatusBadRequest, http.StatusNotFound},
+
+			Request:         DeleteWeaponRequest{},
+			OptionalRequest: true,
+		}, s.handleAPIDeleteWeapon},
+		{openapi.Endpoint{
+			Method:   http.MethodPost,
+			Path:     "/weapons/{name}/restore",
+			Summary:  "Restore a deleted weapon",
+			Tag:      "weapons",
+			Response: models.Params{},
+			Errors:   []int{http.StatusNotFound},
+		}, s.handleAPIRestoreWeapon},
+		{openapi.Endpoint{
+			Method:      http.MethodGet,
+			Path:        "/weapons/{name}/history",
+			Summary:     "Get the history of a weapon",
+			Description: "Lists the snapshots of the weapon, one per game version it changed in, oldest first.",
+			Tag:         "versions",
+			Response:    []models.Snapshot{},
+			Errors:      []int{http.StatusNotFound},
+		}, s.handleAPIWeaponHistory},
+		{openapi.Endpoint{
+			Method:   http.MethodGet,
+			Path:     "/search",
+			Summary:  "Search weapons by name",
+			Tag:      "weapons",
+			Query:    []openapi.Param{{Name: "q", Description: "Part of the name.", Required: true}},
+			Response: []models.Name{},
+			Errors:   []int{http.StatusBadRequest},
+		}, s.handleAPISearch},
+		{openapi.Endpoint{
+			Method:   http.MethodGet,
+			Path:     "/categories",
+			Summary:  "List categories",
+			Tag:      "categories",
+			Response: []models.Category{},
+		}, s.handleAPICategories},
+		{openapi.Endpoint{
+			Method:   http.MethodPost,
+			Path:     "/categories",
+			Summary:  "Create a category",
+			Tag:      "categories",
+			Request:  models.Category{},
+			Response: models.Category{},
+			Status:   http.StatusCreated,
+			Errors:   []int{http.StatusBadRequest, http.StatusConflict},
+		}, s.handleAPICreateCategory},
+		{openapi.Endpoint{
+			Method:   http.MethodGet,
+			Path:     "/categories/{slug}",
+			Summary:  "Get a category",
+			Tag:      "categories",
+			Response: models.Category{},
+			Errors:   []int{http.StatusNotFound},
+		}, s.handleAPICategory},
+		{openapi.Endpoint{
+			Method:   http.MethodPut,
+			Path:     "/categories/{slug}",
+			Summary:  "Replace a category",
+			Tag:      "categories",
+			Request:  models.Category{},
+			Response: models.Category{},
+			Errors:   []int{http.StatusBadRequest, http.StatusNotFound, http.StatusConflict},
+		}, s.handleAPIUpdateCategory},
+		{openapi.Endpoint{
+			Method:  http.MethodDelete,
+			Path:    "/categories/{slug}",
+			Summary: "Delete a category",
+			Tag:     "categories",
+			Status:  http.StatusNoContent,
+			Errors:  []int{http.StatusNotFound},
+		}, s.handleAPIDeleteCategory},
+		{openapi.Endpoint{
+			Method:   http.MethodGet,
+			Path:     "/versions",
+			Summary:  "List game versions",
+			Tag:      "versions",
+			Response: []models.GameVersion{},
+		}, s.handleAPIGameVersions},
+		{openapi.Endpoint{
+			Method:      http.MethodPost,
+			Path:        "/versions",
+			Summary:     "Add a game version",
+			Description: "Weapons written from now on are tagged with the latest version. The date defaults to now.",
+			Tag:         "versions",
+			Request:     models.GameVersion{},
+			Response:    models.GameVersion{},
+			Status:      http.StatusCreated,
+			Errors:      []int{http.StatusBadRequest, http.StatusConflict},
+		}, s.handleAPICreateGameVersion},
+		{openapi.Endpoint{
+			Method:      http.MethodGet,
+			Path:        "/changes",
+			Summary:     "Compare two game versions",
+			Description: "Lists the weapons added and removed between two game versions and every changed parameter with its old and new value and percentage delta, grouped by category. to defaults to the latest version and from to the one before it.",
+			Tag:         "versions",
+			Query: []openapi.Param{
+				{Name: "from", Description: "The older game version."},
+				{Name: "to", Description: "The newer game version."},
+			},
+			Response: diff.Report{},
+			Errors:   []int{http.StatusBadRequest},
+		}, s.handleAPIChanges},
+		{openapi.Endpoint{
+			Method:  http.MethodGet,
+			Path:    "/fields",
+			Summary: "List weapon parameters",
+			Tag:     "fields",
+			Query: []openapi.Param{
+				{Name: "category", Description: "Only list the parameters of this category."},
+				unitsParam,
+			},
+			Response: []models.Field{},
+			Errors:   []int{http.StatusBadRequest},
+		}, s.handleAPIFields},
+		{openapi.Endpoint{
+			Method:      http.MethodGet,
+			Path:        "/units",
+			Summary:     "List units",
+			Description: "Lists the unit systems and the units each quantity can be converted to with the units query parameter.",
+			Tag:         "fields",
+			Response:    UnitsResponse{},
+		}, s.handleAPIUnits},
+	}
+}
+
+// OpenAPI documents the versioned API.
+func OpenAPI() *openapi.Document {
+	g := &openapi.Generator{
+		Info: openapi.Info{
+			Title:       "WT guided weaponry",
+			Description: "Guided weapons of War Thunder and their parameters.",
+			Version:     "1",
+		},
+		ServerURL: "/api/v1",
+		Tags: []openapi.Tag{
+			{Name: "weapons"},
+			{Name: "categories"},
+			{Name: "fields", Description: "The parameter registry."},
+			{Name: "versions", Description: "Game versions and the history of weapons across them."},
+		},
+		Schemas: map[string]*openapi.Schema{
+			"Value":     valueSchema,
+			"ValueKind": kindSchema,
+			"Better":    betterSchema,
+		},
+		Overrides: map[reflect.Type]*openapi.Schema{
+			reflect.TypeOf(models.Value{}):       {Ref: "#/components/schemas/Value"},
+			reflect.TypeOf(models.ValueKind("")): {Ref: "#/components/schemas/ValueKind"},
+			reflect.TypeOf(models.Better("")):    {Ref: "#/components/schemas/Better"},
+		},
+		Envelope: envelopeSchema,
+		Error:    lib.Envelope{Error: &lib.APIError{}},
+	}
+
+	var endpoints []openapi.Endpoint
+	for _, route := range (&Server{}).routes() {
+		endpoints = append(endpoints, route.Endpoint)
+	}
+
+	return g.Build(endpoints)
+}
+
+var kindSchema = &openapi.Schema{
+	Type: "string",
+	Enum: []string{
+		string(models.KindNumber),
+		string(models.KindRange),
+		string(models.KindTuple),
+		string(models.KindBool),
+		string(models.KindText),
+	},
+}
+
+var betterSchema = &openapi.Schema{
+	Description: "Whether higher or lower values of a parameter are better.",
+	Type:        "string",
+	Enum:        []string{string(models.HigherIsBetter), string(models.LowerIsBetter)},
+}
+
+// valueSchema describes models.Value, which is written as an object but
+// also read from plain strings and numbers.
+var valueSchema = &openapi.Schema{
+	Description: `A parameter value. Strings and numbers such as "85 kg" or 85 are parsed, responses use the object form.`,
+	OneOf: []*openapi.Schema{
+		{Type: "string"},
+		{Type: "number"},
+		{
+			Type: "object",
+			Properties: map[string]*openapi.Schema{
+				"text":      {Type: "string"},
+				"kind":      {Ref: "#/components/schemas/ValueKind"},
+				"magnitude": {Type: "number"},
+				"unit":      {Type: "string"},
+				"range":     {Type: "array", Items: &openapi.Schema{Type: "number"}},
+				"tuple":     {Type: "array", Items: &openapi.Schema{Type: "number"}},
+			},
+			Required: []string{"kind", "magnitude", "text"},
+		},
+	},
+}
+
+func envelopeSchema(data, meta *openapi.Schema) *openapi.Schema {
+	s := &openapi.Schema{
+		Type:       "object",
+		Properties: map[string]*openapi.Schema{"data": data},
+		Required:   []string{"data"},
+	}
+
+	if meta != nil {
+		s.Properties["meta"] = meta
+	}
+
+	return s
+}
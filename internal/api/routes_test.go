@@ -0,0 +1,71 @@
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/zeze322/wt-guided-weaponry/internal/db/memory"
+)
+
+// TestOpenAPIUpToDate fails when a route or model changed without the
+// committed document being regenerated with make openapi.
+func TestOpenAPIUpToDate(t *testing.T) {
+	want, err := json.MarshalIndent(OpenAPI(), "", "  ")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want = append(want, '\n')
+
+	got, err := os.ReadFile("../../docs/openapi.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !bytes.Equal(got, want) {
+		t.Fatal("docs/openapi.json is out of date, run make openapi")
+	}
+}
+
+// TestOpenAPIRoutes checks that the routes the v1 router serves and the
+// operations the document describes are the same.
+func TestOpenAPIRoutes(t *testing.T) {
+	doc := OpenAPI()
+
+	served := map[string]bool{}
+
+	router := NewServer(":0", memory.New()).v1().(chi.Routes)
+
+	err := chi.Walk(router, func(method, route string, _ http.Handler, _ ...func(http.Handler) http.Handler) error {
+		op := strings.ToLower(method) + " " + route
+		served[op] = true
+
+		item, ok := doc.Paths[route]
+		if !ok || item == nil || (*item)[strings.ToLower(method)] == nil {
+			t.Errorf("%s %s is served but not documented", method, route)
+		}
+
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(served) == 0 {
+		t.Fatal("the v1 router serves no route")
+	}
+
+	for path, item := range doc.Paths {
+		for method := range *item {
+			if !served[method+" "+path] {
+				t.Errorf("%s %s is documented but not served", strings.ToUpper(method), path)
+			}
+		}
+	}
+}
@@ -8,14 +8,17 @@ import (
 
 	"github.com/go-chi/chi/middleware"
 	"github.com/go-chi/chi/v5"
+	"github.com/graphql-go/graphql"
 
 	"github.com/zeze322/wt-guided-weaponry/internal/db/mongodb"
+	"github.com/zeze322/wt-guided-weaponry/internal/gql"
 	"github.com/zeze322/wt-guided-weaponry/lib"
 )
 
 type Server struct {
-	port  string
-	mongo mongodb.Store
+	port    string
+	mongo   mongodb.Store
+	graphql graphql.Schema
 }
 
 func NewServer(port string, mongo mongodb.Store) *Server {
@@ -26,27 +29,65 @@ func NewServer(port string, mongo mongodb.Store) *Server {
 }
 
 func (s *Server) Run() error {
+	router, err := s.Handler()
+	if err != nil {
+		return err
+	}
+
+	log.Printf("Running on http://localhost%s", s.port)
+
+	if err := http.ListenAndServe(s.port, middleware.Logger(router)); err != nil {
+		return fmt.Errorf("failed to start server: %s", err)
+	}
+
+	return nil
+}
+
+// Handler builds the router of the site and the API.
+func (s *Server) Handler() (http.Handler, error) {
+	schema, err := gql.NewSchema(s.mongo)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build graphql schema: %s", err)
+	}
+
+	s.graphql = schema
+
 	router := chi.NewRouter()
 
-	router.Use(middleware.Logger)
+	router.MethodNotAllowed(lib.MakeHTTP(func(w http.ResponseWriter, r *http.Request) error {
+		return lib.MethodNotAllowed(r.Method)
+	}))
 
 	router.Handle("/*", public())
 
 	router.Get("/", lib.MakeHTTP(s.handleHome))
 	router.Get("/dev/category", lib.MakeHTTP(s.handleCategories))
+	router.Post("/dev/category", lib.MakeHTTP(s.handleInsertCategory))
+	router.Put("/dev/category/{slug}", lib.MakeHTTP(s.handleUpdateCategory))
+	router.Delete("/dev/category/{slug}", lib.MakeHTTP(s.handleDeleteCategory))
 	router.Get("/dev/weapons", lib.MakeHTTP(s.handleWeapons))
+	router.Get("/dev/weapons/deleted", lib.MakeHTTP(s.handleDeletedWeapons))
+	router.Get("/api/fields", lib.MakeHTTP(s.handleFields))
+	router.Get("/api/openapi.json", lib.MakeHTTP(s.handleOpenAPI))
+	router.Get("/api/docs", lib.MakeHTTP(s.handleDocs))
+	router.Get("/api/graphql", lib.MakeHTTP(s.handleGraphQL))
+	router.Post("/api/graphql", lib.MakeHTTP(s.handleGraphQL))
+	router.Mount("/api/v1", s.v1())
 	router.Get("/category", lib.MakeHTTP(s.handleWeaponsByCategory))
+	router.Get("/changes", lib.MakeHTTP(s.handleChanges))
+	router.Get("/compare", lib.MakeHTTP(s.handleCompare))
+	router.Get("/changes.md", lib.MakeHTTP(s.handleChangesMarkdown))
+	router.Get("/export/{format}", lib.MakeHTTP(s.handleExport))
 	router.Get("/search", lib.MakeHTTP(s.handleSearchWeapon))
-	router.Put("/weapon/{name}", lib.MakeHTTP(s.handleUpdateWeapon))
+	router.Post("/units", lib.MakeHTTP(s.handleSetUnits))
+	router.Get("/weapon/{slug}", lib.MakeHTTP(s.handleWeapon))
+	router.Put("/weapon/{slug}", lib.MakeHTTP(s.handleUpdateWeapon))
+	router.Patch("/weapon/{slug}", lib.MakeHTTP(s.handlePatchWeapon))
+	router.Delete("/weapon/{slug}", lib.MakeHTTP(s.handleDeleteWeapon))
+	router.Post("/weapon/{slug}/restore", lib.MakeHTTP(s.handleRestoreWeapon))
 	router.Post("/weapon", lib.MakeHTTP(s.handleInsertWeapon))
 
-	log.Printf("Running on http://localhost%s", s.port)
-
-	if err := http.ListenAndServe(s.port, router); err != nil {
-		return fmt.Errorf("failed to start server: %s", err)
-	}
-
-	return nil
+	return router, nil
 }
 
 func public() http.Handler {
@@ -0,0 +1,103 @@
+package api
+
+import (
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/zeze322/wt-guided-weaponry/lib"
+	"github.com/zeze322/wt-guided-weaponry/models"
+)
+
+// unitsCookie keeps the units picked in the units form of the tables.
+const unitsCookie = "units"
+
+// UnitsResponse lists what the units= query parameter accepts.
+type UnitsResponse struct {
+	Systems    []models.UnitSystem `json:"systems"`
+	Quantities []models.Quantity   `json:"quantities"`
+}
+
+// queryUnits reads the units= query parameter, e.g. "aviation" or
+// "si,speed:mach". The stored units are kept when it's empty.
+func queryUnits(r *http.Request) (models.Units, error) {
+	units, err := models.ParseUnits(r.URL.Query().Get("units"))
+	if err != nil {
+		return models.Units{}, lib.InvalidFields([]lib.FieldError{{Field: "units", Msg: err.Error()}})
+	}
+
+	return units, nil
+}
+
+// pageUnits reads the units of a page from units= or else from the cookie.
+// A cookie that can't be read shows the stored units.
+func pageUnits(r *http.Request) (models.Units, error) {
+	if r.URL.Query().Has("units") {
+		return queryUnits(r)
+	}
+
+	cookie, err := r.Cookie(unitsCookie)
+	if err != nil {
+		return models.Units{}, nil
+	}
+
+	units, err := models.ParseUnits(cookie.Value)
+	if err != nil {
+		return models.Units{}, nil
+	}
+
+	return units, nil
+}
+
+// handleSetUnits stores the units posted by the units form in a cookie and
+// goes back to the page in next.
+func (s *Server) handleSetUnits(w http.ResponseWriter, r *http.Request) error {
+	prefs := []string{r.PostFormValue("system")}
+
+	for _, q := range models.Quantities {
+		if id := r.PostFormValue(q.Name); id != "" {
+			prefs = append(prefs, q.Name+":"+id)
+		}
+	}
+
+	units, err := models.ParseUnits(strings.Join(prefs, ","))
+	if err != nil {
+		return lib.NewApiError(http.StatusBadRequest, err)
+	}
+
+	http.SetCookie(w, &http.Cookie{
+		Name:     unitsCookie,
+		Value:    units.String(),
+		Path:     "/",
+		MaxAge:   365 * 24 * 60 * 60,
+		HttpOnly: true,
+		SameSite: http.SameSiteLaxMode,
+	})
+
+	http.Redirect(w, r, localPath(r.PostFormValue("next")), http.StatusSeeOther)
+
+	return nil
+}
+
+// localPath returns next if it's a path of this site and "/" otherwise.
+// Browsers read a backslash as a slash, so "/\evil.com" would leave the
+// site as well as "//evil.com".
+func localPath(next string) string {
+	if !strings.HasPrefix(next, "/") || strings.HasPrefix(next, "//") || strings.ContainsRune(next, '\\') {
+		return "/"
+	}
+
+	u, err := url.Parse(next)
+	if err != nil || u.Scheme != "" || u.Host != "" {
+		return "/"
+	}
+
+	return next
+}
+
+func (s *Server) handleAPIUnits(w http.ResponseWriter, r *http.Request) error {
+	return lib.WriteData(w, http.StatusOK, UnitsResponse{
+		Systems:    models.UnitSystems,
+		Quantities: models.Quantities,
+	})
+}
@@ -0,0 +1,40 @@
+package api
+
+import (
+	"net/http"
+	"net/url"
+	"testing"
+)
+
+func TestSetUnitsRedirect(t *testing.T) {
+	handler, _ := newTestServer(t)
+
+	tests := []struct {
+		next string
+		want string
+	}{
+		{"/weapons?units=si", "/weapons?units=si"},
+		{"/weapon/aim-9l", "/weapon/aim-9l"},
+		{"", "/"},
+		{"weapons", "/"},
+		{"//evil.com", "/"},
+		{"///evil.com", "/"},
+		{"/\\evil.com", "/"},
+		{"/\\/evil.com", "/"},
+		{"https://evil.com", "/"},
+		{"/\t/evil.com", "/"},
+	}
+
+	for _, tt := range tests {
+		form := url.Values{"system": {"aviation"}, "next": {tt.next}}
+		res, _ := do(t, handler, http.MethodPost, "/units", "application/x-www-form-urlencoded", form.Encode())
+		if res.StatusCode != http.StatusSeeOther {
+			t.Errorf("next %q: status %d, want %d", tt.next, res.StatusCode, http.StatusSeeOther)
+			continue
+		}
+
+		if got := res.Header.Get("Location"); got != tt.want {
+			t.Errorf("next %q: redirected to %q, want %q", tt.next, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,430 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/zeze322/wt-guided-weaponry/internal/db/mongodb"
+	"github.com/zeze322/wt-guided-weaponry/internal/filter"
+	"github.com/zeze322/wt-guided-weaponry/internal/validation"
+	"github.com/zeze322/wt-guided-weaponry/lib"
+	"github.com/zeze322/wt-guided-weaponry/models"
+)
+
+// v1 is the versioned JSON API mounted under /api/v1. Every response body is
+// a lib.Envelope.
+func (s *Server) v1() http.Handler {
+	router := chi.NewRouter()
+
+	router.NotFound(lib.MakeAPI(func(w http.ResponseWriter, r *http.Request) error {
+		return lib.NewApiError(http.StatusNotFound, fmt.Errorf("no such endpoint: %s", r.URL.Path))
+	}))
+	router.MethodNotAllowed(lib.MakeAPI(func(w http.ResponseWriter, r *http.Request) error {
+		return lib.MethodNotAllowed(r.Method)
+	}))
+
+	for _, route := range s.routes() {
+		router.Method(route.Method, route.Path, lib.MakeAPI(route.handler))
+	}
+
+	return router
+}
+
+// urlParam returns the unescaped path parameter, so names containing
+// escaped slashes still match.
+func urlParam(r *http.Request, key string) string {
+	param := chi.URLParam(r, key)
+
+	if unescaped, err := url.PathUnescape(param); err == nil {
+		return unescaped
+	}
+
+	return param
+}
+
+func decodeBody(r *http.Request, v any) error {
+	if err := json.NewDecoder(r.Body).Decode(v); err != nil {
+		return lib.NewApiError(http.StatusBadRequest, fmt.Errorf("invalid request body: %s", err))
+	}
+
+	return nil
+}
+
+// ListMeta is the meta object of a weapon listing.
+type ListMeta struct {
+	NextCursor string `json:"nextCursor,omitempty"`
+}
+
+func (s *Server) handleAPIWeapons(w http.ResponseWriter, r *http.Request) error {
+	opts, err := listOptions(r)
+	if err != nil {
+		return err
+	}
+
+	if opts.Category != "" {
+		_, err := s.mongo.Category(r.Context(), opts.Category)
+		if errors.Is(err, mongodb.ErrNothingFound) {
+			return lib.NotFound(opts.Category)
+		}
+
+		if err != nil {
+			return err
+		}
+	}
+
+	units, err := queryUnits(r)
+	if err != nil {
+		return err
+	}
+
+	page, err := s.mongo.ListWeapons(r.Context(), opts)
+	if err != nil {
+		return err
+	}
+
+	return lib.WriteJSON(w, http.StatusOK, lib.Envelope{
+		Data: units.Weapons(page.Weapons),
+		Meta: ListMeta{NextCursor: page.NextCursor},
+	})
+}
+
+// listOptions reads the query parameters of a weapon listing:
+// category, filter (see package filter), sort ("-" prefix for descending),
+// fields (comma-separated), limit and cursor.
+func listOptions(r *http.Request) (mongodb.ListOptions, error) {
+	query := r.URL.Query()
+
+	opts := mongodb.ListOptions{
+		Category: query.Get("category"),
+		Cursor:   query.Get("cursor"),
+	}
+
+	opts.Sort, opts.Desc = mongodb.ParseSort(query.Get("sort"))
+
+	if expr := query.Get("filter"); expr != "" {
+		node, err := filter.Parse(expr)
+		if err != nil {
+			return opts, lib.InvalidFields([]lib.FieldError{{Field: "filter", Msg: err.Error()}})
+		}
+
+		opts.Filter = node
+	}
+
+	if fields := query.Get("fields"); fields != "" {
+		for _, key := range strings.Split(fields, ",") {
+			if key = strings.TrimSpace(key); key != "" {
+				opts.Fields = append(opts.Fields, key)
+			}
+		}
+	}
+
+	if limit := query.Get("limit"); limit != "" {
+		n, err := strconv.Atoi(limit)
+		if err != nil || n < 1 {
+			return opts, lib.InvalidFields([]lib.FieldError{{Field: "limit", Msg: "limit must be a positive number"}})
+		}
+
+		opts.Limit = n
+	}
+
+	if err := opts.Validate(); err != nil {
+		return opts, lib.NewApiError(http.StatusBadRequest, err)
+	}
+
+	return opts, nil
+}
+
+func (s *Server) handleAPIWeapon(w http.ResponseWriter, r *http.Request) error {
+	name := urlParam(r, "name")
+
+	units, err := queryUnits(r)
+	if err != nil {
+		return err
+	}
+
+	if version := r.FormValue("version"); version != "" {
+		weapon, err := s.weaponAt(r, name, version)
+		if err != nil {
+			return err
+		}
+
+		return lib.WriteData(w, http.StatusOK, units.Params(weapon))
+	}
+
+	weapon, err := s.mongo.Weapon(r.Context(), name)
+	if errors.Is(err, mongodb.ErrNothingFound) {
+		return lib.NotFound(name)
+	}
+
+	if err != nil {
+		return err
+	}
+
+	return lib.WriteData(w, http.StatusOK, units.Params(weapon))
+}
+
+func (s *Server) handleAPICreateWeapon(w http.ResponseWriter, r *http.Request) error {
+	req := new(models.Params)
+	if err := decodeBody(r, req); err != nil {
+		return err
+	}
+
+	if err := s.validateWeapon(r.Context(), req); err != nil {
+		return err
+	}
+
+	err := s.mongo.InsertWeapon(r.Context(), req)
+	if errors.Is(err, mongodb.ErrConflict) {
+		return lib.Conflict(req.Name)
+	}
+
+	if err != nil {
+		return err
+	}
+
+	w.Header().Set("Location", "/api/v1/weapons/"+url.PathEscape(req.Name))
+
+	return lib.WriteData(w, http.StatusCreated, models.NewWeapon(req))
+}
+
+func (s *Server) handleAPIUpdateWeapon(w http.ResponseWriter, r *http.Request) error {
+	name := urlParam(r, "name")
+
+	req := new(models.Params)
+	if err := decodeBody(r, req); err != nil {
+		return err
+	}
+
+	_, err := s.mongo.Weapon(r.Context(), name)
+	if errors.Is(err, mongodb.ErrNothingFound) {
+		return lib.NotFound(name)
+	}
+
+	if err != nil {
+		return err
+	}
+
+	if err := s.validateWeapon(r.Context(), req); err != nil {
+		return err
+	}
+
+	err = s.mongo.UpdateWeapon(r.Context(), name, req)
+	if errors.Is(err, mongodb.ErrNothingFound) {
+		return lib.NotFound(name)
+	}
+
+	if errors.Is(err, mongodb.ErrConflict) {
+		return lib.Conflict(req.Name)
+	}
+
+	if err != nil {
+		return err
+	}
+
+	return lib.WriteData(w, http.StatusOK, models.NewWeapon(req))
+}
+
+func (s *Server) handleAPIPatchWeapon(w http.ResponseWriter, r *http.Request) error {
+	weapon, err := s.patchWeapon(r, urlParam(r, "name"))
+	if err != nil {
+		return err
+	}
+
+	return lib.WriteData(w, http.StatusOK, weapon)
+}
+
+func (s *Server) handleAPIDeleteWeapon(w http.ResponseWriter, r *http.Request) error {
+	name := urlParam(r, "name")
+
+	// The reason is optional, so an empty body is fine.
+	req := new(DeleteWeaponRequest)
+	if err := json.NewDecoder(r.Body).Decode(req); err != nil && !errors.Is(err, io.EOF) {
+		return lib.NewApiError(http.StatusBadRequest, fmt.Errorf("invalid request body: %s", err))
+	}
+
+	err := s.mongo.DeleteWeapon(r.Context(), name, req.Reason)
+	if errors.Is(err, mongodb.ErrNothingFound) {
+		return lib.NotFound(name)
+	}
+
+	if err != nil {
+		return err
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+
+	return nil
+}
+
+func (s *Server) handleAPIRestoreWeapon(w http.ResponseWriter, r *http.Request) error {
+	name := urlParam(r, "name")
+
+	err := s.mongo.RestoreWeapon(r.Context(), name)
+	if errors.Is(err, mongodb.ErrNothingFound) {
+		return lib.NewApiError(http.StatusNotFound, fmt.Errorf("%s isn't deleted", name))
+	}
+
+	if err != nil {
+		return err
+	}
+
+	weapon, err := s.mongo.Weapon(r.Context(), name)
+	if err != nil {
+		return err
+	}
+
+	return lib.WriteData(w, http.StatusOK, weapon)
+}
+
+func (s *Server) handleAPISearch(w http.ResponseWriter, r *http.Request) error {
+	keyWord := r.FormValue("q")
+	if keyWord == "" {
+		return lib.InvalidFields([]lib.FieldError{{Field: "q", Msg: "q is required"}})
+	}
+
+	weapons, err := s.mongo.SearchWeapon(r.Context(), keyWord)
+	if err != nil && !errors.Is(err, mongodb.ErrNothingFound) {
+		return err
+	}
+
+	if weapons == nil {
+		weapons = []models.Name{}
+	}
+
+	return lib.WriteData(w, http.StatusOK, weapons)
+}
+
+func (s *Server) handleAPICategories(w http.ResponseWriter, r *http.Request) error {
+	categories, err := s.mongo.Categories(r.Context())
+	if err != nil {
+		return err
+	}
+
+	if categories == nil {
+		categories = []models.Category{}
+	}
+
+	return lib.WriteData(w, http.StatusOK, categories)
+}
+
+func (s *Server) handleAPICategory(w http.ResponseWriter, r *http.Request) error {
+	slug := urlParam(r, "slug")
+
+	category, err := s.mongo.Category(r.Context(), slug)
+	if errors.Is(err, mongodb.ErrNothingFound) {
+		return lib.NotFound(slug)
+	}
+
+	if err != nil {
+		return err
+	}
+
+	return lib.WriteData(w, http.StatusOK, category)
+}
+
+func (s *Server) handleAPICreateCategory(w http.ResponseWriter, r *http.Request) error {
+	req := new(models.Category)
+	if err := decodeBody(r, req); err != nil {
+		return err
+	}
+
+	if err := validateCategory(req); err != nil {
+		return err
+	}
+
+	err := s.mongo.InsertCategory(r.Context(), req)
+	if errors.Is(err, mongodb.ErrConflict) {
+		return lib.Conflict(req.Slug)
+	}
+
+	if err != nil {
+		return err
+	}
+
+	w.Header().Set("Location", "/api/v1/categories/"+url.PathEscape(req.Slug))
+
+	return lib.WriteData(w, http.StatusCreated, req)
+}
+
+func (s *Server) handleAPIUpdateCategory(w http.ResponseWriter, r *http.Request) error {
+	slug := urlParam(r, "slug")
+
+	req := new(models.Category)
+	if err := decodeBody(r, req); err != nil {
+		return err
+	}
+
+	_, err := s.mongo.Category(r.Context(), slug)
+	if errors.Is(err, mongodb.ErrNothingFound) {
+		return lib.NotFound(slug)
+	}
+
+	if err != nil {
+		return err
+	}
+
+	if err := validateCategory(req); err != nil {
+		return err
+	}
+
+	err = s.mongo.UpdateCategory(r.Context(), slug, req)
+	if errors.Is(err, mongodb.ErrNothingFound) {
+		return lib.NotFound(slug)
+	}
+
+	if errors.Is(err, mongodb.ErrConflict) {
+		return lib.Conflict(req.Slug)
+	}
+
+	if err != nil {
+		return err
+	}
+
+	return lib.WriteData(w, http.StatusOK, req)
+}
+
+func (s *Server) handleAPIDeleteCategory(w http.ResponseWriter, r *http.Request) error {
+	slug := urlParam(r, "slug")
+
+	err := s.mongo.DeleteCategory(r.Context(), slug)
+	if errors.Is(err, mongodb.ErrNothingFound) {
+		return lib.NotFound(slug)
+	}
+
+	if err != nil {
+		return err
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+
+	return nil
+}
+
+func (s *Server) handleAPIFields(w http.ResponseWriter, r *http.Request) error {
+	units, err := queryUnits(r)
+	if err != nil {
+		return err
+	}
+
+	if category := r.FormValue("category"); category != "" {
+		return lib.WriteData(w, http.StatusOK, units.Fields(models.FieldsFor(category)))
+	}
+
+	return lib.WriteData(w, http.StatusOK, units.Fields(models.Fields()))
+}
+
+func validateCategory(category *models.Category) error {
+	if errs := validation.Category(category); len(errs) > 0 {
+		return lib.InvalidFields(errs)
+	}
+
+	return nil
+}
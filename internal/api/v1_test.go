@@ -0,0 +1,102 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/zeze322/wt-guided-weaponry/internal/db/memory"
+	"github.com/zeze322/wt-guided-weaponry/models"
+)
+
+var errDown = errors.New("server selection error: context deadline exceeded")
+
+// downStore fails every write, as a store does during an outage.
+type downStore struct {
+	*memory.MemoryStore
+}
+
+func (downStore) DeleteWeapon(context.Context, string, string) error {
+	return errDown
+}
+
+func (downStore) RestoreWeapon(context.Context, string) error {
+	return errDown
+}
+
+func (downStore) InsertCategory(context.Context, *models.Category) error {
+	return errDown
+}
+
+func (downStore) UpdateCategory(context.Context, string, *models.Category) error {
+	return errDown
+}
+
+func (downStore) DeleteCategory(context.Context, string) error {
+	return errDown
+}
+
+func (downStore) InsertGameVersion(context.Context, *models.GameVersion) error {
+	return errDown
+}
+
+const testCategory = `{"slug": "aam-test", "name": "AAM (test)", "class": "AAM", "guidance": "IR", "sortOrder": 100}`
+
+// TestStoreErrors checks that missing resources answer 404, taken names 409
+// and any other store error a 500 that doesn't show the error.
+func TestStoreErrors(t *testing.T) {
+	handler, store := newTestServer(t)
+	ctx := context.Background()
+
+	if err := store.DeleteWeapon(ctx, "AIM-9B", ""); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := store.InsertGameVersion(ctx, &models.GameVersion{Version: "2.35"}); err != nil {
+		t.Fatal(err)
+	}
+
+	down, err := NewServer(":0", downStore{store}).Handler()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		handler              http.Handler
+		method, target, body string
+		want                 int
+	}{
+		{handler, http.MethodDelete, "/api/v1/weapons/nope", "", http.StatusNotFound},
+		{handler, http.MethodPost, "/api/v1/weapons/AIM-9L/restore", "", http.StatusNotFound},
+		{handler, http.MethodPost, "/api/v1/weapons/nope/restore", "", http.StatusNotFound},
+		{handler, http.MethodPost, "/api/v1/categories", `{"slug": "` + models.CategoryAAMARH + `", "name": "AAM (ARH)"}`, http.StatusConflict},
+		{handler, http.MethodPut, "/api/v1/categories/nope", testCategory, http.StatusNotFound},
+		{handler, http.MethodDelete, "/api/v1/categories/nope", "", http.StatusNotFound},
+		{handler, http.MethodGet, "/api/v1/weapons/nope/history", "", http.StatusNotFound},
+		{handler, http.MethodPost, "/api/v1/versions", `{"version": "2.35"}`, http.StatusConflict},
+		{handler, http.MethodPost, "/weapon/AIM-9L/restore", "", http.StatusNotFound},
+		{handler, http.MethodPut, "/weapon/nope", `{}`, http.StatusNotFound},
+		{down, http.MethodDelete, "/api/v1/weapons/AIM-9L", "", http.StatusInternalServerError},
+		{down, http.MethodPost, "/api/v1/weapons/AIM-9B/restore", "", http.StatusInternalServerError},
+		{down, http.MethodPost, "/api/v1/categories", testCategory, http.StatusInternalServerError},
+		{down, http.MethodPut, "/api/v1/categories/" + models.CategoryAAMARH, testCategory, http.StatusInternalServerError},
+		{down, http.MethodDelete, "/api/v1/categories/" + models.CategoryAAMARH, "", http.StatusInternalServerError},
+		{down, http.MethodPost, "/api/v1/versions", `{"version": "2.36"}`, http.StatusInternalServerError},
+		{down, http.MethodDelete, "/weapon/AIM-9L", "", http.StatusInternalServerError},
+		{down, http.MethodPost, "/weapon/AIM-9B/restore", "", http.StatusInternalServerError},
+		{down, http.MethodPost, "/dev/category", testCategory, http.StatusInternalServerError},
+	}
+
+	for _, tt := range tests {
+		res, body := do(t, tt.handler, tt.method, tt.target, "application/json", tt.body)
+		if res.StatusCode != tt.want {
+			t.Errorf("%s %s returned %d, want %d: %s", tt.method, tt.target, res.StatusCode, tt.want, body)
+		}
+
+		if strings.Contains(body, "nothing found") || strings.Contains(body, errDown.Error()) {
+			t.Errorf("%s %s shows the store error: %s", tt.method, tt.target, body)
+		}
+	}
+}
@@ -0,0 +1,190 @@
+package api
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/zeze322/wt-guided-weaponry/internal/db/mongodb"
+	"github.com/zeze322/wt-guided-weaponry/internal/diff"
+	"github.com/zeze322/wt-guided-weaponry/lib"
+	"github.com/zeze322/wt-guided-weaponry/models"
+	"github.com/zeze322/wt-guided-weaponry/views/changes"
+)
+
+func (s *Server) handleAPIGameVersions(w http.ResponseWriter, r *http.Request) error {
+	versions, err := s.mongo.GameVersions(r.Context())
+	if err != nil {
+		return err
+	}
+
+	if versions == nil {
+		versions = []models.GameVersion{}
+	}
+
+	return lib.WriteData(w, http.StatusOK, versions)
+}
+
+// handleAPICreateGameVersion adds a game version, dated now unless the
+// request says otherwise. Weapons written from then on are tagged with it.
+func (s *Server) handleAPICreateGameVersion(w http.ResponseWriter, r *http.Request) error {
+	req := new(models.GameVersion)
+	if err := decodeBody(r, req); err != nil {
+		return err
+	}
+
+	req.Version = strings.TrimSpace(req.Version)
+	if req.Version == "" {
+		return lib.InvalidFields([]lib.FieldError{{Field: "version", Msg: "version is required"}})
+	}
+
+	if req.Date.IsZero() {
+		req.Date = time.Now().UTC()
+	}
+
+	err := s.mongo.InsertGameVersion(r.Context(), req)
+	if errors.Is(err, mongodb.ErrConflict) {
+		return lib.Conflict(req.Version)
+	}
+
+	if err != nil {
+		return err
+	}
+
+	return lib.WriteData(w, http.StatusCreated, req)
+}
+
+func (s *Server) handleAPIWeaponHistory(w http.ResponseWriter, r *http.Request) error {
+	name := urlParam(r, "name")
+
+	history, err := s.mongo.WeaponHistory(r.Context(), name)
+	if errors.Is(err, mongodb.ErrNothingFound) {
+		return lib.NotFound(name)
+	}
+
+	if err != nil {
+		return err
+	}
+
+	return lib.WriteData(w, http.StatusOK, history)
+}
+
+// weaponAt reads a weapon as it was in version.
+func (s *Server) weaponAt(r *http.Request, name, version string) (*models.Params, error) {
+	weapon, err := s.mongo.WeaponAt(r.Context(), name, version)
+	if errors.Is(err, mongodb.ErrUnknownVersion) {
+		return nil, unknownVersion(err)
+	}
+
+	if errors.Is(err, mongodb.ErrNothingFound) {
+		return nil, lib.NotFound(name)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return weapon, nil
+}
+
+func unknownVersion(err error) error {
+	return lib.InvalidFields([]lib.FieldError{{Field: "version", Msg: err.Error()}})
+}
+
+// changes compares the weapons of the game versions from= and to=. to
+// defaults to the latest version and from to the one before to.
+func (s *Server) changes(r *http.Request) (*diff.Report, error) {
+	ctx := r.Context()
+
+	versions, err := s.mongo.GameVersions(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	to, err := pickVersion(versions, "to", r.FormValue("to"), len(versions)-1)
+	if err != nil {
+		return nil, err
+	}
+
+	from, err := pickVersion(versions, "from", r.FormValue("from"), slices.Index(versions, to)-1)
+	if err != nil {
+		return nil, err
+	}
+
+	before, err := s.mongo.WeaponsAt(ctx, from.Version)
+	if err != nil {
+		return nil, err
+	}
+
+	after, err := s.mongo.WeaponsAt(ctx, to.Version)
+	if err != nil {
+		return nil, err
+	}
+
+	categories, err := s.mongo.Categories(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return diff.Compare(from, to, before, after, categories), nil
+}
+
+// pickVersion finds the version of the query parameter field, or takes the
+// one at index when it's empty.
+func pickVersion(versions []models.GameVersion, field, version string, index int) (models.GameVersion, error) {
+	if version == "" {
+		if index < 0 || index >= len(versions) {
+			return models.GameVersion{}, lib.NewApiError(http.StatusBadRequest, errors.New("comparing needs two game versions"))
+		}
+		return versions[index], nil
+	}
+
+	for _, v := range versions {
+		if v.Version == version {
+			return v, nil
+		}
+	}
+
+	msg := fmt.Sprintf("%s: %s", mongodb.ErrUnknownVersion, version)
+
+	return models.GameVersion{}, lib.InvalidFields([]lib.FieldError{{Field: field, Msg: msg}})
+}
+
+func (s *Server) handleAPIChanges(w http.ResponseWriter, r *http.Request) error {
+	report, err := s.changes(r)
+	if err != nil {
+		return err
+	}
+
+	return lib.WriteData(w, http.StatusOK, report)
+}
+
+// handleChanges renders the patch notes page comparing two game versions.
+func (s *Server) handleChanges(w http.ResponseWriter, r *http.Request) error {
+	versions, err := s.mongo.GameVersions(r.Context())
+	if err != nil {
+		return err
+	}
+
+	report, err := s.changes(r)
+	if err != nil {
+		return err
+	}
+
+	return lib.Render(w, r, changes.Changes(report, versions))
+}
+
+// handleChangesMarkdown serves the comparison as Markdown for patch notes.
+func (s *Server) handleChangesMarkdown(w http.ResponseWriter, r *http.Request) error {
+	report, err := s.changes(r)
+	if err != nil {
+		return err
+	}
+
+	w.Header().Set("Content-Type", "text/markdown; charset=UTF-8")
+
+	return diff.WriteMarkdown(w, report)
+}
@@ -0,0 +1,156 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/url"
+
+	"github.com/zeze322/wt-guided-weaponry/internal/db/mongodb"
+	"github.com/zeze322/wt-guided-weaponry/lib"
+	"github.com/zeze322/wt-guided-weaponry/models"
+	"github.com/zeze322/wt-guided-weaponry/views/weapon"
+)
+
+// similarWeapons is how many similar weapons a weapon page lists.
+const similarWeapons = 5
+
+// WeaponDetail is a weapon with what its page shows around it.
+type WeaponDetail struct {
+	Weapon *models.Params `json:"weapon"`
+
+	// Category is absent when the weapon's category has been deleted.
+	Category *models.Category `json:"category,omitempty"`
+
+	// Similar lists the weapons of the category closest to this one.
+	Similar []models.Name `json:"similar"`
+
+	Links WeaponLinks `json:"links"`
+}
+
+// WeaponLinks are the paths of the pages about a weapon.
+type WeaponLinks struct {
+	Page    string `json:"page"`
+	Compare string `json:"compare"`
+	History string `json:"history"`
+}
+
+// weaponDetail gathers the detail of weapon. Values are converted to units.
+func (s *Server) weaponDetail(ctx context.Context, weapon *models.Params, units models.Units) (*WeaponDetail, error) {
+	res := &WeaponDetail{
+		Weapon:  units.Params(weapon),
+		Similar: []models.Name{},
+		Links: WeaponLinks{
+			Page:    weaponPath(weapon.Slug),
+			Compare: "/compare?" + url.Values{"w": {weapon.Name}}.Encode(),
+			History: "/api/v1/weapons/" + url.PathEscape(weapon.Name) + "/history",
+		},
+	}
+
+	if category, err := s.mongo.Category(ctx, weapon.Category); err == nil {
+		res.Category = category
+	}
+
+	candidates, err := s.mongo.WeaponsByCategory(ctx, weapon.Category)
+	if err != nil && !errors.Is(err, mongodb.ErrNothingFound) {
+		return nil, err
+	}
+
+	for _, similar := range models.Similar(weapon, candidates, similarWeapons) {
+		res.Similar = append(res.Similar, models.Name{Name: similar.Name, Slug: similar.Slug, Category: similar.Category})
+	}
+
+	return res, nil
+}
+
+func weaponPath(slug string) string {
+	return "/weapon/" + url.PathEscape(slug)
+}
+
+// weaponName resolves the weapon a /weapon/{slug} URL points at to its
+// name. Like the weapon page, it falls back to names so old links keep
+// working.
+func (s *Server) weaponName(ctx context.Context, slug string) (string, error) {
+	if found, err := s.mongo.WeaponBySlug(ctx, slug); err == nil {
+		return found.Name, nil
+	}
+
+	if found, err := s.mongo.Weapon(ctx, slug); err == nil {
+		return found.Name, nil
+	}
+
+	return "", lib.NotFound(slug)
+}
+
+// deletedWeaponName is weaponName for deleted weapons.
+func (s *Server) deletedWeaponName(ctx context.Context, slug string) (string, error) {
+	deleted, err := s.mongo.DeletedWeapons(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	for _, weapon := range deleted {
+		if weapon.Slug == slug {
+			return weapon.Name, nil
+		}
+	}
+
+	for _, weapon := range deleted {
+		if weapon.Name == slug {
+			return weapon.Name, nil
+		}
+	}
+
+	return "", lib.NotFound(slug)
+}
+
+// handleWeapon renders the page of a weapon. Its URL holds the slug, which
+// outlives renames; names are redirected to it so old links keep working.
+func (s *Server) handleWeapon(w http.ResponseWriter, r *http.Request) error {
+	ctx := r.Context()
+	slug := urlParam(r, "slug")
+
+	found, err := s.mongo.WeaponBySlug(ctx, slug)
+	if err != nil {
+		found, err := s.mongo.Weapon(ctx, slug)
+		if err != nil || found.Slug == "" {
+			return lib.NotFound(slug)
+		}
+
+		http.Redirect(w, r, weaponPath(found.Slug), http.StatusMovedPermanently)
+		return nil
+	}
+
+	units, err := pageUnits(r)
+	if err != nil {
+		return err
+	}
+
+	detail, err := s.weaponDetail(ctx, found, units)
+	if err != nil {
+		return err
+	}
+
+	return lib.Render(w, r, weapon.Weapon(detail.Weapon, detail.Category, detail.Similar, units.Fields(models.FieldsForAll([]string{found.Category})), units))
+}
+
+func (s *Server) handleAPIWeaponBySlug(w http.ResponseWriter, r *http.Request) error {
+	slug := urlParam(r, "slug")
+
+	units, err := queryUnits(r)
+	if err != nil {
+		return err
+	}
+
+	found, err := s.mongo.WeaponBySlug(r.Context(), slug)
+	if err != nil {
+		return lib.NotFound(slug)
+	}
+
+	detail, err := s.weaponDetail(r.Context(), found, units)
+	if err != nil {
+		return err
+	}
+
+	return lib.WriteData(w, http.StatusOK, detail)
+}
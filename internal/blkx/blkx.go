@@ -0,0 +1,395 @@
+// Package blkx reads War Thunder datamined weapon files (the JSON form of
+// weapons/rocketguns/*.blk) and maps their rocket, guidance, seeker and
+// fuse blocks onto models.Params, so the numbers don't have to be copied
+// into the database by hand.
+package blkx
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/zeze322/wt-guided-weaponry/models"
+)
+
+// g0 is the standard gravity used for the specific impulse, in m/s².
+const g0 = 9.80665
+
+var (
+	ErrNoMissile = errors.New("no rocket or bomb block")
+	ErrUnguided  = errors.New("no guidance, not a guided weapon")
+)
+
+// Missile is a weapon read from a blkx file.
+type Missile struct {
+	Params *models.Params
+
+	// Reason explains how the category was inferred. The category is left
+	// empty when it couldn't be, and Reason says why.
+	Reason string
+
+	// Warnings lists what was found in the file but couldn't be mapped.
+	Warnings []string
+}
+
+// Parse maps the blkx file at path onto a Missile. The name of the weapon
+// is derived from the file name, e.g. "us_aim_9l.blkx" becomes "AIM-9L".
+func Parse(path string, data []byte) (*Missile, error) {
+	var doc node
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return nil, err
+	}
+
+	rocket, isBomb := doc.block("rocket"), false
+	if rocket == nil {
+		rocket, isBomb = doc.block("bomb"), true
+	}
+
+	if rocket == nil {
+		return nil, ErrNoMissile
+	}
+
+	guidance := rocket.block("guidance")
+
+	guidanceType := guidanceTypeOf(rocket, guidance)
+	if guidanceType == "" {
+		return nil, ErrUnguided
+	}
+
+	m := &Missile{Params: &models.Params{Name: Name(path)}}
+
+	m.set(rocket, mappings)
+	m.engine(rocket)
+	m.fuse(rocket)
+
+	m.set(guidance, guidanceMappings)
+	m.seeker(guidance)
+	m.Params.GuidanceType = models.ParseValue(guidanceType)
+
+	m.Params.Category, m.Reason = infer(path, doc, rocket, isBomb, guidanceType)
+
+	return m, nil
+}
+
+// Name derives a weapon name from a blkx file name: the country prefix is
+// dropped, the rest upper-cased and a hyphen put between the designation
+// and the number when the file doesn't have one.
+func Name(path string) string {
+	name := strings.TrimSuffix(filepath.Base(path), filepath.Ext(path))
+
+	if prefix, rest, ok := strings.Cut(name, "_"); ok && countries[prefix] {
+		name = rest
+	}
+
+	name = strings.ToUpper(strings.ReplaceAll(name, "_", "-"))
+
+	if !strings.Contains(name, "-") {
+		if i := strings.IndexFunc(name, isDigit); i > 0 {
+			name = name[:i] + "-" + name[i:]
+		}
+	}
+
+	return name
+}
+
+func isDigit(r rune) bool {
+	return r >= '0' && r <= '9'
+}
+
+var countries = map[string]bool{
+	"us": true, "ussr": true, "germ": true, "uk": true, "jp": true, "cn": true,
+	"it": true, "fr": true, "sw": true, "il": true, "za": true,
+}
+
+// mapping copies a number of a blkx block onto a parameter.
+type mapping struct {
+	block []string
+	key   string
+	field string
+	conv  func(float64) float64
+}
+
+func km(m float64) float64 { return m / 1000 }
+
+func mm(m float64) float64 { return m * 1000 }
+
+func deg(rad float64) float64 { return rad * 180 / math.Pi }
+
+// mappings apply to the rocket block.
+var mappings = []mapping{
+	{nil, "mass", "physicalProp.mass", nil},
+	{nil, "massEnd", "physicalProp.massAtEndOfBoosterBurn", nil},
+	{nil, "massEnd1", "physicalProp.massAtEndOfSustainerBurn", nil},
+	{nil, "caliber", "physicalProp.calibre", mm},
+	{nil, "length", "physicalProp.length", nil},
+	{nil, "force", "engineProp.forceExertedByBooster", nil},
+	{nil, "timeFire", "engineProp.burnTimeOfBooster", nil},
+	{nil, "force1", "engineProp.forceExertedBySustainer", nil},
+	{nil, "timeFire1", "engineProp.burnTimeOfSustainer", nil},
+	{nil, "explosiveMass", "fuseAndWarheadProp.explosiveMass", nil},
+	{[]string{"cumulativeDamage"}, "armorPower", "fuseAndWarheadProp.penetration", nil},
+	{nil, "startSpeed", "flightProp.startSpeed", nil},
+	{nil, "endSpeed", "flightProp.maximumSpeed", nil},
+	{nil, "minDistance", "flightProp.minimumRange", nil},
+	{nil, "maxDistance", "flightProp.maximumFlightRange", km},
+	{nil, "finsAoaHor", "flightProp.maximumFinAngleOfAttack", deg},
+	{nil, "finsLatAccel", "flightProp.finsLateralAcceleration", nil},
+	{nil, "wingAreamult", "flightProp.wingAreaMultiplier", nil},
+	{nil, "loadFactorMax", "flightProp.maximumOverLoad", nil},
+	{[]string{"thrustVectoring"}, "angleMax", "flightProp.thrustVectoringAngle", nil},
+}
+
+// guidanceMappings apply to the guidance block of the rocket.
+var guidanceMappings = []mapping{
+	{nil, "warmUpTime", "guidanceProp.seekerWarmUpTime", nil},
+	{nil, "workTime", "guidanceProp.seekerSearchDuration", nil},
+	{nil, "breakLockMaxTime", "guidanceProp.maximumBreakLockTime", nil},
+	{[]string{"inertialGuidance"}, "inertialNavigationDriftSpeed", "guidanceProp.inertialNavigationDriftSpeed", nil},
+	{[]string{"guidanceAutopilot"}, "propNavMult", "guidanceProp.proportionalNavigationMultiplier", nil},
+	{[]string{"guidanceAutopilot"}, "baseIndSpeed", "guidanceProp.baseIndicatedAirSpeed", nil},
+	{[]string{"guidanceAutopilot"}, "reqAccelMax", "flightProp.maximumLateralAcceleration", nil},
+	{[]string{"guidanceAutopilot"}, "loftElevation", "flightProp.loftAngle", nil},
+	{[]string{"guidanceAutopilot"}, "loftTargetElevation", "flightProp.targetElevation", nil},
+	{[]string{"irSeeker"}, "fov", "guidanceProp.fieldOfView", nil},
+	{[]string{"irSeeker"}, "angleMax", "guidanceProp.gimbalLimit", nil},
+	{[]string{"irSeeker"}, "rateMax", "guidanceProp.trackRate", nil},
+	{[]string{"irSeeker"}, "lockAngleMax", "guidanceProp.maximumLockAngleBeforeLaunch", nil},
+	{[]string{"irSeeker"}, "minAngleToSun", "guidanceProp.minimumAngleBetweenSeekerAndSunForNotCapture", nil},
+	{[]string{"irSeeker"}, "rangeBand0", "guidanceProp.lockOnRangeFromRearAspect", km},
+	{[]string{"irSeeker"}, "rangeBand1", "guidanceProp.lockOnRangeFromAllAspect", km},
+	{[]string{"irSeeker"}, "rangeBand2", "guidanceProp.flareDetectionRange", km},
+	{[]string{"irSeeker"}, "rangeBand3", "guidanceProp.IRCMDetectionRange", km},
+	{[]string{"irSeeker"}, "rangeBand4", "guidanceProp.DIRCMDetectionRange", km},
+	{[]string{"irSeeker"}, "rangeBand7", "guidanceProp.lockOnRangeGround", km},
+	{[]string{"irSeeker"}, "gateWidth", "guidanceProp.IRCCMFieldOfView", nil},
+	{[]string{"opticalFlowSeeker"}, "fov", "guidanceProp.fieldOfView", nil},
+	{[]string{"opticalFlowSeeker"}, "angleMax", "guidanceProp.gimbalLimit", nil},
+	{[]string{"opticalFlowSeeker"}, "rateMax", "guidanceProp.trackRate", nil},
+	{[]string{"opticalFlowSeeker"}, "lockRange", "guidanceProp.lockOnRangeGround", km},
+	{[]string{"laserSeeker"}, "fov", "guidanceProp.fieldOfView", nil},
+	{[]string{"laserSeeker"}, "angleMax", "guidanceProp.gimbalLimit", nil},
+	{[]string{"laserSeeker"}, "rateMax", "guidanceProp.trackRate", nil},
+	{[]string{"radarSeeker"}, "sideLobesAttenuation", "guidanceProp.sidelobeAttenuation", nil},
+	{[]string{"radarSeeker", "transmitter"}, "power", "guidanceProp.transmitterPower", nil},
+	{[]string{"radarSeeker", "transmitter", "antenna"}, "angleHalfSens", "guidanceProp.transmitterAngleOfHalfSensitivity", nil},
+	{[]string{"radarSeeker", "transmitter", "antenna"}, "sideLobesSensitivity", "guidanceProp.transmitterSidelobeSensitivity", nil},
+	{[]string{"radarSeeker", "receiver", "antenna"}, "angleHalfSens", "guidanceProp.receiverAngleOfHalfSensitivity", nil},
+	{[]string{"radarSeeker", "receiver", "antenna"}, "sideLobesSensitivity", "guidanceProp.receiverSidelobeSensitivity", nil},
+	{[]string{"radarSeeker", "distance"}, "minValue", "guidanceProp.distanceMinimumValue", nil},
+	{[]string{"radarSeeker", "distance"}, "maxValue", "guidanceProp.distanceMaximumValue", nil},
+	{[]string{"radarSeeker", "distance"}, "width", "guidanceProp.distanceWidth", nil},
+	{[]string{"radarSeeker", "distance"}, "minSignalGate", "guidanceProp.distanceMinimumSignalGate", nil},
+	{[]string{"radarSeeker", "distance"}, "refWidth", "guidanceProp.distanceRefWidth", nil},
+	{[]string{"radarSeeker", "distance"}, "searchRange", "guidanceProp.distanceGateSearchRange", nil},
+	{[]string{"radarSeeker", "dopplerSpeed"}, "minValue", "guidanceProp.dopplerSpeedMinimumValue", nil},
+	{[]string{"radarSeeker", "dopplerSpeed"}, "maxValue", "guidanceProp.dopplerSpeedMaximumValue", nil},
+	{[]string{"radarSeeker", "dopplerSpeed"}, "width", "guidanceProp.dopplerSpeedWidth", nil},
+	{[]string{"radarSeeker", "dopplerSpeed"}, "minSignalGate", "guidanceProp.dopplerSpeedMinimumSignalGate", nil},
+	{[]string{"radarSeeker", "dopplerSpeed"}, "refWidth", "guidanceProp.dopplerSpeedRefWidth", nil},
+	{[]string{"radarSeeker", "dopplerSpeed"}, "searchRange", "guidanceProp.dopplerSpeedGateSearchRange", nil},
+}
+
+func (m *Missile) set(n node, mappings []mapping) {
+	for _, mp := range mappings {
+		f, ok := n.block(mp.block...).num(mp.key)
+		if !ok {
+			continue
+		}
+
+		if mp.conv != nil {
+			f = mp.conv(f)
+		}
+
+		m.number(mp.field, f)
+	}
+}
+
+func (m *Missile) number(key string, f float64) {
+	m.setValue(key, strconv.FormatFloat(round(f), 'f', -1, 64))
+}
+
+func (m *Missile) flag(key string, b bool) {
+	text := "No"
+	if b {
+		text = "Yes"
+	}
+	m.setValue(key, text)
+}
+
+func (m *Missile) setValue(key, text string) {
+	field, ok := models.FieldByKey(key)
+	if !ok {
+		panic(fmt.Sprintf("blkx: unknown parameter %s", key))
+	}
+	field.SetValue(m.Params, models.ParseValue(text))
+}
+
+// round keeps three decimals, so unit conversions don't leave noise such
+// as 127.00000000000001.
+func round(f float64) float64 {
+	return math.Round(f*1000) / 1000
+}
+
+// engine derives the performance of the booster and sustainer from their
+// force, burn time and the masses before and after the burn.
+func (m *Missile) engine(rocket node) {
+	var total float64
+
+	stage := func(force, time, start, end string, isp, delta string) {
+		f, okF := rocket.num(force)
+		t, okT := rocket.num(time)
+		m0, ok0 := rocket.num(start)
+		m1, ok1 := rocket.num(end)
+
+		if !okF || !okT || !ok0 || !ok1 || f <= 0 || t <= 0 || m1 <= 0 || m0 <= m1 {
+			return
+		}
+
+		specificImpulse := f * t / ((m0 - m1) * g0)
+		deltaSpeed := specificImpulse * g0 * math.Log(m0/m1)
+
+		m.number(isp, specificImpulse)
+		m.number(delta, deltaSpeed)
+
+		total += deltaSpeed
+	}
+
+	stage("force", "timeFire", "mass", "massEnd", "engineProp.specificImpulseOfBooster", "engineProp.deltaSpeedOfBooster")
+	stage("force1", "timeFire1", "massEnd", "massEnd1", "engineProp.specificImpulseOfSustainer", "engineProp.deltaSpeedOfSustainer")
+
+	if total > 0 {
+		m.number("engineProp.totalDeltaSpeed", total)
+	}
+
+	if f, ok := rocket.num("force"); ok {
+		if mass, ok := rocket.num("mass"); ok && mass > 0 {
+			m.number("engineProp.rawAccelerationAtIgnition", f/mass)
+		}
+	}
+
+	if rocket.has("thrustVectoring") {
+		m.flag("flightProp.thrustVectoring", true)
+	}
+}
+
+func (m *Missile) fuse(rocket node) {
+	fuse := rocket.block("proximityFuse")
+	if fuse == nil {
+		return
+	}
+
+	if on, ok := fuse.boolean("enabled"); ok && !on {
+		m.flag("fuseAndWarheadProp.proximityFuse", false)
+		return
+	}
+
+	m.flag("fuseAndWarheadProp.proximityFuse", true)
+
+	if f, ok := fuse.num("radius"); ok {
+		m.number("fuseAndWarheadProp.proximityFuseRange", f)
+	}
+
+	if f, ok := fuse.num("armDistance"); ok {
+		m.number("fuseAndWarheadProp.proximityFuseArmingDistance", f)
+	}
+
+	if b, ok := fuse.boolean("detectShells"); ok {
+		m.flag("fuseAndWarheadProp.proximityFuseShellDetection", b)
+	}
+
+	if f, ok := fuse.num("minAltitude"); ok {
+		m.number("fuseAndWarheadProp.proximityFuseMinimumAltitude", f)
+	}
+
+	if f, ok := fuse.num("timeOut"); ok {
+		m.number("fuseAndWarheadProp.proximityFuseDelay", f)
+	}
+}
+
+// seeker sets the parameters that depend on which blocks are present
+// rather than on a single number.
+func (m *Missile) seeker(guidance node) {
+	if guidance == nil {
+		return
+	}
+
+	if b, ok := guidance.boolean("uncageBeforeLaunch"); ok {
+		m.flag("guidanceProp.uncageSeekerBeforeLaunch", b)
+	}
+
+	if b, ok := guidance.boolean("lockAfterLaunch"); ok {
+		m.flag("guidanceProp.canLockAfterLaunch", b)
+	}
+
+	if guidance.has("inertialGuidance") {
+		m.flag("guidanceProp.inertialNavigation", true)
+	}
+
+	if autopilot := guidance.block("guidanceAutopilot"); autopilot.has("loftElevation") {
+		m.flag("flightProp.loft", true)
+	}
+
+	if ir := guidance.block("irSeeker"); ir != nil {
+		m.flag("guidanceProp.IRCCM", ir.has("bandMaskToReject"))
+
+		if f, ok := ir.num("rangeBand7"); ok && f > 0 {
+			m.flag("guidanceProp.canLockGround", true)
+		}
+	}
+
+	if radar := guidance.block("radarSeeker"); radar != nil {
+		band, ok := radar.num("band")
+		if !ok {
+			m.Warnings = append(m.Warnings, "radar seeker without a band")
+		} else if letter, ok := bandLetter(band); ok {
+			m.setValue("guidanceProp.band", letter)
+		} else {
+			m.Warnings = append(m.Warnings, fmt.Sprintf("unknown radar band %v", band))
+		}
+	}
+}
+
+// bandLetter turns the radar band index of the datamine (0 is A) into its
+// NATO letter.
+func bandLetter(band float64) (string, bool) {
+	if band < 0 || band > 'M'-'A' || band != math.Trunc(band) {
+		return "", false
+	}
+	return string(rune('A' + int(band))), true
+}
+
+// guidanceTypeOf names the guidance the way the categories do: IR, SARH,
+// ARH, SALH, TV, SACLOS, LOSBR, MCLOS or INS. It is empty for unguided
+// rockets and bombs.
+func guidanceTypeOf(rocket, guidance node) string {
+	switch {
+	case guidance.has("irSeeker"):
+		return "IR"
+	case guidance.has("radarSeeker"):
+		if guidance.block("radarSeeker").has("transmitter") {
+			return "ARH"
+		}
+		return "SARH"
+	case guidance.has("laserSeeker"):
+		return "SALH"
+	case guidance.has("opticalFlowSeeker"):
+		return "TV"
+	case guidance.has("lineOfSightControl"):
+		if b, _ := guidance.block("lineOfSightControl").boolean("beamRider"); b {
+			return "LOSBR"
+		}
+		return "SACLOS"
+	case guidance.has("inertialGuidance"):
+		return "INS"
+	}
+
+	if b, _ := rocket.boolean("operated"); b {
+		return "MCLOS"
+	}
+
+	return ""
+}
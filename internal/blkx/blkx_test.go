@@ -0,0 +1,204 @@
+package blkx
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/zeze322/wt-guided-weaponry/models"
+)
+
+func TestName(t *testing.T) {
+	tests := []struct {
+		path, want string
+	}{
+		{"rocketguns/us_aim_9l.blkx", "AIM-9L"},
+		{"rocketguns/us_aim_120a.blkx", "AIM-120A"},
+		{"rocketguns/us_agm_114b.blkx", "AGM-114B"},
+		{"groundmodels_weapons/ussr_9m113.blkx", "9M113"},
+		{"rocketguns/ussr_kh_23.blkx", "KH-23"},
+	}
+
+	for _, tt := range tests {
+		if got := Name(tt.path); got != tt.want {
+			t.Errorf("Name(%q) = %q, want %q", tt.path, got, tt.want)
+		}
+	}
+}
+
+func parseSample(t *testing.T, path string) (*Missile, error) {
+	t.Helper()
+
+	data, err := os.ReadFile(filepath.Join("testdata/datamine", path))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return Parse(path, data)
+}
+
+// TestParse checks the fields decoded from the samples, by their text.
+func TestParse(t *testing.T) {
+	tests := []struct {
+		path     string
+		name     string
+		category string
+		fields   map[string]string
+	}{
+		{
+			path:     "rocketguns/us_aim_9l.blkx",
+			name:     "AIM-9L",
+			category: models.CategoryIRAllAspect,
+			fields: map[string]string{
+				"physicalProp.mass":                      "85.5",
+				"physicalProp.massAtEndOfBoosterBurn":    "58",
+				"physicalProp.calibre":                   "127",
+				"engineProp.forceExertedByBooster":       "13000",
+				"engineProp.burnTimeOfBooster":           "5.2",
+				"engineProp.specificImpulseOfBooster":    "250.665",
+				"engineProp.deltaSpeedOfBooster":         "953.955",
+				"fuseAndWarheadProp.explosiveMass":       "3.54",
+				"fuseAndWarheadProp.proximityFuse":       "Yes",
+				"fuseAndWarheadProp.proximityFuseRange":  "9",
+				"guidanceProp.guidanceType":              "IR",
+				"guidanceProp.gimbalLimit":               "40",
+				"guidanceProp.lockOnRangeFromRearAspect": "10",
+				"guidanceProp.lockOnRangeFromAllAspect":  "5",
+				"guidanceProp.IRCCM":                     "No",
+				"flightProp.maximumSpeed":                "860",
+				"flightProp.maximumFlightRange":          "18",
+				"flightProp.maximumLateralAcceleration":  "30",
+				"engineProp.deltaSpeedOfSustainer":       "",
+				"physicalProp.massAtEndOfSustainerBurn":  "",
+			},
+		},
+		{
+			path:     "rocketguns/us_aim_7f.blkx",
+			name:     "AIM-7F",
+			category: models.CategoryAAMSARH,
+			fields: map[string]string{
+				"physicalProp.mass":                     "231",
+				"physicalProp.massAtEndOfSustainerBurn": "163",
+				"engineProp.deltaSpeedOfBooster":        "554.678",
+				"engineProp.deltaSpeedOfSustainer":      "303.018",
+				"engineProp.totalDeltaSpeed":            "857.696",
+				"guidanceProp.guidanceType":             "SARH",
+				"flightProp.maximumSpeed":               "1250",
+			},
+		},
+		{
+			path:     "groundmodels_weapons/ussr_9m113.blkx",
+			name:     "9M113",
+			category: models.CategoryATGMSACLOS,
+			fields: map[string]string{
+				"physicalProp.mass":                "25.2",
+				"engineProp.totalDeltaSpeed":       "347.68",
+				"fuseAndWarheadProp.penetration":   "600",
+				"fuseAndWarheadProp.explosiveMass": "2.7",
+				"guidanceProp.guidanceType":        "SACLOS",
+			},
+		},
+		{
+			path:     "rocketguns/us_agm_114b.blkx",
+			name:     "AGM-114B",
+			category: models.CategoryAGMSALH,
+			fields: map[string]string{
+				"engineProp.deltaSpeedOfBooster": "345.827",
+				"fuseAndWarheadProp.penetration": "800",
+				"guidanceProp.guidanceType":      "SALH",
+			},
+		},
+		{
+			path:     "rocketguns/us_aim_120a.blkx",
+			name:     "AIM-120A",
+			category: models.CategoryAAMARH,
+			fields: map[string]string{
+				"engineProp.rawAccelerationAtIgnition": "82.803",
+				"guidanceProp.guidanceType":            "ARH",
+				"flightProp.maximumSpeed":              "1400",
+			},
+		},
+		{
+			path:     "bombguns/us_gbu_12.blkx",
+			name:     "GBU-12",
+			category: models.CategoryGBU,
+			fields: map[string]string{
+				"physicalProp.mass":                "277",
+				"fuseAndWarheadProp.explosiveMass": "87",
+				"guidanceProp.guidanceType":        "SALH",
+				"engineProp.forceExertedByBooster": "",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m, err := parseSample(t, tt.path)
+			if err != nil {
+				t.Fatalf("Parse: %v", err)
+			}
+
+			if m.Params.Name != tt.name || m.Params.Category != tt.category {
+				t.Fatalf("Parse returned %s in %q, want %s in %q (%s)", m.Params.Name, m.Params.Category, tt.name, tt.category, m.Reason)
+			}
+
+			for key, want := range tt.fields {
+				field, ok := models.FieldByKey(key)
+				if !ok {
+					t.Fatalf("unknown field %s", key)
+				}
+
+				if got := field.Value(m.Params).String(); got != want {
+					t.Errorf("%s = %q, want %q", key, got, want)
+				}
+			}
+		})
+	}
+}
+
+func TestParseSkips(t *testing.T) {
+	tests := []struct {
+		path string
+		want error
+	}{
+		{"rocketguns/us_hydra_70_m151.blkx", ErrUnguided},
+		{"rocketguns/us_lau_7.blkx", ErrNoMissile},
+	}
+
+	for _, tt := range tests {
+		if _, err := parseSample(t, tt.path); !errors.Is(err, tt.want) {
+			t.Errorf("Parse(%q) returned %v, want %v", tt.path, err, tt.want)
+		}
+	}
+
+	var syntax *json.SyntaxError
+	if _, err := parseSample(t, "rocketguns/ussr_kh_23.blkx"); !errors.As(err, &syntax) {
+		t.Errorf("Parse of a malformed file returned %v, want a syntax error", err)
+	}
+}
+
+// TestParseDir compares the plan of the samples with the reviewed one in
+// testdata/plan.json. Regenerate it with blkx -o after a deliberate change.
+func TestParseDir(t *testing.T) {
+	plan, err := ParseDir("testdata/datamine")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := json.MarshalIndent(plan, "", "  ")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want, err := os.ReadFile("testdata/plan.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !bytes.Equal(append(got, '\n'), want) {
+		t.Fatal("the parsed plan differs from testdata/plan.json")
+	}
+}
@@ -0,0 +1,131 @@
+package blkx
+
+import (
+	"fmt"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/zeze322/wt-guided-weaponry/models"
+)
+
+// surfaceDirs are the datamine directories holding weapons launched from
+// ground vehicles and ships rather than aircraft.
+var surfaceDirs = []string{"groundmodels_weapons", "ships_weapons"}
+
+// infer returns the category slug of a missile and the reason for it. The
+// class comes from the bomb block, the tags of the rocket and where the file
+// lives in the datamine, the category within the class from the guidance.
+// Helicopter IR missiles look like any other IR missile in the datamine, so
+// ir-heli is never inferred and has to be set in the plan.
+func infer(path string, doc, rocket node, isBomb bool, guidanceType string) (string, string) {
+	if isBomb {
+		return models.CategoryGBU, "bomb with " + guidanceType + " guidance"
+	}
+
+	tags := rocket.block("tags")
+	if tags == nil {
+		tags = doc.block("tags")
+	}
+
+	surface := false
+	for _, dir := range strings.Split(filepath.ToSlash(path), "/") {
+		if slices.Contains(surfaceDirs, dir) {
+			surface = true
+		}
+	}
+
+	launch := "air"
+	if surface {
+		launch = "surface"
+	}
+
+	tagged := func(keys ...string) bool {
+		for _, key := range keys {
+			if b, _ := tags.boolean(key); b {
+				return true
+			}
+		}
+		return false
+	}
+
+	var class models.Class
+
+	switch {
+	case tagged("antiShip") && !tagged("antiAir"):
+		class = models.ClassAShM
+	case tagged("antiAir"):
+		class = models.ClassAAM
+		if surface {
+			class = models.ClassSAM
+		}
+	case tagged("antiTank", "antiGround", "antiSurface"):
+		class = models.ClassAGM
+		if surface {
+			class = models.ClassATGM
+		}
+	default:
+		return "", "no antiAir, antiTank, antiGround or antiShip tag"
+	}
+
+	slug := slugFor(class, guidanceType, rocket)
+	if slug == "" {
+		return "", fmt.Sprintf("no %s category for %s guidance", class, guidanceType)
+	}
+
+	return slug, fmt.Sprintf("%s-launched %s with %s guidance", launch, class, guidanceType)
+}
+
+func slugFor(class models.Class, guidanceType string, rocket node) string {
+	switch class {
+	case models.ClassAShM:
+		return models.CategoryAShM
+	case models.ClassAAM:
+		switch guidanceType {
+		case "IR":
+			if f, ok := rocket.block("guidance", "irSeeker").num("rangeBand1"); ok && f > 0 {
+				return models.CategoryIRAllAspect
+			}
+			return models.CategoryIRRearAspect
+		case "SARH":
+			return models.CategoryAAMSARH
+		case "ARH":
+			return models.CategoryAAMARH
+		case "MCLOS", "SACLOS", "LOSBR":
+			return models.CategoryAAMMCLOSLOSBR
+		}
+	case models.ClassSAM:
+		switch guidanceType {
+		case "IR":
+			return models.CategorySAMIR
+		case "SACLOS", "LOSBR":
+			return models.CategorySAMSACLOSLOSBR
+		}
+	case models.ClassAGM:
+		switch guidanceType {
+		case "IR", "TV", "ARH", "INS":
+			return models.CategoryAGMAutomatic
+		case "SALH":
+			return models.CategoryAGMSALH
+		case "SACLOS":
+			return models.CategoryAGMSACLOS
+		case "MCLOS":
+			return models.CategoryAGMMCLOS
+		case "LOSBR":
+			return models.CategoryAGMLOSBR
+		}
+	case models.ClassATGM:
+		switch guidanceType {
+		case "IR", "TV", "ARH", "SALH":
+			return models.CategoryATGMAutomatic
+		case "SACLOS":
+			return models.CategoryATGMSACLOS
+		case "MCLOS":
+			return models.CategoryATGMMCLOS
+		case "LOSBR":
+			return models.CategoryATGMLOSBR
+		}
+	}
+
+	return ""
+}
@@ -0,0 +1,84 @@
+package blkx
+
+// node is a block of a blkx file decoded from JSON. Blocks that appear more
+// than once in the original blk are decoded as arrays, the accessors use
+// the first occurrence.
+type node map[string]any
+
+func first(v any) any {
+	if list, ok := v.([]any); ok {
+		if len(list) == 0 {
+			return nil
+		}
+		return list[0]
+	}
+	return v
+}
+
+// block returns the sub-block at path, nil when it's missing.
+func (n node) block(path ...string) node {
+	cur := n
+	for _, key := range path {
+		if cur == nil {
+			return nil
+		}
+
+		m, ok := first(cur[key]).(map[string]any)
+		if !ok {
+			return nil
+		}
+
+		cur = m
+	}
+	return cur
+}
+
+func (n node) has(key string) bool {
+	if n == nil {
+		return false
+	}
+	_, ok := n[key]
+	return ok
+}
+
+func (n node) num(key string) (float64, bool) {
+	if n == nil {
+		return 0, false
+	}
+
+	switch v := first(n[key]).(type) {
+	case float64:
+		return v, true
+	case bool:
+		if v {
+			return 1, true
+		}
+		return 0, true
+	}
+
+	return 0, false
+}
+
+func (n node) boolean(key string) (bool, bool) {
+	if n == nil {
+		return false, false
+	}
+
+	switch v := first(n[key]).(type) {
+	case bool:
+		return v, true
+	case float64:
+		return v != 0, true
+	}
+
+	return false, false
+}
+
+func (n node) str(key string) (string, bool) {
+	if n == nil {
+		return "", false
+	}
+
+	s, ok := first(n[key]).(string)
+	return s, ok
+}
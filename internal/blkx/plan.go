@@ -0,0 +1,110 @@
+package blkx
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/zeze322/wt-guided-weaponry/internal/importer"
+	"github.com/zeze322/wt-guided-weaponry/models"
+)
+
+// Plan is what importing a datamine directory would do, one entry per
+// blkx file. It is meant to be reviewed, and edited where the inference
+// got it wrong, before it's applied.
+type Plan struct {
+	Entries []Entry `json:"entries"`
+}
+
+type Entry struct {
+	// File is the path of the blkx file relative to the directory.
+	File     string         `json:"file"`
+	Params   *models.Params `json:"params,omitempty"`
+	Reason   string         `json:"reason,omitempty"`
+	Warnings []string       `json:"warnings,omitempty"`
+
+	// Error is set instead of Params when the file couldn't be parsed.
+	Error string `json:"error,omitempty"`
+}
+
+// ParseDir parses every .blkx file below dir, in lexical order. Files that
+// can't be parsed are kept in the plan with their error. Launchers and
+// unguided rockets and bombs are left out.
+func ParseDir(dir string) (*Plan, error) {
+	plan := &Plan{Entries: []Entry{}}
+
+	err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+
+		if d.IsDir() || !strings.EqualFold(filepath.Ext(path), ".blkx") {
+			return nil
+		}
+
+		rel, err := filepath.Rel(dir, path)
+		if err != nil {
+			return err
+		}
+
+		entry := Entry{File: filepath.ToSlash(rel)}
+
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+
+		m, err := Parse(entry.File, data)
+		if errors.Is(err, ErrNoMissile) || errors.Is(err, ErrUnguided) {
+			return nil
+		}
+
+		if err != nil {
+			entry.Error = err.Error()
+		} else {
+			entry.Params = m.Params
+			entry.Reason = m.Reason
+			entry.Warnings = m.Warnings
+		}
+
+		plan.Entries = append(plan.Entries, entry)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return plan, nil
+}
+
+// Records turns the plan into records for importer.Run. Entries without a
+// category fail there with the reason it couldn't be inferred.
+func (p *Plan) Records() []importer.Record {
+	records := make([]importer.Record, 0, len(p.Entries))
+
+	for _, entry := range p.Entries {
+		var rec importer.Record
+
+		switch {
+		case entry.Error != "":
+			rec.Err = fmt.Errorf("%s: %s", entry.File, entry.Error)
+		case entry.Params.Category == "":
+			rec.Err = fmt.Errorf("%s: no category, %s", entry.File, entry.Reason)
+		default:
+			rec.Params = entry.Params.Clone()
+		}
+
+		records = append(records, rec)
+	}
+
+	return records
+}
+
+// Apply imports the plan into store.
+func (p *Plan) Apply(ctx context.Context, store importer.Store, opts importer.Options) (*importer.Report, error) {
+	return importer.Run(ctx, store, p.Records(), opts)
+}
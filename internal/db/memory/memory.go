@@ -0,0 +1,488 @@
+package memory
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/zeze322/wt-guided-weaponry/internal/db/mongodb"
+	"github.com/zeze322/wt-guided-weaponry/internal/filter"
+	"github.com/zeze322/wt-guided-weaponry/models"
+)
+
+// MemoryStore keeps weapons in process memory. It mirrors the behavior of
+// mongodb.MongoClient so it can stand in for it in tests and offline development.
+type MemoryStore struct {
+	mu         sync.RWMutex
+	weapons    []*models.Params
+	categories []models.Category
+	versions   []models.GameVersion
+	history    map[string][]models.Snapshot
+}
+
+func New() *MemoryStore {
+	return &MemoryStore{}
+}
+
+func (m *MemoryStore) Categories(ctx context.Context) ([]models.Category, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	categories := slices.Clone(m.categories)
+
+	slices.SortStableFunc(categories, func(a, b models.Category) int {
+		if a.SortOrder != b.SortOrder {
+			return cmp.Compare(a.SortOrder, b.SortOrder)
+		}
+		return strings.Compare(a.Slug, b.Slug)
+	})
+
+	return categories, nil
+}
+
+func (m *MemoryStore) Category(ctx context.Context, slug string) (*models.Category, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	i := m.categoryIndex(slug)
+	if i == -1 {
+		return nil, fmt.Errorf("%w: %s", mongodb.ErrNothingFound, slug)
+	}
+
+	category := m.categories[i]
+
+	return &category, nil
+}
+
+func (m *MemoryStore) InsertCategory(ctx context.Context, category *models.Category) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.categoryIndex(category.Slug) != -1 {
+		return fmt.Errorf("%s %w", category.Slug, mongodb.ErrConflict)
+	}
+
+	m.categories = append(m.categories, *category)
+
+	return nil
+}
+
+func (m *MemoryStore) UpdateCategory(ctx context.Context, slug string, category *models.Category) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	i := m.categoryIndex(slug)
+	if i == -1 {
+		return fmt.Errorf("%w: %s", mongodb.ErrNothingFound, slug)
+	}
+
+	if j := m.categoryIndex(category.Slug); j != -1 && j != i {
+		return fmt.Errorf("%s %w", category.Slug, mongodb.ErrConflict)
+	}
+
+	m.categories[i] = *category
+
+	return nil
+}
+
+func (m *MemoryStore) DeleteCategory(ctx context.Context, slug string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	i := m.categoryIndex(slug)
+	if i == -1 {
+		return fmt.Errorf("%w: %s", mongodb.ErrNothingFound, slug)
+	}
+
+	m.categories = slices.Delete(m.categories, i, i+1)
+
+	return nil
+}
+
+func (m *MemoryStore) Weapons(ctx context.Context) ([]*models.Params, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	var weapons []*models.Params
+
+	for _, weapon := range m.weapons {
+		if weapon.Deleted == nil {
+			weapons = append(weapons, clone(weapon))
+		}
+	}
+
+	return weapons, nil
+}
+
+func (m *MemoryStore) Weapon(ctx context.Context, name string) (*models.Params, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	i := m.indexOf(name)
+	if i == -1 || m.weapons[i].Deleted != nil {
+		return nil, fmt.Errorf("%w: %s", mongodb.ErrNothingFound, name)
+	}
+
+	return clone(m.weapons[i]), nil
+}
+
+func (m *MemoryStore) WeaponBySlug(ctx context.Context, slug string) (*models.Params, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	for _, weapon := range m.weapons {
+		if weapon.Slug == slug && weapon.Deleted == nil {
+			return clone(weapon), nil
+		}
+	}
+
+	return nil, fmt.Errorf("%w: no weapon has the slug %s", mongodb.ErrNothingFound, slug)
+}
+
+func (m *MemoryStore) ListWeapons(ctx context.Context, opts mongodb.ListOptions) (*mongodb.Page, error) {
+	if err := opts.Validate(); err != nil {
+		return nil, err
+	}
+
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	var weapons []*models.Params
+
+	for _, weapon := range m.weapons {
+		if weapon.Deleted != nil || (opts.Category != "" && weapon.Category != opts.Category) {
+			continue
+		}
+
+		if opts.Filter != nil && !filter.Eval(opts.Filter, weapon) {
+			continue
+		}
+
+		weapons = append(weapons, clone(weapon))
+	}
+
+	slices.SortFunc(weapons, opts.Compare)
+
+	return mongodb.Paginate(weapons, opts)
+}
+
+func (m *MemoryStore) WeaponsByCategory(ctx context.Context, category string) ([]*models.Params, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	var weapons []*models.Params
+
+	for _, weapon := range m.weapons {
+		if weapon.Category == category && weapon.Deleted == nil {
+			weapons = append(weapons, clone(weapon))
+		}
+	}
+
+	if len(weapons) == 0 {
+		return nil, mongodb.ErrNothingFound
+	}
+
+	return weapons, nil
+}
+
+// InsertWeapon stores params under a new slug, which it sets on params,
+// replacing any slug the caller set.
+func (m *MemoryStore) InsertWeapon(ctx context.Context, params *models.Params) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.indexOf(params.Name) != -1 {
+		return fmt.Errorf("%s %w", params.Name, mongodb.ErrConflict)
+	}
+
+	params.Slug = m.newSlug(params.Name)
+
+	m.weapons = append(m.weapons, clone(models.NewWeapon(params)))
+	m.recordSnapshot(params)
+
+	return nil
+}
+
+func (m *MemoryStore) UpdateWeapon(ctx context.Context, name string, params *models.Params) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	i := m.indexOf(name)
+	if i == -1 || m.weapons[i].Deleted != nil {
+		return fmt.Errorf("%w: %s", mongodb.ErrNothingFound, name)
+	}
+
+	if params.Name != name && m.indexOf(params.Name) != -1 {
+		return fmt.Errorf("%s %w", params.Name, mongodb.ErrConflict)
+	}
+
+	slug := m.weapons[i].Slug
+
+	m.weapons[i] = clone(models.NewWeapon(params))
+	m.weapons[i].Slug = slug
+
+	if params.Name != name {
+		m.renameHistory(name, params.Name)
+	}
+
+	m.recordSnapshot(params)
+
+	return nil
+}
+
+func (m *MemoryStore) SearchWeapon(ctx context.Context, keyWord string) ([]models.Name, error) {
+	re, err := regexp.Compile("(?i)" + regexp.QuoteMeta(keyWord))
+	if err != nil {
+		return nil, err
+	}
+
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	var weapons []models.Name
+
+	for _, weapon := range m.weapons {
+		if re.MatchString(weapon.Name) && weapon.Deleted == nil {
+			weapons = append(weapons, models.Name{Name: weapon.Name, Slug: weapon.Slug, Category: weapon.Category})
+		}
+	}
+
+	if len(weapons) == 0 {
+		return nil, mongodb.ErrNothingFound
+	}
+
+	return weapons, nil
+}
+
+func (m *MemoryStore) DeleteWeapon(ctx context.Context, name, reason string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	i := m.indexOf(name)
+	if i == -1 || m.weapons[i].Deleted != nil {
+		return fmt.Errorf("%w: %s", mongodb.ErrNothingFound, name)
+	}
+
+	m.weapons[i].Deleted = &models.Deletion{
+		DeletedAt: time.Now().UTC(),
+		Reason:    reason,
+	}
+
+	m.recordSnapshot(m.weapons[i])
+
+	return nil
+}
+
+func (m *MemoryStore) DeletedWeapons(ctx context.Context) ([]*models.Params, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	var weapons []*models.Params
+
+	for _, weapon := range m.weapons {
+		if weapon.Deleted != nil {
+			weapons = append(weapons, clone(weapon))
+		}
+	}
+
+	return weapons, nil
+}
+
+func (m *MemoryStore) RestoreWeapon(ctx context.Context, name string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	i := m.indexOf(name)
+	if i == -1 || m.weapons[i].Deleted == nil {
+		return fmt.Errorf("%w: %s isn't deleted", mongodb.ErrNothingFound, name)
+	}
+
+	m.weapons[i].Deleted = nil
+
+	m.recordSnapshot(m.weapons[i])
+
+	return nil
+}
+
+func (m *MemoryStore) GameVersions(ctx context.Context) ([]models.GameVersion, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	return slices.Clone(m.versions), nil
+}
+
+func (m *MemoryStore) InsertGameVersion(ctx context.Context, version *models.GameVersion) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if slices.ContainsFunc(m.versions, func(v models.GameVersion) bool { return v.Version == version.Version }) {
+		return fmt.Errorf("%s %w", version.Version, mongodb.ErrConflict)
+	}
+
+	first := len(m.versions) == 0
+
+	m.versions = append(m.versions, *version)
+	slices.SortStableFunc(m.versions, models.CompareGameVersions)
+
+	if first {
+		for _, weapon := range m.weapons {
+			if weapon.Deleted == nil {
+				m.writeSnapshot(version.Version, weapon)
+			}
+		}
+	}
+
+	return nil
+}
+
+func (m *MemoryStore) WeaponHistory(ctx context.Context, name string) ([]models.Snapshot, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	if m.indexOf(name) == -1 {
+		return nil, fmt.Errorf("%w: %s", mongodb.ErrNothingFound, name)
+	}
+
+	history := mongodb.SortHistory(m.history[name], m.versions)
+	for i := range history {
+		history[i].Params = clone(history[i].Params)
+	}
+
+	return history, nil
+}
+
+func (m *MemoryStore) WeaponAt(ctx context.Context, name, version string) (*models.Params, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	if err := m.checkVersion(version); err != nil {
+		return nil, err
+	}
+
+	s := models.SnapshotAt(m.history[name], m.versions, version)
+	if s == nil || s.Params.Deleted != nil {
+		return nil, fmt.Errorf("%w: %s didn't exist in %s", mongodb.ErrNothingFound, name, version)
+	}
+
+	return clone(s.Params), nil
+}
+
+func (m *MemoryStore) WeaponsAt(ctx context.Context, version string) ([]*models.Params, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	if err := m.checkVersion(version); err != nil {
+		return nil, err
+	}
+
+	names := make([]string, 0, len(m.weapons))
+	for _, weapon := range m.weapons {
+		names = append(names, weapon.Name)
+	}
+
+	return mongodb.SnapshotsAt(names, m.history, m.versions, version), nil
+}
+
+func (m *MemoryStore) WeaponsByCategoryAt(ctx context.Context, category, version string) ([]*models.Params, error) {
+	weapons, err := m.WeaponsAt(ctx, version)
+	if err != nil {
+		return nil, err
+	}
+
+	return mongodb.InCategory(weapons, category)
+}
+
+func (m *MemoryStore) checkVersion(version string) error {
+	if !slices.ContainsFunc(m.versions, func(v models.GameVersion) bool { return v.Version == version }) {
+		return fmt.Errorf("%w: %s", mongodb.ErrUnknownVersion, version)
+	}
+
+	return nil
+}
+
+// recordSnapshot tags params with the latest game version. Nothing is
+// recorded while the store has no versions. A deleted weapon is recorded
+// with its deletion, so it's missing from that version on.
+func (m *MemoryStore) recordSnapshot(params *models.Params) {
+	if len(m.versions) > 0 {
+		m.writeSnapshot(m.versions[len(m.versions)-1].Version, params)
+	}
+}
+
+func (m *MemoryStore) writeSnapshot(version string, params *models.Params) {
+	if m.history == nil {
+		m.history = map[string][]models.Snapshot{}
+	}
+
+	weapon := models.NewWeapon(params)
+	weapon.Deleted = params.Deleted
+
+	s := models.Snapshot{Version: version, Date: time.Now().UTC(), Params: clone(weapon)}
+
+	history := m.history[params.Name]
+
+	i := slices.IndexFunc(history, func(s models.Snapshot) bool { return s.Version == version })
+	if i == -1 {
+		m.history[params.Name] = append(history, s)
+		return
+	}
+
+	history[i] = s
+}
+
+func (m *MemoryStore) renameHistory(from, to string) {
+	history := m.history[from]
+	if history == nil {
+		return
+	}
+
+	for i := range history {
+		history[i].Params.Name = to
+	}
+
+	m.history[to] = history
+	delete(m.history, from)
+}
+
+func (m *MemoryStore) indexOf(name string) int {
+	for i, weapon := range m.weapons {
+		if weapon.Name == name {
+			return i
+		}
+	}
+
+	return -1
+}
+
+// newSlug returns the slug of name, numbered when another weapon, deleted
+// or not, already has it.
+func (m *MemoryStore) newSlug(name string) string {
+	base := models.Slug(name)
+
+	for n := 1; ; n++ {
+		slug := mongodb.NumberSlug(base, n)
+
+		if !slices.ContainsFunc(m.weapons, func(w *models.Params) bool { return w.Slug == slug }) {
+			return slug
+		}
+	}
+}
+
+func (m *MemoryStore) categoryIndex(slug string) int {
+	for i, category := range m.categories {
+		if category.Slug == slug {
+			return i
+		}
+	}
+
+	return -1
+}
+
+func clone(params *models.Params) *models.Params {
+	return params.Clone()
+}
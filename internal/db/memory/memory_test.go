@@ -0,0 +1,14 @@
+package memory
+
+import (
+	"testing"
+
+	"github.com/zeze322/wt-guided-weaponry/internal/db/mongodb"
+	"github.com/zeze322/wt-guided-weaponry/internal/db/storetest"
+)
+
+func TestStore(t *testing.T) {
+	storetest.Run(t, func(t *testing.T) mongodb.Store {
+		return New()
+	})
+}
@@ -0,0 +1,157 @@
+package mongodb
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+
+	"github.com/zeze322/wt-guided-weaponry/models"
+)
+
+func TestDuplicateKey(t *testing.T) {
+	raw := func(doc bson.D) bson.Raw {
+		data, err := bson.Marshal(doc)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return data
+	}
+
+	writeError := func(e mongo.WriteError) error {
+		return fmt.Errorf("insert: %w", mongo.WriteException{WriteErrors: []mongo.WriteError{e}})
+	}
+
+	tests := []struct {
+		name string
+		err  error
+		want string
+	}{
+		{
+			name: "key pattern",
+			err: writeError(mongo.WriteError{
+				Code:    11000,
+				Message: "E11000 duplicate key error collection: wt.weapons index: slug_1 dup key: { slug: \"aim-9l\" }",
+				Raw:     raw(bson.D{{Key: "code", Value: 11000}, {Key: "keyPattern", Value: bson.D{{Key: "slug", Value: 1}}}}),
+			}),
+			want: "slug",
+		},
+		{
+			name: "message",
+			err: writeError(mongo.WriteError{
+				Code:    11000,
+				Message: "E11000 duplicate key error collection: wt.weapons index: name_1 dup key: { name: \"AIM-9L\" }",
+			}),
+			want: "name",
+		},
+		{
+			name: "other write error",
+			err:  writeError(mongo.WriteError{Code: 121, Message: "Document failed validation"}),
+		},
+		{
+			name: "not a write error",
+			err:  errors.New("connection refused"),
+		},
+		{
+			name: "nil",
+		},
+	}
+
+	for _, tt := range tests {
+		if got := duplicateKey(tt.err); got != tt.want {
+			t.Errorf("%s: duplicateKey returned %q, want %q", tt.name, got, tt.want)
+		}
+	}
+}
+
+// duplicate returns the error of an insert that breaks the unique index on
+// key, as reported by servers without key patterns.
+func duplicate(key string) error {
+	return mongo.WriteException{WriteErrors: []mongo.WriteError{{
+		Code:    11000,
+		Message: fmt.Sprintf("E11000 duplicate key error collection: wt.weapons index: %s_1 dup key: { %s: \"x\" }", key, key),
+	}}}
+}
+
+func TestInsertWithSlug(t *testing.T) {
+	errDown := errors.New("connection refused")
+
+	tests := []struct {
+		name     string
+		slugErr  error
+		inserts  []error // returned by the inserts in turn, then nil
+		wantSlug string
+		wantErr  error
+		attempts int
+	}{
+		{name: "free slug", wantSlug: "aim-9l", attempts: 1},
+		{name: "slug taken meanwhile", inserts: []error{duplicate("slug"), duplicate("slug")}, wantSlug: "aim-9l-3", attempts: 3},
+		{name: "name taken", inserts: []error{duplicate("name")}, wantErr: ErrConflict, attempts: 1},
+		{name: "slug taken, then name", inserts: []error{duplicate("slug"), duplicate("name")}, wantErr: ErrConflict, attempts: 2},
+		{name: "insert fails", inserts: []error{errDown}, wantErr: errDown, attempts: 1},
+		{name: "slugs fail", slugErr: errDown, wantErr: errDown},
+	}
+
+	for _, tt := range tests {
+		var slugs, attempts int
+
+		newSlug := func(ctx context.Context, name string) (string, error) {
+			slugs++
+			return NumberSlug(models.Slug(name), slugs), tt.slugErr
+		}
+
+		insert := func(ctx context.Context, weapon *models.Params) error {
+			if weapon.Slug != NumberSlug("aim-9l", slugs) {
+				t.Errorf("%s: inserted slug %q after %d slugs", tt.name, weapon.Slug, slugs)
+			}
+
+			attempts++
+			if attempts <= len(tt.inserts) {
+				return tt.inserts[attempts-1]
+			}
+			return nil
+		}
+
+		params := &models.Params{Name: "AIM-9L", Slug: "chosen-by-the-caller"}
+
+		err := insertWithSlug(context.Background(), params, newSlug, insert)
+		if !errors.Is(err, tt.wantErr) {
+			t.Errorf("%s: insertWithSlug returned %v, want %v", tt.name, err, tt.wantErr)
+		}
+
+		if attempts != tt.attempts {
+			t.Errorf("%s: %d inserts, want %d", tt.name, attempts, tt.attempts)
+		}
+
+		if tt.wantErr == nil && params.Slug != tt.wantSlug {
+			t.Errorf("%s: slug %q, want %q", tt.name, params.Slug, tt.wantSlug)
+		}
+	}
+}
+
+// TestInsertWithSlugGivesUp checks that inserts racing for every slug end
+// with the duplicate-key error after slugAttempts slugs.
+func TestInsertWithSlugGivesUp(t *testing.T) {
+	var attempts int
+
+	newSlug := func(ctx context.Context, name string) (string, error) {
+		return "aim-9l", nil
+	}
+
+	insert := func(ctx context.Context, weapon *models.Params) error {
+		attempts++
+		return duplicate("slug")
+	}
+
+	err := insertWithSlug(context.Background(), &models.Params{Name: "AIM-9L"}, newSlug, insert)
+	if duplicateKey(err) != "slug" || errors.Is(err, ErrConflict) {
+		t.Errorf("insertWithSlug returned %v, want the slug's duplicate-key error", err)
+	}
+
+	if attempts != slugAttempts {
+		t.Errorf("%d inserts, want %d", attempts, slugAttempts)
+	}
+}
@@ -0,0 +1,8 @@
+package mongodb
+
+import "context"
+
+// DropDatabase removes the database of m, for tests that create one each.
+func (m *MongoClient) DropDatabase(ctx context.Context) error {
+	return m.client.Database(m.mongoDatabase).Drop(ctx)
+}
@@ -0,0 +1,94 @@
+package mongodb
+
+import (
+	"regexp"
+
+	"go.mongodb.org/mongo-driver/bson"
+
+	"github.com/zeze322/wt-guided-weaponry/internal/filter"
+	"github.com/zeze322/wt-guided-weaponry/models"
+)
+
+// numericKinds are the kinds of values with a magnitude, see Value.Float.
+var numericKinds = bson.A{models.KindNumber, models.KindRange, models.KindTuple}
+
+var comparisonOps = map[filter.Op]string{
+	filter.OpEq: "$eq",
+	filter.OpNe: "$ne",
+	filter.OpLt: "$lt",
+	filter.OpLe: "$lte",
+	filter.OpGt: "$gt",
+	filter.OpGe: "$gte",
+}
+
+// filterDocument translates a filter expression into a query matching the
+// same weapons as filter.Eval.
+func filterDocument(n filter.Node) bson.M {
+	switch n := n.(type) {
+	case filter.And:
+		return bson.M{"$and": filterDocuments(n.Operands)}
+	case filter.Or:
+		return bson.M{"$or": filterDocuments(n.Operands)}
+	case filter.Not:
+		return bson.M{"$nor": bson.A{filterDocument(n.Operand)}}
+	case filter.Exists:
+		if n.Field.Param == nil {
+			return bson.M{}
+		}
+		return present(n.Field.Key)
+	case filter.In:
+		var operands []filter.Node
+		for _, value := range n.Values {
+			operands = append(operands, filter.Compare{Field: n.Field, Op: filter.OpEq, Value: value})
+		}
+		return bson.M{"$or": filterDocuments(operands)}
+	case filter.Compare:
+		return compareDocument(n)
+	default:
+		// Parse never produces other nodes, match nothing to be safe.
+		return bson.M{"_id": bson.M{"$exists": false}}
+	}
+}
+
+func filterDocuments(nodes []filter.Node) bson.A {
+	docs := bson.A{}
+	for _, n := range nodes {
+		docs = append(docs, filterDocument(n))
+	}
+	return docs
+}
+
+// present matches documents that have a non-null value for key.
+func present(key string) bson.M {
+	return bson.M{key: bson.M{"$exists": true, "$ne": nil}}
+}
+
+func compareDocument(n filter.Compare) bson.M {
+	key := n.Field.Key
+
+	if n.Field.Param == nil {
+		return bson.M{key: bson.M{comparisonOps[n.Op]: n.Value.Raw}}
+	}
+
+	var equal bson.M
+
+	switch n.Field.Param.Kind {
+	case models.KindNumber:
+		// The kind keeps out weapons without the parameter, which $ne
+		// alone would match, and text values, whose magnitude is 0.
+		return bson.M{
+			key + ".kind":      bson.M{"$in": numericKinds},
+			key + ".magnitude": bson.M{comparisonOps[n.Op]: n.Value.Value.Magnitude},
+		}
+	case models.KindBool:
+		equal = bson.M{key + ".kind": "bool", key + ".magnitude": n.Value.Value.Magnitude}
+	default:
+		equal = bson.M{key + ".text": bson.M{"$regex": "^" + regexp.QuoteMeta(n.Value.Raw) + "$", "$options": "i"}}
+	}
+
+	if n.Op == filter.OpNe {
+		return bson.M{"$and": bson.A{present(key), bson.M{"$nor": bson.A{equal}}}}
+	}
+
+	return equal
+}
@@ -0,0 +1,28 @@
+package mongodb
+
+import (
+	"reflect"
+	"testing"
+
+	"go.mongodb.org/mongo-driver/bson"
+
+	"github.com/zeze322/wt-guided-weaponry/internal/filter"
+)
+
+// TestFilterDocumentKind checks that numeric comparisons skip text values,
+// whose magnitude is 0, as filter.Eval does.
+func TestFilterDocumentKind(t *testing.T) {
+	node, err := filter.Parse("physicalProp.mass < 10")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := bson.M{
+		"physicalProp.mass.kind":      bson.M{"$in": numericKinds},
+		"physicalProp.mass.magnitude": bson.M{"$lt": 10.0},
+	}
+
+	if got := filterDocument(node); !reflect.DeepEqual(got, want) {
+		t.Fatalf("filterDocument(%s) = %v, want %v", node, got, want)
+	}
+}
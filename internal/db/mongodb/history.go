@@ -0,0 +1,342 @@
+package mongodb
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"github.com/zeze322/wt-guided-weaponry/models"
+)
+
+const (
+	versionsCollection = "versions"
+	historyCollection  = "history"
+)
+
+// ErrUnknownVersion is returned when reading weapons at a game version the
+// store doesn't have.
+var ErrUnknownVersion = errors.New("unknown game version")
+
+var byReleaseDate = bson.D{{Key: "date", Value: 1}, {Key: "version", Value: 1}}
+
+// snapshot is a document of the history collection.
+type snapshot struct {
+	Name            string `bson:"name"`
+	models.Snapshot `bson:",inline"`
+}
+
+func (m *MongoClient) GameVersions(ctx context.Context) ([]models.GameVersion, error) {
+	coll := m.client.Database(m.mongoDatabase).Collection(versionsCollection)
+
+	cursor, err := coll.Find(ctx, bson.M{}, options.Find().SetSort(byReleaseDate))
+	if err != nil {
+		return nil, err
+	}
+
+	defer cursor.Close(ctx)
+
+	var versions []models.GameVersion
+
+	if err := cursor.All(ctx, &versions); err != nil {
+		return nil, err
+	}
+
+	if err := cursor.Err(); err != nil {
+		return nil, err
+	}
+
+	return versions, nil
+}
+
+// InsertGameVersion adds a game version. Adding the first one snapshots
+// every weapon, so the numbers entered before versions were tracked become
+// the baseline of that version.
+func (m *MongoClient) InsertGameVersion(ctx context.Context, version *models.GameVersion) error {
+	coll := m.client.Database(m.mongoDatabase).Collection(versionsCollection)
+
+	count, err := coll.CountDocuments(ctx, bson.M{})
+	if err != nil {
+		return err
+	}
+
+	_, err = coll.InsertOne(ctx, version)
+	if mongo.IsDuplicateKeyError(err) {
+		return fmt.Errorf("%s %w", version.Version, ErrConflict)
+	}
+
+	if err != nil {
+		return err
+	}
+
+	if count > 0 {
+		return nil
+	}
+
+	weapons, err := m.Weapons(ctx)
+	if err != nil {
+		return err
+	}
+
+	for _, weapon := range weapons {
+		if err := m.writeSnapshot(ctx, version.Version, weapon); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// WeaponHistory lists the snapshots of a weapon, oldest first. Deleted
+// weapons keep their history.
+func (m *MongoClient) WeaponHistory(ctx context.Context, name string) ([]models.Snapshot, error) {
+	coll := m.client.Database(m.mongoDatabase).Collection(m.mongoCollection)
+
+	err := coll.FindOne(ctx, bson.M{"name": name}).Err()
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return nil, fmt.Errorf("%w: %s", ErrNothingFound, name)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	versions, err := m.GameVersions(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	history, err := m.history(ctx, bson.M{"name": name})
+	if err != nil {
+		return nil, err
+	}
+
+	return SortHistory(history[name], versions), nil
+}
+
+// WeaponAt returns a weapon as it was in version. Weapons deleted since
+// are still returned for the versions before their deletion.
+func (m *MongoClient) WeaponAt(ctx context.Context, name, version string) (*models.Params, error) {
+	versions, err := m.knownVersions(ctx, version)
+	if err != nil {
+		return nil, err
+	}
+
+	history, err := m.history(ctx, bson.M{"name": name})
+	if err != nil {
+		return nil, err
+	}
+
+	s := models.SnapshotAt(history[name], versions, version)
+	if s == nil || s.Params.Deleted != nil {
+		return nil, fmt.Errorf("%w: %s didn't exist in %s", ErrNothingFound, name, version)
+	}
+
+	return s.Params, nil
+}
+
+// WeaponsAt returns every weapon as it was in version, in insertion order.
+func (m *MongoClient) WeaponsAt(ctx context.Context, version string) ([]*models.Params, error) {
+	versions, err := m.knownVersions(ctx, version)
+	if err != nil {
+		return nil, err
+	}
+
+	coll := m.client.Database(m.mongoDatabase).Collection(m.mongoCollection)
+
+	cursor, err := coll.Find(ctx, bson.M{}, options.Find().SetSort(byInsertion).SetProjection(bson.M{"name": 1}))
+	if err != nil {
+		return nil, err
+	}
+
+	defer cursor.Close(ctx)
+
+	var all []models.Name
+
+	if err := cursor.All(ctx, &all); err != nil {
+		return nil, err
+	}
+
+	if err := cursor.Err(); err != nil {
+		return nil, err
+	}
+
+	names := make([]string, 0, len(all))
+	for _, weapon := range all {
+		names = append(names, weapon.Name)
+	}
+
+	history, err := m.history(ctx, bson.M{})
+	if err != nil {
+		return nil, err
+	}
+
+	return SnapshotsAt(names, history, versions, version), nil
+}
+
+func (m *MongoClient) WeaponsByCategoryAt(ctx context.Context, category, version string) ([]*models.Params, error) {
+	weapons, err := m.WeaponsAt(ctx, version)
+	if err != nil {
+		return nil, err
+	}
+
+	return InCategory(weapons, category)
+}
+
+func (m *MongoClient) knownVersions(ctx context.Context, version string) ([]models.GameVersion, error) {
+	versions, err := m.GameVersions(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if !slices.ContainsFunc(versions, func(v models.GameVersion) bool { return v.Version == version }) {
+		return nil, fmt.Errorf("%w: %s", ErrUnknownVersion, version)
+	}
+
+	return versions, nil
+}
+
+// history returns the snapshots matching filter by weapon name.
+func (m *MongoClient) history(ctx context.Context, filter bson.M) (map[string][]models.Snapshot, error) {
+	coll := m.client.Database(m.mongoDatabase).Collection(historyCollection)
+
+	cursor, err := coll.Find(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	defer cursor.Close(ctx)
+
+	var docs []snapshot
+
+	if err := cursor.All(ctx, &docs); err != nil {
+		return nil, err
+	}
+
+	if err := cursor.Err(); err != nil {
+		return nil, err
+	}
+
+	history := map[string][]models.Snapshot{}
+	for _, doc := range docs {
+		history[doc.Name] = append(history[doc.Name], doc.Snapshot)
+	}
+
+	return history, nil
+}
+
+// recordSnapshot tags params with the latest game version. Nothing is
+// recorded while the store has no versions. A deleted weapon is recorded
+// with its deletion, so it's missing from that version on.
+func (m *MongoClient) recordSnapshot(ctx context.Context, params *models.Params) error {
+	coll := m.client.Database(m.mongoDatabase).Collection(versionsCollection)
+
+	latest := new(models.GameVersion)
+
+	opts := options.FindOne().SetSort(bson.D{{Key: "date", Value: -1}, {Key: "version", Value: -1}})
+
+	err := coll.FindOne(ctx, bson.M{}, opts).Decode(latest)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return nil
+	}
+
+	if err != nil {
+		return err
+	}
+
+	return m.writeSnapshot(ctx, latest.Version, params)
+}
+
+// recordStored snapshots the weapon as stored, deleted or not.
+func (m *MongoClient) recordStored(ctx context.Context, name string) error {
+	coll := m.client.Database(m.mongoDatabase).Collection(m.mongoCollection)
+
+	weapon := new(models.Params)
+	if err := coll.FindOne(ctx, bson.M{"name": name}).Decode(weapon); err != nil {
+		return err
+	}
+
+	return m.recordSnapshot(ctx, weapon)
+}
+
+func (m *MongoClient) writeSnapshot(ctx context.Context, version string, params *models.Params) error {
+	coll := m.client.Database(m.mongoDatabase).Collection(historyCollection)
+
+	weapon := models.NewWeapon(params)
+	weapon.Deleted = params.Deleted
+
+	update := bson.M{"$set": bson.M{"date": time.Now().UTC(), "params": weapon}}
+	filter := bson.M{"name": params.Name, "version": version}
+
+	_, err := coll.UpdateOne(ctx, filter, update, options.Update().SetUpsert(true))
+
+	return err
+}
+
+// renameHistory moves the snapshots of a renamed weapon to its new name.
+func (m *MongoClient) renameHistory(ctx context.Context, from, to string) error {
+	coll := m.client.Database(m.mongoDatabase).Collection(historyCollection)
+
+	update := bson.M{"$set": bson.M{"name": to, "params.name": to}}
+
+	_, err := coll.UpdateMany(ctx, bson.M{"name": from}, update)
+
+	return err
+}
+
+// SortHistory orders snapshots like their versions, oldest first.
+func SortHistory(history []models.Snapshot, versions []models.GameVersion) []models.Snapshot {
+	rank := make(map[string]int, len(versions))
+	for i, v := range versions {
+		rank[v.Version] = i
+	}
+
+	res := slices.Clone(history)
+	slices.SortStableFunc(res, func(a, b models.Snapshot) int {
+		return rank[a.Version] - rank[b.Version]
+	})
+
+	if res == nil {
+		res = []models.Snapshot{}
+	}
+
+	return res
+}
+
+// SnapshotsAt returns the weapons of names as they were in version,
+// leaving out those that didn't exist yet or had been deleted by then.
+func SnapshotsAt(names []string, history map[string][]models.Snapshot, versions []models.GameVersion, version string) []*models.Params {
+	weapons := []*models.Params{}
+
+	for _, name := range names {
+		s := models.SnapshotAt(history[name], versions, version)
+		if s != nil && s.Params.Deleted == nil {
+			weapons = append(weapons, s.Params.Clone())
+		}
+	}
+
+	return weapons
+}
+
+// InCategory keeps the weapons of category, ErrNothingFound when there are
+// none.
+func InCategory(weapons []*models.Params, category string) ([]*models.Params, error) {
+	var res []*models.Params
+
+	for _, weapon := range weapons {
+		if weapon.Category == category {
+			res = append(res, weapon)
+		}
+	}
+
+	if len(res) == 0 {
+		return nil, ErrNothingFound
+	}
+
+	return res, nil
+}
@@ -5,14 +5,46 @@ import (
 
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
 )
 
 func (m *MongoClient) CreateIndex(ctx context.Context) error {
 	coll := m.client.Database(m.mongoDatabase).Collection(m.mongoCollection)
 
-	model := mongo.IndexModel{Keys: bson.D{{"name", "text"}}}
+	models := []mongo.IndexModel{
+		{Keys: bson.D{{Key: "name", Value: "text"}}},
+		{Keys: bson.D{{Key: "name", Value: 1}}, Options: options.Index().SetUnique(true)},
+		{Keys: bson.D{{Key: "slug", Value: 1}}, Options: options.Index().SetUnique(true)},
+	}
+
+	_, err := coll.Indexes().CreateMany(ctx, models)
+	if err != nil {
+		return err
+	}
+
+	categories := m.client.Database(m.mongoDatabase).Collection(categoriesCollection)
+
+	model := mongo.IndexModel{Keys: bson.D{{Key: "slug", Value: 1}}, Options: options.Index().SetUnique(true)}
+
+	_, err = categories.Indexes().CreateOne(ctx, model)
+	if err != nil {
+		return err
+	}
+
+	versions := m.client.Database(m.mongoDatabase).Collection(versionsCollection)
+
+	model = mongo.IndexModel{Keys: bson.D{{Key: "version", Value: 1}}, Options: options.Index().SetUnique(true)}
+
+	_, err = versions.Indexes().CreateOne(ctx, model)
+	if err != nil {
+		return err
+	}
+
+	history := m.client.Database(m.mongoDatabase).Collection(historyCollection)
+
+	model = mongo.IndexModel{Keys: bson.D{{Key: "name", Value: 1}, {Key: "version", Value: 1}}, Options: options.Index().SetUnique(true)}
 
-	_, err := coll.Indexes().CreateOne(ctx, model)
+	_, err = history.Indexes().CreateOne(ctx, model)
 	if err != nil {
 		return err
 	}
@@ -0,0 +1,106 @@
+package mongodb
+
+import (
+	"reflect"
+	"slices"
+	"strings"
+	"testing"
+
+	"go.mongodb.org/mongo-driver/bson"
+
+	"github.com/zeze322/wt-guided-weaponry/models"
+)
+
+// TestLayout checks that InsertWeapon and UpdateWeapon write documents with
+// the same keys, so an update never leaves a second shape behind.
+func TestLayout(t *testing.T) {
+	weapon := fullWeapon()
+
+	inserted, err := documentKeys(models.NewWeapon(weapon))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	updated, err := documentKeys(models.UpdateWeaponParams(weapon))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var missing, extra []string
+
+	for _, key := range inserted {
+		if !slices.Contains(updated, key) {
+			missing = append(missing, key)
+		}
+	}
+
+	for _, key := range updated {
+		if !slices.Contains(inserted, key) {
+			extra = append(extra, key)
+		}
+	}
+
+	if len(missing) > 0 || len(extra) > 0 {
+		t.Fatalf("update layout differs from insert: missing %v, extra %v", missing, extra)
+	}
+}
+
+// fullWeapon returns a weapon with every parameter set, so omitempty doesn't
+// hide any key from the comparison.
+func fullWeapon() *models.Params {
+	weapon := &models.Params{Name: "name", Category: "category"}
+
+	fill(reflect.ValueOf(weapon).Elem())
+
+	return weapon
+}
+
+func fill(v reflect.Value) {
+	for i := 0; i < v.NumField(); i++ {
+		f := v.Field(i)
+
+		switch {
+		case f.Type() == reflect.TypeOf((*models.Value)(nil)):
+			f.Set(reflect.ValueOf(models.NewNumber(1, "")))
+		case f.Kind() == reflect.Struct:
+			fill(f)
+		}
+	}
+}
+
+// documentKeys returns the dotted paths of the fields in the bson encoding of
+// v, descending into parameter groups but not into the values themselves.
+func documentKeys(v any) ([]string, error) {
+	data, err := bson.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+
+	elems, err := bson.Raw(data).Elements()
+	if err != nil {
+		return nil, err
+	}
+
+	var keys []string
+
+	for _, e := range elems {
+		group, ok := e.Value().DocumentOK()
+		if !ok {
+			keys = append(keys, e.Key())
+			continue
+		}
+
+		fields, err := group.Elements()
+		if err != nil {
+			return nil, err
+		}
+
+		for _, f := range fields {
+			keys = append(keys, strings.Join([]string{e.Key(), f.Key()}, "."))
+		}
+	}
+
+	slices.Sort(keys)
+
+	return keys, nil
+}
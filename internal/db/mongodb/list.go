@@ -0,0 +1,381 @@
+package mongodb
+
+import (
+	"cmp"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+
+	"github.com/zeze322/wt-guided-weaponry/internal/filter"
+	"github.com/zeze322/wt-guided-weaponry/models"
+)
+
+const (
+	DefaultLimit = 50
+	MaxLimit     = 200
+)
+
+var ErrInvalidCursor = errors.New("invalid cursor")
+
+// ListOptions narrows, orders and pages a weapon listing.
+type ListOptions struct {
+	// Category limits the listing to one category when set.
+	Category string
+
+	// Filter limits the listing to the weapons it matches when set.
+	Filter filter.Node
+
+	// Sort is the key of a numeric parameter, e.g. "flightProp.maximumSpeed".
+	// Weapons are listed by name when it's empty. Weapons without the
+	// parameter or with a text value sort before all others, ties are
+	// broken by name.
+	Sort string
+	Desc bool
+
+	// Fields lists the parameters to return besides the name. Every
+	// parameter is returned when it's empty.
+	Fields []string
+
+	// Limit is the page size, DefaultLimit when zero.
+	Limit int
+
+	// Cursor continues a listing from Page.NextCursor.
+	Cursor string
+}
+
+// Page is one page of a weapon listing. NextCursor is empty on the last page.
+type Page struct {
+	Weapons    []*models.Params
+	NextCursor string
+}
+
+// Validate reports the first invalid option.
+func (o ListOptions) Validate() error {
+	if o.Sort != "" {
+		field, ok := models.FieldByKey(o.Sort)
+		if !ok {
+			return fmt.Errorf("unknown sort field %s", o.Sort)
+		}
+
+		if field.Kind != models.KindNumber {
+			return fmt.Errorf("%s isn't numeric and can't be sorted by", o.Sort)
+		}
+	}
+
+	for _, key := range o.Fields {
+		if key == "name" || key == "category" {
+			continue
+		}
+
+		if _, ok := models.FieldByKey(key); !ok {
+			return fmt.Errorf("unknown field %s", key)
+		}
+	}
+
+	if o.Limit < 0 || o.Limit > MaxLimit {
+		return fmt.Errorf("limit must be between 1 and %d", MaxLimit)
+	}
+
+	if _, err := o.after(); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (o ListOptions) limit() int {
+	if o.Limit == 0 {
+		return DefaultLimit
+	}
+	return o.Limit
+}
+
+// listKey is where a weapon falls in a listing: its sort value, nil when
+// it has none or the listing isn't sorted, then its name.
+type listKey struct {
+	Value *float64 `json:"v,omitempty"`
+	Name  string   `json:"n"`
+}
+
+// key returns the listKey of weapon.
+func (o ListOptions) key(weapon *models.Params) listKey {
+	k := listKey{Name: weapon.Name}
+
+	// Text values have no magnitude and sort like missing ones.
+	if o.Sort != "" {
+		field, _ := models.FieldByKey(o.Sort)
+		if f, ok := field.Value(weapon).Float(); ok {
+			k.Value = &f
+		}
+	}
+
+	return k
+}
+
+func (o ListOptions) compareKeys(a, b listKey) int {
+	var c int
+
+	switch {
+	case a.Value == nil && b.Value == nil:
+	case a.Value == nil:
+		c = -1
+	case b.Value == nil:
+		c = 1
+	default:
+		c = cmp.Compare(*a.Value, *b.Value)
+	}
+
+	if o.Desc {
+		c = -c
+	}
+
+	return cmp.Or(c, strings.Compare(a.Name, b.Name))
+}
+
+// Compare orders weapons the way the listing does, mirroring MongoDB:
+// missing and text values sort lowest and ties are broken by name in ascending
+// order whatever the direction.
+func (o ListOptions) Compare(a, b *models.Params) int {
+	return o.compareKeys(o.key(a), o.key(b))
+}
+
+// listCursor is the decoded form of a cursor. It holds the key of the last
+// weapon of a page, so the next page starts after it even when weapons
+// are added or deleted in between, and remembers the listing it belongs
+// to, so a cursor can't be replayed against a different listing.
+type listCursor struct {
+	listKey
+	Sort     string `json:"s,omitempty"`
+	Desc     bool   `json:"d,omitempty"`
+	Category string `json:"c,omitempty"`
+	Filter   string `json:"f,omitempty"`
+}
+
+// after returns the key the page starts after, nil on the first page.
+func (o ListOptions) after() (*listKey, error) {
+	if o.Cursor == "" {
+		return nil, nil
+	}
+
+	data, err := base64.RawURLEncoding.DecodeString(o.Cursor)
+	if err != nil {
+		return nil, ErrInvalidCursor
+	}
+
+	var c listCursor
+	if err := json.Unmarshal(data, &c); err != nil {
+		return nil, ErrInvalidCursor
+	}
+
+	if c.Name == "" || c.Sort != o.Sort || c.Desc != o.Desc || c.Category != o.Category || c.Filter != o.filterString() {
+		return nil, ErrInvalidCursor
+	}
+
+	if o.Sort == "" && c.Value != nil {
+		return nil, ErrInvalidCursor
+	}
+
+	return &c.listKey, nil
+}
+
+func (o ListOptions) filterString() string {
+	if o.Filter == nil {
+		return ""
+	}
+	return o.Filter.String()
+}
+
+// nextCursor returns the cursor of the page after last.
+func (o ListOptions) nextCursor(last *models.Params) string {
+	data, _ := json.Marshal(listCursor{
+		listKey:  o.key(last),
+		Sort:     o.Sort,
+		Desc:     o.Desc,
+		Category: o.Category,
+		Filter:   o.filterString(),
+	})
+
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+// Paginate cuts the page following the cursor out of weapons, which must
+// already be filtered and ordered by opts.Compare. It lets stores without
+// server-side paging share the cursor format.
+func Paginate(weapons []*models.Params, opts ListOptions) (*Page, error) {
+	after, err := opts.after()
+	if err != nil {
+		return nil, err
+	}
+
+	if after != nil {
+		weapons = weapons[sort.Search(len(weapons), func(i int) bool {
+			return opts.compareKeys(opts.key(weapons[i]), *after) > 0
+		}):]
+	}
+
+	page := &Page{Weapons: []*models.Params{}}
+
+	if len(weapons) > opts.limit() {
+		weapons = weapons[:opts.limit()]
+		page.NextCursor = opts.nextCursor(weapons[len(weapons)-1])
+	}
+
+	for _, weapon := range weapons {
+		if len(opts.Fields) > 0 {
+			weapon = weapon.Project(opts.Fields)
+		}
+		page.Weapons = append(page.Weapons, weapon)
+	}
+
+	return page, nil
+}
+
+// sortValue is the field a sorted listing orders by, see numericMagnitude.
+const sortValue = "_sortValue"
+
+// numericMagnitude computes the magnitude of the parameter at key, null
+// when the weapon lacks it or when it is text, whose stored magnitude is
+// 0. Sorted listings order by it so that text sorts like missing values,
+// as in ListOptions.Compare.
+func numericMagnitude(key string) bson.M {
+	return bson.M{"$cond": bson.A{
+		bson.M{"$in": bson.A{"$" + key + ".kind", numericKinds}},
+		"$" + key + ".magnitude",
+		nil,
+	}}
+}
+
+// afterQuery matches the weapons that follow key in the listing.
+func (o ListOptions) afterQuery(key *listKey) bson.M {
+	if o.Sort == "" {
+		return bson.M{"name": bson.M{"$gt": key.Name}}
+	}
+
+	field := sortValue
+
+	// A null match covers weapons without a numeric value, which range
+	// queries on numbers never match.
+	var value any
+	if key.Value != nil {
+		value = *key.Value
+	}
+
+	or := bson.A{bson.M{field: value, "name": bson.M{"$gt": key.Name}}}
+
+	switch {
+	case key.Value == nil && !o.Desc:
+		or = append(or, bson.M{field: bson.M{"$ne": nil}})
+	case key.Value != nil && !o.Desc:
+		or = append(or, bson.M{field: bson.M{"$gt": value}})
+	case key.Value != nil && o.Desc:
+		or = append(or, bson.M{field: bson.M{"$lt": value}}, bson.M{field: nil})
+	}
+
+	return bson.M{"$or": or}
+}
+
+func (m *MongoClient) ListWeapons(ctx context.Context, opts ListOptions) (*Page, error) {
+	if err := opts.Validate(); err != nil {
+		return nil, err
+	}
+
+	coll := m.client.Database(m.mongoDatabase).Collection(m.mongoCollection)
+
+	query := bson.M{"name": bson.M{"$ne": nil}, "deleted": notDeleted}
+	if opts.Category != "" {
+		query["category"] = opts.Category
+	}
+
+	if opts.Filter != nil {
+		query = bson.M{"$and": bson.A{query, filterDocument(opts.Filter)}}
+	}
+
+	pipeline := mongo.Pipeline{{{Key: "$match", Value: query}}}
+
+	sort := byName
+	if opts.Sort != "" {
+		dir := 1
+		if opts.Desc {
+			dir = -1
+		}
+
+		pipeline = append(pipeline, bson.D{{Key: "$addFields", Value: bson.M{sortValue: numericMagnitude(opts.Sort)}}})
+		sort = bson.D{{Key: sortValue, Value: dir}, {Key: "name", Value: 1}}
+	}
+
+	after, _ := opts.after()
+	if after != nil {
+		pipeline = append(pipeline, bson.D{{Key: "$match", Value: opts.afterQuery(after)}})
+	}
+
+	// One document more than the page tells whether another page follows.
+	pipeline = append(pipeline,
+		bson.D{{Key: "$sort", Value: sort}},
+		bson.D{{Key: "$limit", Value: opts.limit() + 1}},
+	)
+
+	switch {
+	case len(opts.Fields) > 0:
+		// The sort parameter is read for the cursor even when it isn't
+		// returned.
+		projection := bson.M{"name": 1, "slug": 1}
+		if opts.Sort != "" {
+			projection[opts.Sort] = 1
+		}
+		for _, key := range opts.Fields {
+			projection[key] = 1
+		}
+
+		pipeline = append(pipeline, bson.D{{Key: "$project", Value: projection}})
+	case opts.Sort != "":
+		pipeline = append(pipeline, bson.D{{Key: "$project", Value: bson.M{sortValue: 0}}})
+	}
+
+	cursor, err := coll.Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, err
+	}
+
+	defer cursor.Close(ctx)
+
+	weapons := []*models.Params{}
+
+	if err := cursor.All(ctx, &weapons); err != nil {
+		return nil, err
+	}
+
+	if err := cursor.Err(); err != nil {
+		return nil, err
+	}
+
+	page := &Page{Weapons: weapons}
+
+	if len(weapons) > opts.limit() {
+		page.Weapons = weapons[:opts.limit()]
+		page.NextCursor = opts.nextCursor(page.Weapons[opts.limit()-1])
+	}
+
+	if len(opts.Fields) > 0 {
+		for i, weapon := range page.Weapons {
+			page.Weapons[i] = weapon.Project(opts.Fields)
+		}
+	}
+
+	return page, nil
+}
+
+// ParseSort splits a sort parameter such as "-flightProp.maximumSpeed" into
+// the field key and the direction.
+func ParseSort(s string) (key string, desc bool) {
+	if strings.HasPrefix(s, "-") {
+		return s[1:], true
+	}
+	return strings.TrimPrefix(s, "+"), false
+}
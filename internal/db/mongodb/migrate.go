@@ -0,0 +1,284 @@
+package mongodb
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/bsontype"
+	"go.mongodb.org/mongo-driver/mongo"
+
+	"github.com/zeze322/wt-guided-weaponry/models"
+)
+
+const migrationsCollection = "migrations"
+
+type migration struct {
+	id  string
+	run func(context.Context, *MongoClient) error
+}
+
+// migrations run in order, each at most once per database.
+var migrations = []migration{
+	{id: "normalize-weapon-keys", run: normalizeWeaponKeys},
+	{id: "backfill-weapon-slugs", run: backfillSlugs},
+	{id: "type-weapon-values", run: typeWeaponValues},
+}
+
+// Migrate runs the migrations that haven't been applied to the database yet
+// and records them in the migrations collection.
+func (m *MongoClient) Migrate(ctx context.Context) error {
+	coll := m.client.Database(m.mongoDatabase).Collection(migrationsCollection)
+
+	for _, mig := range migrations {
+		err := coll.FindOne(ctx, bson.M{"_id": mig.id}).Err()
+		if err == nil {
+			continue
+		}
+
+		if !errors.Is(err, mongo.ErrNoDocuments) {
+			return err
+		}
+
+		if err := mig.run(ctx, m); err != nil {
+			return fmt.Errorf("migration %s: %w", mig.id, err)
+		}
+
+		// Another instance may have run the same migration concurrently.
+		// Migrations are idempotent, so that's harmless.
+		_, err = coll.InsertOne(ctx, bson.M{"_id": mig.id, "appliedAt": time.Now().UTC()})
+		if err != nil && !mongo.IsDuplicateKeyError(err) {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// normalizeWeaponKeys rewrites weapons stored before Params had bson tags.
+// Inserts used the driver's lowercase keys ("physicalprop", "massatendofboosterburn")
+// while updates $set capitalized ones ("PhysicalProp"), so a single document
+// could carry both shapes.
+func normalizeWeaponKeys(ctx context.Context, m *MongoClient) error {
+	coll := m.client.Database(m.mongoDatabase).Collection(m.mongoCollection)
+
+	cursor, err := coll.Find(ctx, bson.M{})
+	if err != nil {
+		return err
+	}
+
+	defer cursor.Close(ctx)
+
+	for cursor.Next(ctx) {
+		var doc bson.D
+		if err := cursor.Decode(&doc); err != nil {
+			return err
+		}
+
+		normalized := normalizeDocument(doc, weaponKeys)
+
+		before, err := bson.Marshal(doc)
+		if err != nil {
+			return err
+		}
+
+		after, err := bson.Marshal(normalized)
+		if err != nil {
+			return err
+		}
+
+		if bytes.Equal(before, after) {
+			continue
+		}
+
+		id := cursor.Current.Lookup("_id")
+
+		if _, err := coll.ReplaceOne(ctx, bson.D{{Key: "_id", Value: id}}, normalized); err != nil {
+			return err
+		}
+	}
+
+	return cursor.Err()
+}
+
+// typeWeaponValues rewrites parameters stored as plain strings or numbers,
+// as earlier versions did, into typed Value documents, and removes null
+// ones. Filters and sorts query the magnitude, kind and text of values, so
+// they would skip the old forms.
+func typeWeaponValues(ctx context.Context, m *MongoClient) error {
+	coll := m.client.Database(m.mongoDatabase).Collection(m.mongoCollection)
+
+	cursor, err := coll.Find(ctx, bson.M{})
+	if err != nil {
+		return err
+	}
+
+	defer cursor.Close(ctx)
+
+	for cursor.Next(ctx) {
+		update, err := typedValues(cursor.Current)
+		if err != nil {
+			return err
+		}
+
+		if len(update) == 0 {
+			continue
+		}
+
+		id := cursor.Current.Lookup("_id")
+
+		if _, err := coll.UpdateOne(ctx, bson.D{{Key: "_id", Value: id}}, update); err != nil {
+			return err
+		}
+	}
+
+	return cursor.Err()
+}
+
+// typedValues returns the update that types the untyped parameters of doc,
+// nil when they all are.
+func typedValues(doc bson.Raw) (bson.D, error) {
+	set, unset := bson.D{}, bson.D{}
+
+	for _, field := range models.Fields() {
+		raw, err := doc.LookupErr(strings.Split(field.Key, ".")...)
+		if err != nil {
+			continue
+		}
+
+		switch raw.Type {
+		case bsontype.String, bsontype.Double, bsontype.Int32, bsontype.Int64, bsontype.Null, bsontype.Undefined:
+		default:
+			continue
+		}
+
+		v := new(models.Value)
+		if err := v.UnmarshalBSONValue(raw.Type, raw.Value); err != nil {
+			return nil, fmt.Errorf("%s: %w", field.Key, err)
+		}
+
+		if v.Kind == "" && v.Text == "" {
+			unset = append(unset, bson.E{Key: field.Key, Value: ""})
+			continue
+		}
+
+		set = append(set, bson.E{Key: field.Key, Value: v})
+	}
+
+	var update bson.D
+
+	if len(set) > 0 {
+		update = append(update, bson.E{Key: "$set", Value: set})
+	}
+
+	if len(unset) > 0 {
+		update = append(update, bson.E{Key: "$unset", Value: unset})
+	}
+
+	return update, nil
+}
+
+// keySpec describes the canonical key of a document field and, for
+// subdocuments, the keys of its own fields.
+type keySpec struct {
+	key string
+
+	// legacy is the Go field name that old updates used as a key.
+	legacy string
+
+	fields map[string]*keySpec
+}
+
+var weaponKeys = newKeySpec(reflect.TypeOf(models.Params{}))
+
+var (
+	valueType = reflect.TypeOf(models.Value{})
+	timeType  = reflect.TypeOf(time.Time{})
+)
+
+// newKeySpec indexes the fields of t by their lowercase key and lowercase
+// Go name, which covers every spelling older code has written.
+func newKeySpec(t reflect.Type) map[string]*keySpec {
+	fields := map[string]*keySpec{}
+
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+
+		key, _, _ := strings.Cut(f.Tag.Get("bson"), ",")
+		if key == "" || key == "-" {
+			continue
+		}
+
+		spec := &keySpec{key: key, legacy: f.Name}
+
+		ft := f.Type
+		if ft.Kind() == reflect.Pointer {
+			ft = ft.Elem()
+		}
+
+		if ft.Kind() == reflect.Struct && ft != valueType && ft != timeType {
+			spec.fields = newKeySpec(ft)
+		}
+
+		fields[strings.ToLower(key)] = spec
+		fields[strings.ToLower(f.Name)] = spec
+	}
+
+	return fields
+}
+
+// normalizeDocument renames the keys of doc to their canonical spelling and
+// drops null values. When several spellings of a key are present, the one old
+// updates wrote wins because it's the most recent. Unknown keys are kept.
+func normalizeDocument(doc bson.D, fields map[string]*keySpec) bson.D {
+	type candidate struct {
+		value  any
+		legacy bool
+	}
+
+	var (
+		order  []string
+		chosen = map[string]candidate{}
+		res    bson.D
+	)
+
+	for _, e := range doc {
+		spec, ok := fields[strings.ToLower(e.Key)]
+		if !ok {
+			res = append(res, e)
+			continue
+		}
+
+		if e.Value == nil {
+			continue
+		}
+
+		c := candidate{value: e.Value, legacy: e.Key == spec.legacy && e.Key != spec.key}
+
+		prev, seen := chosen[spec.key]
+		if !seen {
+			order = append(order, spec.key)
+		}
+
+		if !seen || (c.legacy && !prev.legacy) {
+			chosen[spec.key] = c
+		}
+	}
+
+	for _, key := range order {
+		value := chosen[key].value
+
+		if sub, ok := value.(bson.D); ok && fields[strings.ToLower(key)].fields != nil {
+			value = normalizeDocument(sub, fields[strings.ToLower(key)].fields)
+		}
+
+		res = append(res, bson.E{Key: key, Value: value})
+	}
+
+	return res
+}
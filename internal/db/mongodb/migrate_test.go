@@ -0,0 +1,77 @@
+package mongodb
+
+import (
+	"testing"
+
+	"go.mongodb.org/mongo-driver/bson"
+
+	"github.com/zeze322/wt-guided-weaponry/models"
+)
+
+func TestTypedValues(t *testing.T) {
+	doc, err := bson.Marshal(bson.D{
+		{Key: "name", Value: "AIM-9L"},
+		{Key: "physicalProp", Value: bson.D{
+			{Key: "mass", Value: "85.5"},
+			{Key: "calibre", Value: 127},
+			{Key: "length", Value: nil},
+			{Key: "massAtEndOfBoosterBurn", Value: models.NewNumber(58, "kg")},
+		}},
+		{Key: "guidanceProp", Value: bson.D{
+			{Key: "guidanceType", Value: "IR"},
+			{Key: "IRCCM", Value: "No"},
+		}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	update, err := typedValues(doc)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := map[string]models.Value{
+		"physicalProp.mass":         {Text: "85.5", Kind: models.KindNumber, Magnitude: 85.5},
+		"physicalProp.calibre":      {Text: "127", Kind: models.KindNumber, Magnitude: 127},
+		"guidanceProp.guidanceType": {Text: "IR", Kind: models.KindText},
+		"guidanceProp.IRCCM":        {Text: "No", Kind: models.KindBool},
+	}
+
+	set := update.Map()["$set"].(bson.D)
+	if len(set) != len(want) {
+		t.Fatalf("$set holds %v, want the keys of %v", set, want)
+	}
+
+	for _, e := range set {
+		got := e.Value.(*models.Value)
+		w, ok := want[e.Key]
+		if !ok || got.Text != w.Text || got.Kind != w.Kind || got.Magnitude != w.Magnitude {
+			t.Errorf("$set %s = %+v, want %+v", e.Key, got, w)
+		}
+	}
+
+	unset := update.Map()["$unset"].(bson.D)
+	if len(unset) != 1 || unset[0].Key != "physicalProp.length" {
+		t.Errorf("$unset holds %v, want physicalProp.length", unset)
+	}
+}
+
+func TestTypedValuesTyped(t *testing.T) {
+	doc, err := bson.Marshal(models.NewWeapon(&models.Params{
+		Name:         "AIM-9L",
+		PhysicalProp: models.PhysicalProp{Mass: models.NewNumber(85.5, "kg")},
+	}))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	update, err := typedValues(doc)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if update != nil {
+		t.Fatalf("typedValues of a typed weapon returned %v, want nil", update)
+	}
+}
@@ -2,7 +2,10 @@ package mongodb
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"regexp"
+	"time"
 
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/bson/primitive"
@@ -14,13 +17,90 @@ import (
 
 type Store interface {
 	Categories(context.Context) ([]models.Category, error)
+	Category(context.Context, string) (*models.Category, error)
+	InsertCategory(context.Context, *models.Category) error
+	UpdateCategory(context.Context, string, *models.Category) error
+	DeleteCategory(context.Context, string) error
 	Weapons(context.Context) ([]*models.Params, error)
+	Weapon(context.Context, string) (*models.Params, error)
+	WeaponBySlug(context.Context, string) (*models.Params, error)
+	ListWeapons(context.Context, ListOptions) (*Page, error)
 	WeaponsByCategory(context.Context, string) ([]*models.Params, error)
 	InsertWeapon(context.Context, *models.Params) error
 	UpdateWeapon(context.Context, string, *models.Params) error
 	SearchWeapon(context.Context, string) ([]models.Name, error)
+	DeleteWeapon(context.Context, string, string) error
+	DeletedWeapons(context.Context) ([]*models.Params, error)
+	RestoreWeapon(context.Context, string) error
+	GameVersions(context.Context) ([]models.GameVersion, error)
+	InsertGameVersion(context.Context, *models.GameVersion) error
+	WeaponHistory(context.Context, string) ([]models.Snapshot, error)
+	WeaponAt(context.Context, string, string) (*models.Params, error)
+	WeaponsAt(context.Context, string) ([]*models.Params, error)
+	WeaponsByCategoryAt(context.Context, string, string) ([]*models.Params, error)
 }
 
+const categoriesCollection = "categories"
+
+// ErrNothingFound is returned by the listing and search methods when no
+// weapon matches, and wrapped by the methods that read or change a single
+// weapon or category when it doesn't exist. RestoreWeapon wraps it for
+// weapons that aren't deleted.
+var ErrNothingFound = errors.New("nothing found")
+
+// ErrConflict is returned when a weapon is inserted or renamed under the
+// name of another weapon, deleted or not, when a category is inserted or
+// renamed under the slug of another category and when a game version is
+// inserted twice.
+var ErrConflict = errors.New("already exists")
+
+// slugAttempts is how many slugs InsertWeapon tries before giving up when
+// concurrent inserts keep taking them.
+const slugAttempts = 10
+
+// duplicateIndexName finds the index in the message of a duplicate-key
+// error from servers that don't report its key pattern.
+var duplicateIndexName = regexp.MustCompile(`index: (\w+)_-?1 dup key`)
+
+// duplicateKey returns the field of the unique index a duplicate-key error
+// violates, e.g. "name" or "slug", or "" when err isn't one.
+func duplicateKey(err error) string {
+	var we mongo.WriteException
+	if !errors.As(err, &we) {
+		return ""
+	}
+
+	for _, e := range we.WriteErrors {
+		if e.Code != 11000 {
+			continue
+		}
+
+		if pattern, err := e.Raw.LookupErr("keyPattern"); err == nil {
+			if elems, err := pattern.Document().Elements(); err == nil && len(elems) > 0 {
+				return elems[0].Key()
+			}
+		}
+
+		if m := duplicateIndexName.FindStringSubmatch(e.Message); m != nil {
+			return m[1]
+		}
+	}
+
+	return ""
+}
+
+var (
+	// byInsertion sorts documents in the order they were inserted.
+	byInsertion = bson.D{{Key: "_id", Value: 1}}
+
+	byName = bson.D{{Key: "name", Value: 1}}
+
+	bySortOrder = bson.D{{Key: "sortorder", Value: 1}, {Key: "slug", Value: 1}}
+
+	// notDeleted matches weapons that haven't been soft-deleted.
+	notDeleted = bson.M{"$exists": false}
+)
+
 type MongoClient struct {
 	client          *mongo.Client
 	mongoDatabase   string
@@ -49,11 +129,9 @@ func (m *MongoClient) Close(ctx context.Context) error {
 }
 
 func (m *MongoClient) Categories(ctx context.Context) ([]models.Category, error) {
-	coll := m.client.Database(m.mongoDatabase).Collection(m.mongoCollection)
+	coll := m.client.Database(m.mongoDatabase).Collection(categoriesCollection)
 
-	filter := bson.M{"categories": "exists"}
-
-	cursor, err := coll.Find(ctx, filter)
+	cursor, err := coll.Find(ctx, bson.M{}, options.Find().SetSort(bySortOrder))
 	if err != nil {
 		return nil, err
 	}
@@ -73,12 +151,78 @@ func (m *MongoClient) Categories(ctx context.Context) ([]models.Category, error)
 	return categories, nil
 }
 
+func (m *MongoClient) Category(ctx context.Context, slug string) (*models.Category, error) {
+	coll := m.client.Database(m.mongoDatabase).Collection(categoriesCollection)
+
+	category := new(models.Category)
+
+	err := coll.FindOne(ctx, bson.M{"slug": slug}).Decode(category)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return nil, fmt.Errorf("%w: %s", ErrNothingFound, slug)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return category, nil
+}
+
+func (m *MongoClient) InsertCategory(ctx context.Context, category *models.Category) error {
+	coll := m.client.Database(m.mongoDatabase).Collection(categoriesCollection)
+
+	_, err := coll.InsertOne(ctx, category)
+	if mongo.IsDuplicateKeyError(err) {
+		return fmt.Errorf("%s %w", category.Slug, ErrConflict)
+	}
+
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (m *MongoClient) UpdateCategory(ctx context.Context, slug string, category *models.Category) error {
+	coll := m.client.Database(m.mongoDatabase).Collection(categoriesCollection)
+
+	res, err := coll.ReplaceOne(ctx, bson.M{"slug": slug}, category)
+	if mongo.IsDuplicateKeyError(err) {
+		return fmt.Errorf("%s %w", category.Slug, ErrConflict)
+	}
+
+	if err != nil {
+		return err
+	}
+
+	if res.MatchedCount == 0 {
+		return fmt.Errorf("%w: %s", ErrNothingFound, slug)
+	}
+
+	return nil
+}
+
+func (m *MongoClient) DeleteCategory(ctx context.Context, slug string) error {
+	coll := m.client.Database(m.mongoDatabase).Collection(categoriesCollection)
+
+	res, err := coll.DeleteOne(ctx, bson.M{"slug": slug})
+	if err != nil {
+		return err
+	}
+
+	if res.DeletedCount == 0 {
+		return fmt.Errorf("%w: %s", ErrNothingFound, slug)
+	}
+
+	return nil
+}
+
 func (m *MongoClient) Weapons(ctx context.Context) ([]*models.Params, error) {
 	coll := m.client.Database(m.mongoDatabase).Collection(m.mongoCollection)
 
-	filter := bson.M{"name": bson.M{"$ne": nil}}
+	filter := bson.M{"name": bson.M{"$ne": nil}, "deleted": notDeleted}
 
-	cursor, err := coll.Find(ctx, filter)
+	cursor, err := coll.Find(ctx, filter, options.Find().SetSort(byInsertion))
 	if err != nil {
 		return nil, err
 	}
@@ -98,12 +242,29 @@ func (m *MongoClient) Weapons(ctx context.Context) ([]*models.Params, error) {
 	return weapons, nil
 }
 
+func (m *MongoClient) Weapon(ctx context.Context, name string) (*models.Params, error) {
+	coll := m.client.Database(m.mongoDatabase).Collection(m.mongoCollection)
+
+	weapon := new(models.Params)
+
+	err := coll.FindOne(ctx, bson.M{"name": name, "deleted": notDeleted}).Decode(weapon)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return nil, fmt.Errorf("%w: %s", ErrNothingFound, name)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return weapon, nil
+}
+
 func (m *MongoClient) WeaponsByCategory(ctx context.Context, category string) ([]*models.Params, error) {
 	coll := m.client.Database(m.mongoDatabase).Collection(m.mongoCollection)
 
-	filter := bson.M{"category": category}
+	filter := bson.M{"category": category, "deleted": notDeleted}
 
-	cursor, err := coll.Find(ctx, filter)
+	cursor, err := coll.Find(ctx, filter, options.Find().SetSort(byInsertion))
 	if err != nil {
 		return nil, err
 	}
@@ -121,59 +282,96 @@ func (m *MongoClient) WeaponsByCategory(ctx context.Context, category string) ([
 	}
 
 	if len(weapons) == 0 {
-		return nil, fmt.Errorf("nothing found")
+		return nil, ErrNothingFound
 	}
 
 	return weapons, nil
 }
 
+// InsertWeapon stores params under a new slug, which it sets on params,
+// replacing any slug the caller set.
 func (m *MongoClient) InsertWeapon(ctx context.Context, params *models.Params) error {
 	coll := m.client.Database(m.mongoDatabase).Collection(m.mongoCollection)
 
-	weapon := models.NewWeapon(params)
-
-	filter := bson.M{"name": params.Name}
-	count, err := coll.CountDocuments(ctx, filter)
-	if err != nil {
+	insert := func(ctx context.Context, weapon *models.Params) error {
+		_, err := coll.InsertOne(ctx, weapon)
 		return err
 	}
 
-	if count != 0 {
-		return fmt.Errorf("%s already exists", params.Name)
+	if err := insertWithSlug(ctx, params, m.newSlug, insert); err != nil {
+		return err
 	}
 
-	_, err = coll.InsertOne(ctx, weapon)
-	if err != nil {
+	return m.recordSnapshot(ctx, params)
+}
+
+// insertWithSlug sets a slug chosen by newSlug on params and inserts the
+// weapon. The unique indexes reject concurrent inserts that a
+// count-then-insert check would let through. Another weapon can take the
+// slug between choosing and inserting it, so a slug collision picks the
+// next free slug, while a name collision is a conflict.
+func insertWithSlug(ctx context.Context, params *models.Params, newSlug func(context.Context, string) (string, error), insert func(context.Context, *models.Params) error) error {
+	for attempt := 1; ; attempt++ {
+		slug, err := newSlug(ctx, params.Name)
+		if err != nil {
+			return err
+		}
+
+		params.Slug = slug
+
+		err = insert(ctx, models.NewWeapon(params))
+
+		key := duplicateKey(err)
+		if key == "slug" && attempt < slugAttempts {
+			continue
+		}
+
+		if key == "name" {
+			return fmt.Errorf("%s %w", params.Name, ErrConflict)
+		}
+
 		return err
 	}
-
-	return nil
 }
 
 func (m *MongoClient) UpdateWeapon(ctx context.Context, name string, params *models.Params) error {
 	coll := m.client.Database(m.mongoDatabase).Collection(m.mongoCollection)
 
 	update := bson.M{"$set": models.UpdateWeaponParams(params)}
-	filter := bson.M{"name": name}
+	filter := bson.M{"name": name, "deleted": notDeleted}
 
+	// The unique index on name rejects renames onto another weapon.
 	res, err := coll.UpdateOne(ctx, filter, update)
+	if duplicateKey(err) == "name" {
+		return fmt.Errorf("%s %w", params.Name, ErrConflict)
+	}
+
 	if err != nil {
 		return err
 	}
 
 	if res.MatchedCount == 0 {
-		return fmt.Errorf("%s doesn't exist", name)
+		return fmt.Errorf("%w: %s", ErrNothingFound, name)
 	}
 
-	return nil
+	if params.Name != name {
+		if err := m.renameHistory(ctx, name, params.Name); err != nil {
+			return err
+		}
+	}
+
+	return m.recordSnapshot(ctx, params)
 }
 
 func (m *MongoClient) SearchWeapon(ctx context.Context, keyWord string) ([]models.Name, error) {
 	coll := m.client.Database(m.mongoDatabase).Collection(m.mongoCollection)
 
-	filter := bson.D{{Key: "name", Value: primitive.Regex{Pattern: keyWord, Options: "i"}}}
+	filter := bson.D{
+		{Key: "name", Value: primitive.Regex{Pattern: regexp.QuoteMeta(keyWord), Options: "i"}},
+		{Key: "deleted", Value: notDeleted},
+	}
 
-	cursor, err := coll.Find(ctx, filter)
+	cursor, err := coll.Find(ctx, filter, options.Find().SetSort(byInsertion))
 	if err != nil {
 		return nil, err
 	}
@@ -191,8 +389,74 @@ func (m *MongoClient) SearchWeapon(ctx context.Context, keyWord string) ([]model
 	}
 
 	if len(weapons) == 0 {
-		return nil, fmt.Errorf("nothing found")
+		return nil, ErrNothingFound
 	}
 
 	return weapons, nil
 }
+
+func (m *MongoClient) DeleteWeapon(ctx context.Context, name, reason string) error {
+	coll := m.client.Database(m.mongoDatabase).Collection(m.mongoCollection)
+
+	deletion := models.Deletion{
+		DeletedAt: time.Now().UTC(),
+		Reason:    reason,
+	}
+
+	update := bson.M{"$set": bson.M{"deleted": deletion}}
+	filter := bson.M{"name": name, "deleted": notDeleted}
+
+	res, err := coll.UpdateOne(ctx, filter, update)
+	if err != nil {
+		return err
+	}
+
+	if res.MatchedCount == 0 {
+		return fmt.Errorf("%w: %s", ErrNothingFound, name)
+	}
+
+	return m.recordStored(ctx, name)
+}
+
+func (m *MongoClient) DeletedWeapons(ctx context.Context) ([]*models.Params, error) {
+	coll := m.client.Database(m.mongoDatabase).Collection(m.mongoCollection)
+
+	filter := bson.M{"deleted": bson.M{"$exists": true}}
+
+	cursor, err := coll.Find(ctx, filter, options.Find().SetSort(byInsertion))
+	if err != nil {
+		return nil, err
+	}
+
+	defer cursor.Close(ctx)
+
+	var weapons []*models.Params
+
+	if err := cursor.All(ctx, &weapons); err != nil {
+		return nil, err
+	}
+
+	if err := cursor.Err(); err != nil {
+		return nil, err
+	}
+
+	return weapons, nil
+}
+
+func (m *MongoClient) RestoreWeapon(ctx context.Context, name string) error {
+	coll := m.client.Database(m.mongoDatabase).Collection(m.mongoCollection)
+
+	update := bson.M{"$unset": bson.M{"deleted": ""}}
+	filter := bson.M{"name": name, "deleted": bson.M{"$exists": true}}
+
+	res, err := coll.UpdateOne(ctx, filter, update)
+	if err != nil {
+		return err
+	}
+
+	if res.MatchedCount == 0 {
+		return fmt.Errorf("%w: %s isn't deleted", ErrNothingFound, name)
+	}
+
+	return m.recordStored(ctx, name)
+}
@@ -0,0 +1,58 @@
+package mongodb_test
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/zeze322/wt-guided-weaponry/internal/db/mongodb"
+	"github.com/zeze322/wt-guided-weaponry/internal/db/storetest"
+)
+
+// TestStore runs the conformance suite against the MongoDB server at
+// MONGO_TEST_URI, in a fresh database per subtest. It is skipped when the
+// variable is unset.
+func TestStore(t *testing.T) {
+	uri := os.Getenv("MONGO_TEST_URI")
+	if uri == "" {
+		t.Skip("MONGO_TEST_URI is not set")
+	}
+
+	n := 0
+
+	storetest.Run(t, func(t *testing.T) mongodb.Store {
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+
+		n++
+		database := fmt.Sprintf("wt_guided_weaponry_test_%d_%d", time.Now().UnixNano(), n)
+
+		store, err := mongodb.New(ctx, uri, database, "weapons")
+		if err != nil {
+			t.Fatalf("New: %v", err)
+		}
+
+		t.Cleanup(func() {
+			ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+			defer cancel()
+
+			if err := store.DropDatabase(ctx); err != nil {
+				t.Errorf("DropDatabase: %v", err)
+			}
+
+			store.Close(ctx)
+		})
+
+		if err := store.Migrate(ctx); err != nil {
+			t.Fatalf("Migrate: %v", err)
+		}
+
+		if err := store.CreateIndex(ctx); err != nil {
+			t.Fatalf("CreateIndex: %v", err)
+		}
+
+		return store
+	})
+}
@@ -0,0 +1,29 @@
+package mongodb
+
+import (
+	"context"
+
+	"github.com/zeze322/wt-guided-weaponry/models"
+)
+
+// SeedCategories inserts the categories into a store that has none yet.
+// It runs on every start, so a store with categories is left as it is:
+// default categories that were deleted stay deleted.
+func SeedCategories(ctx context.Context, s Store, categories []models.Category) error {
+	existing, err := s.Categories(ctx)
+	if err != nil {
+		return err
+	}
+
+	if len(existing) > 0 {
+		return nil
+	}
+
+	for _, category := range categories {
+		if err := s.InsertCategory(ctx, &category); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,95 @@
+package mongodb
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"github.com/zeze322/wt-guided-weaponry/models"
+)
+
+func (m *MongoClient) WeaponBySlug(ctx context.Context, slug string) (*models.Params, error) {
+	coll := m.client.Database(m.mongoDatabase).Collection(m.mongoCollection)
+
+	weapon := new(models.Params)
+
+	err := coll.FindOne(ctx, bson.M{"slug": slug, "deleted": notDeleted}).Decode(weapon)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return nil, fmt.Errorf("%w: no weapon has the slug %s", ErrNothingFound, slug)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return weapon, nil
+}
+
+// newSlug returns the slug of name, numbered when another weapon, deleted
+// or not, already has it.
+func (m *MongoClient) newSlug(ctx context.Context, name string) (string, error) {
+	coll := m.client.Database(m.mongoDatabase).Collection(m.mongoCollection)
+
+	base := models.Slug(name)
+
+	for n := 1; ; n++ {
+		slug := NumberSlug(base, n)
+
+		count, err := coll.CountDocuments(ctx, bson.M{"slug": slug})
+		if err != nil {
+			return "", err
+		}
+
+		if count == 0 {
+			return slug, nil
+		}
+	}
+}
+
+// backfillSlugs gives a slug to the weapons stored before weapons had one,
+// in insertion order. It runs before the unique index on slugs is created.
+func backfillSlugs(ctx context.Context, m *MongoClient) error {
+	coll := m.client.Database(m.mongoDatabase).Collection(m.mongoCollection)
+
+	filter := bson.M{"slug": bson.M{"$exists": false}}
+
+	cursor, err := coll.Find(ctx, filter, options.Find().SetSort(byInsertion).SetProjection(bson.M{"name": 1}))
+	if err != nil {
+		return err
+	}
+
+	defer cursor.Close(ctx)
+
+	for cursor.Next(ctx) {
+		var weapon models.Name
+		if err := cursor.Decode(&weapon); err != nil {
+			return err
+		}
+
+		slug, err := m.newSlug(ctx, weapon.Name)
+		if err != nil {
+			return err
+		}
+
+		id := cursor.Current.Lookup("_id")
+
+		if _, err := coll.UpdateOne(ctx, bson.D{{Key: "_id", Value: id}}, bson.M{"$set": bson.M{"slug": slug}}); err != nil {
+			return err
+		}
+	}
+
+	return cursor.Err()
+}
+
+// NumberSlug returns the nth slug for base: base itself, then base-2,
+// base-3 and so on.
+func NumberSlug(base string, n int) string {
+	if n == 1 {
+		return base
+	}
+	return fmt.Sprintf("%s-%d", base, n)
+}
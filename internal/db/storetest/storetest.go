@@ -0,0 +1,1490 @@
+// Package storetest implements a conformance suite for mongodb.Store
+// implementations. Every backend is expected to pass it:
+//
+//	func TestStore(t *testing.T) {
+//		storetest.Run(t, func(t *testing.T) mongodb.Store {
+//			return memory.New()
+//		})
+//	}
+package storetest
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/zeze322/wt-guided-weaponry/internal/db/mongodb"
+	"github.com/zeze322/wt-guided-weaponry/internal/filter"
+	"github.com/zeze322/wt-guided-weaponry/models"
+)
+
+// NewStoreFunc returns an empty Store. It is called once per subtest.
+type NewStoreFunc func(t *testing.T) mongodb.Store
+
+type testCase struct {
+	name string
+	fn   func(t *testing.T, ctx context.Context, s mongodb.Store)
+}
+
+var testCases = []testCase{
+	{"CategoriesEmpty", testCategoriesEmpty},
+	{"CategoriesSortOrder", testCategoriesSortOrder},
+	{"Category", testCategory},
+	{"CategoryMissing", testCategoryMissing},
+	{"InsertCategoryDuplicate", testInsertCategoryDuplicate},
+	{"UpdateCategory", testUpdateCategory},
+	{"UpdateCategoryMissing", testUpdateCategoryMissing},
+	{"UpdateCategoryRenameConflict", testUpdateCategoryRenameConflict},
+	{"DeleteCategory", testDeleteCategory},
+	{"SeedCategories", testSeedCategories},
+	{"WeaponsEmpty", testWeaponsEmpty},
+	{"WeaponsInsertionOrder", testWeaponsInsertionOrder},
+	{"WeaponsReturnsCopies", testWeaponsReturnsCopies},
+	{"Weapon", testWeapon},
+	{"WeaponMissing", testWeaponMissing},
+	{"ListWeaponsPages", testListWeaponsPages},
+	{"ListWeaponsPagesChanged", testListWeaponsPagesChanged},
+	{"ListWeaponsSort", testListWeaponsSort},
+	{"ListWeaponsSortPages", testListWeaponsSortPages},
+	{"ListWeaponsSortPagesMissing", testListWeaponsSortPagesMissing},
+	{"ListWeaponsCategory", testListWeaponsCategory},
+	{"ListWeaponsFields", testListWeaponsFields},
+	{"ListWeaponsFilter", testListWeaponsFilter},
+	{"ListWeaponsFilterPages", testListWeaponsFilterPages},
+	{"ListWeaponsSkipsDeleted", testListWeaponsSkipsDeleted},
+	{"ListWeaponsInvalidOptions", testListWeaponsInvalidOptions},
+	{"WeaponsByCategory", testWeaponsByCategory},
+	{"WeaponsByCategoryNothingFound", testWeaponsByCategoryNothingFound},
+	{"InsertWeapon", testInsertWeapon},
+	{"InsertWeaponDuplicate", testInsertWeaponDuplicate},
+	{"InsertWeaponDuplicateRace", testInsertWeaponDuplicateRace},
+	{"UpdateWeapon", testUpdateWeapon},
+	{"UpdateWeaponMissingName", testUpdateWeaponMissingName},
+	{"UpdateWeaponRename", testUpdateWeaponRename},
+	{"UpdateWeaponRenameConflict", testUpdateWeaponRenameConflict},
+	{"WeaponBySlug", testWeaponBySlug},
+	{"WeaponBySlugRace", testWeaponBySlugRace},
+	{"WeaponBySlugRename", testWeaponBySlugRename},
+	{"WeaponBySlugDeleted", testWeaponBySlugDeleted},
+	{"SearchWeapon", testSearchWeapon},
+	{"SearchWeaponNothingFound", testSearchWeaponNothingFound},
+	{"SearchWeaponMetacharacters", testSearchWeaponMetacharacters},
+	{"DeleteWeapon", testDeleteWeapon},
+	{"DeleteWeaponMissing", testDeleteWeaponMissing},
+	{"DeleteWeaponTwice", testDeleteWeaponTwice},
+	{"DeletedWeapons", testDeletedWeapons},
+	{"RestoreWeapon", testRestoreWeapon},
+	{"RestoreWeaponNotDeleted", testRestoreWeaponNotDeleted},
+	{"UpdateDeletedWeapon", testUpdateDeletedWeapon},
+	{"InsertDeletedWeaponName", testInsertDeletedWeaponName},
+	{"GameVersionsSortOrder", testGameVersionsSortOrder},
+	{"InsertGameVersionDuplicate", testInsertGameVersionDuplicate},
+	{"WeaponAtBaseline", testWeaponAtBaseline},
+	{"WeaponAtUpdates", testWeaponAtUpdates},
+	{"WeaponAtBeforeInsert", testWeaponAtBeforeInsert},
+	{"WeaponAtUnknownVersion", testWeaponAtUnknownVersion},
+	{"WeaponHistory", testWeaponHistory},
+	{"WeaponAtDeleted", testWeaponAtDeleted},
+	{"WeaponHistoryRename", testWeaponHistoryRename},
+	{"WeaponsByCategoryAt", testWeaponsByCategoryAt},
+}
+
+// Run runs the conformance suite against the stores returned by newStore.
+func Run(t *testing.T, newStore NewStoreFunc) {
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.fn(t, context.Background(), newStore(t))
+		})
+	}
+}
+
+func weapon(name, category string) *models.Params {
+	return &models.Params{
+		Name:     name,
+		Category: category,
+		PhysicalProp: models.PhysicalProp{
+			Mass:    models.ParseValue("85.5"),
+			Calibre: models.ParseValue("127"),
+			Length:  models.ParseValue("2.87"),
+		},
+	}
+}
+
+func mustInsert(t *testing.T, ctx context.Context, s mongodb.Store, weapons ...*models.Params) {
+	t.Helper()
+
+	for _, w := range weapons {
+		if err := s.InsertWeapon(ctx, w); err != nil {
+			t.Fatalf("InsertWeapon(%q): %v", w.Name, err)
+		}
+	}
+}
+
+func names(weapons []*models.Params) []string {
+	var res []string
+	for _, w := range weapons {
+		res = append(res, w.Name)
+	}
+	return res
+}
+
+func checkNames(t *testing.T, method string, got, want []string) {
+	t.Helper()
+
+	if fmt.Sprint(got) != fmt.Sprint(want) {
+		t.Errorf("%s returned %q, want %q", method, got, want)
+	}
+}
+
+func category(slug string, sortOrder int) *models.Category {
+	return &models.Category{
+		Slug:        slug,
+		Name:        slug,
+		Class:       models.ClassAAM,
+		Guidance:    "IR",
+		SortOrder:   sortOrder,
+		Description: "description of " + slug,
+	}
+}
+
+func mustInsertCategory(t *testing.T, ctx context.Context, s mongodb.Store, categories ...*models.Category) {
+	t.Helper()
+
+	for _, c := range categories {
+		if err := s.InsertCategory(ctx, c); err != nil {
+			t.Fatalf("InsertCategory(%q): %v", c.Slug, err)
+		}
+	}
+}
+
+func slugs(t *testing.T, ctx context.Context, s mongodb.Store) []string {
+	t.Helper()
+
+	categories, err := s.Categories(ctx)
+	if err != nil {
+		t.Fatalf("Categories: %v", err)
+	}
+
+	var res []string
+	for _, c := range categories {
+		res = append(res, c.Slug)
+	}
+	return res
+}
+
+func testCategoriesEmpty(t *testing.T, ctx context.Context, s mongodb.Store) {
+	if got := slugs(t, ctx, s); len(got) != 0 {
+		t.Fatalf("Categories on an empty store returned %q", got)
+	}
+}
+
+func testCategoriesSortOrder(t *testing.T, ctx context.Context, s mongodb.Store) {
+	mustInsertCategory(t, ctx, s,
+		category("aam-arh", 50),
+		category("ir-rear-aspect", 10),
+		category("ir-heli", 30),
+		category("ir-all-aspect", 30),
+	)
+
+	checkNames(t, "Categories", slugs(t, ctx, s), []string{"ir-rear-aspect", "ir-all-aspect", "ir-heli", "aam-arh"})
+}
+
+func testCategory(t *testing.T, ctx context.Context, s mongodb.Store) {
+	want := category("ir-all-aspect", 20)
+	mustInsertCategory(t, ctx, s, category("ir-rear-aspect", 10), want)
+
+	got, err := s.Category(ctx, "ir-all-aspect")
+	if err != nil {
+		t.Fatalf("Category: %v", err)
+	}
+
+	if *got != *want {
+		t.Fatalf("Category returned %+v, want %+v", *got, *want)
+	}
+}
+
+func testCategoryMissing(t *testing.T, ctx context.Context, s mongodb.Store) {
+	mustInsertCategory(t, ctx, s, category("ir-rear-aspect", 10))
+
+	if _, err := s.Category(ctx, "ir-all-aspect"); !errors.Is(err, mongodb.ErrNothingFound) {
+		t.Fatalf("Category for a missing slug returned %v, want ErrNothingFound", err)
+	}
+}
+
+func testInsertCategoryDuplicate(t *testing.T, ctx context.Context, s mongodb.Store) {
+	mustInsertCategory(t, ctx, s, category("ir-rear-aspect", 10))
+
+	if err := s.InsertCategory(ctx, category("ir-rear-aspect", 20)); !errors.Is(err, mongodb.ErrConflict) {
+		t.Fatalf("InsertCategory of a duplicate slug returned %v, want ErrConflict", err)
+	}
+
+	got, err := s.Category(ctx, "ir-rear-aspect")
+	if err != nil {
+		t.Fatalf("Category: %v", err)
+	}
+
+	if got.SortOrder != 10 {
+		t.Fatalf("rejected duplicate overwrote the sort order with %d", got.SortOrder)
+	}
+}
+
+func testUpdateCategory(t *testing.T, ctx context.Context, s mongodb.Store) {
+	mustInsertCategory(t, ctx, s, category("ir-rear-aspect", 10), category("ir-all-aspect", 20))
+
+	update := category("ir-rear", 30)
+	update.Name = "AAM (IR rear-aspect)"
+
+	if err := s.UpdateCategory(ctx, "ir-rear-aspect", update); err != nil {
+		t.Fatalf("UpdateCategory: %v", err)
+	}
+
+	checkNames(t, "Categories", slugs(t, ctx, s), []string{"ir-all-aspect", "ir-rear"})
+
+	got, err := s.Category(ctx, "ir-rear")
+	if err != nil {
+		t.Fatalf("Category: %v", err)
+	}
+
+	if *got != *update {
+		t.Fatalf("updated category read back as %+v, want %+v", *got, *update)
+	}
+
+	if _, err := s.Category(ctx, "ir-rear-aspect"); err == nil {
+		t.Fatal("Category found a category under its old slug after a rename")
+	}
+}
+
+func testUpdateCategoryMissing(t *testing.T, ctx context.Context, s mongodb.Store) {
+	if err := s.UpdateCategory(ctx, "ir-rear-aspect", category("ir-rear-aspect", 10)); !errors.Is(err, mongodb.ErrNothingFound) {
+		t.Fatalf("UpdateCategory for a missing slug returned %v, want ErrNothingFound", err)
+	}
+
+	if got := slugs(t, ctx, s); len(got) != 0 {
+		t.Fatalf("UpdateCategory for a missing slug created %q", got)
+	}
+}
+
+func testUpdateCategoryRenameConflict(t *testing.T, ctx context.Context, s mongodb.Store) {
+	mustInsertCategory(t, ctx, s, category("ir-rear-aspect", 10), category("ir-all-aspect", 20))
+
+	if err := s.UpdateCategory(ctx, "ir-rear-aspect", category("ir-all-aspect", 10)); !errors.Is(err, mongodb.ErrConflict) {
+		t.Fatalf("UpdateCategory onto an existing slug returned %v, want ErrConflict", err)
+	}
+
+	checkNames(t, "Categories", slugs(t, ctx, s), []string{"ir-rear-aspect", "ir-all-aspect"})
+}
+
+func testDeleteCategory(t *testing.T, ctx context.Context, s mongodb.Store) {
+	if err := s.DeleteCategory(ctx, "ir-rear-aspect"); !errors.Is(err, mongodb.ErrNothingFound) {
+		t.Fatalf("DeleteCategory on an empty store returned %v, want ErrNothingFound", err)
+	}
+
+	mustInsertCategory(t, ctx, s, category("ir-rear-aspect", 10), category("ir-all-aspect", 20))
+
+	if err := s.DeleteCategory(ctx, "ir-rear-aspect"); err != nil {
+		t.Fatalf("DeleteCategory: %v", err)
+	}
+
+	checkNames(t, "Categories", slugs(t, ctx, s), []string{"ir-all-aspect"})
+
+	if _, err := s.Category(ctx, "ir-rear-aspect"); err == nil {
+		t.Fatal("Category found a deleted category")
+	}
+}
+
+func testSeedCategories(t *testing.T, ctx context.Context, s mongodb.Store) {
+	defaults := []models.Category{*category("ir-rear-aspect", 10), *category("ir-all-aspect", 20)}
+
+	if err := mongodb.SeedCategories(ctx, s, defaults); err != nil {
+		t.Fatalf("SeedCategories: %v", err)
+	}
+
+	checkNames(t, "Categories", slugs(t, ctx, s), []string{"ir-rear-aspect", "ir-all-aspect"})
+
+	if err := s.DeleteCategory(ctx, "ir-rear-aspect"); err != nil {
+		t.Fatalf("DeleteCategory: %v", err)
+	}
+
+	if err := mongodb.SeedCategories(ctx, s, defaults); err != nil {
+		t.Fatalf("SeedCategories on a seeded store: %v", err)
+	}
+
+	checkNames(t, "Categories", slugs(t, ctx, s), []string{"ir-all-aspect"})
+}
+
+func testWeaponsEmpty(t *testing.T, ctx context.Context, s mongodb.Store) {
+	weapons, err := s.Weapons(ctx)
+	if err != nil {
+		t.Fatalf("Weapons on an empty store: %v", err)
+	}
+
+	if len(weapons) != 0 {
+		t.Fatalf("Weapons on an empty store returned %d weapons", len(weapons))
+	}
+}
+
+func testWeaponsInsertionOrder(t *testing.T, ctx context.Context, s mongodb.Store) {
+	mustInsert(t, ctx, s,
+		weapon("R-73", "ir-all-aspect"),
+		weapon("AIM-9B", "ir-rear-aspect"),
+		weapon("AIM-120A", "aam-arh"),
+	)
+
+	weapons, err := s.Weapons(ctx)
+	if err != nil {
+		t.Fatalf("Weapons: %v", err)
+	}
+
+	checkNames(t, "Weapons", names(weapons), []string{"R-73", "AIM-9B", "AIM-120A"})
+}
+
+func testWeaponsReturnsCopies(t *testing.T, ctx context.Context, s mongodb.Store) {
+	mustInsert(t, ctx, s, weapon("R-73", "ir-all-aspect"))
+
+	weapons, err := s.Weapons(ctx)
+	if err != nil {
+		t.Fatalf("Weapons: %v", err)
+	}
+
+	weapons[0].Name = "mutated"
+	weapons[0].Mass.Text = "0"
+
+	weapons, err = s.Weapons(ctx)
+	if err != nil {
+		t.Fatalf("Weapons: %v", err)
+	}
+
+	if weapons[0].Name != "R-73" || weapons[0].Mass.String() != "85.5" {
+		t.Fatalf("mutating a returned weapon changed the store: got %q with mass %q", weapons[0].Name, weapons[0].Mass)
+	}
+}
+
+func testWeapon(t *testing.T, ctx context.Context, s mongodb.Store) {
+	mustInsert(t, ctx, s,
+		weapon("R-73", "ir-all-aspect"),
+		weapon("R-60", "ir-all-aspect"),
+	)
+
+	w, err := s.Weapon(ctx, "R-60")
+	if err != nil {
+		t.Fatalf("Weapon: %v", err)
+	}
+
+	if w.Name != "R-60" || w.Category != "ir-all-aspect" {
+		t.Fatalf("Weapon returned %s in %s, want R-60 in ir-all-aspect", w.Name, w.Category)
+	}
+
+	if got := w.Mass; got.Magnitude != 85.5 || got.Unit != "kg" {
+		t.Errorf("Weapon returned mass %+v, want 85.5 kg", got)
+	}
+
+	w.Name = "changed"
+
+	again, err := s.Weapon(ctx, "R-60")
+	if err != nil {
+		t.Fatalf("Weapon after modifying a result: %v", err)
+	}
+
+	if again.Name != "R-60" {
+		t.Error("Weapon returned a reference into the store")
+	}
+}
+
+func testWeaponMissing(t *testing.T, ctx context.Context, s mongodb.Store) {
+	if _, err := s.Weapon(ctx, "R-73"); !errors.Is(err, mongodb.ErrNothingFound) {
+		t.Fatalf("Weapon of a missing weapon returned %v, want ErrNothingFound", err)
+	}
+
+	mustInsert(t, ctx, s, weapon("R-73", "ir-all-aspect"))
+
+	if err := s.DeleteWeapon(ctx, "R-73", ""); err != nil {
+		t.Fatalf("DeleteWeapon: %v", err)
+	}
+
+	if _, err := s.Weapon(ctx, "R-73"); !errors.Is(err, mongodb.ErrNothingFound) {
+		t.Fatalf("Weapon of a deleted weapon returned %v, want ErrNothingFound", err)
+	}
+}
+
+func testWeaponsByCategory(t *testing.T, ctx context.Context, s mongodb.Store) {
+	mustInsert(t, ctx, s,
+		weapon("AIM-9L", "ir-all-aspect"),
+		weapon("AIM-9B", "ir-rear-aspect"),
+		weapon("R-73", "ir-all-aspect"),
+	)
+
+	weapons, err := s.WeaponsByCategory(ctx, "ir-all-aspect")
+	if err != nil {
+		t.Fatalf("WeaponsByCategory: %v", err)
+	}
+
+	checkNames(t, "WeaponsByCategory", names(weapons), []string{"AIM-9L", "R-73"})
+
+	for _, w := range weapons {
+		if w.Category != "ir-all-aspect" {
+			t.Errorf("WeaponsByCategory returned %q from category %q", w.Name, w.Category)
+		}
+	}
+}
+
+func testWeaponsByCategoryNothingFound(t *testing.T, ctx context.Context, s mongodb.Store) {
+	if _, err := s.WeaponsByCategory(ctx, "ir-all-aspect"); !errors.Is(err, mongodb.ErrNothingFound) {
+		t.Fatalf("WeaponsByCategory on an empty store returned %v, want ErrNothingFound", err)
+	}
+
+	mustInsert(t, ctx, s, weapon("AIM-9B", "ir-rear-aspect"))
+
+	if _, err := s.WeaponsByCategory(ctx, "ir-all-aspect"); !errors.Is(err, mongodb.ErrNothingFound) {
+		t.Fatalf("WeaponsByCategory for a category without weapons returned %v, want ErrNothingFound", err)
+	}
+}
+
+func testInsertWeapon(t *testing.T, ctx context.Context, s mongodb.Store) {
+	w := weapon("R-73", "ir-all-aspect")
+	w.GimbalLimit = models.ParseValue("45")
+	w.Slug = "chosen-by-the-caller"
+
+	mustInsert(t, ctx, s, w)
+
+	// The store picks the slug and sets it on the inserted params.
+	if w.Slug != "r-73" {
+		t.Fatalf("InsertWeapon set the slug %q, want r-73", w.Slug)
+	}
+
+	weapons, err := s.WeaponsByCategory(ctx, "ir-all-aspect")
+	if err != nil {
+		t.Fatalf("WeaponsByCategory: %v", err)
+	}
+
+	if len(weapons) != 1 {
+		t.Fatalf("WeaponsByCategory returned %d weapons, want 1", len(weapons))
+	}
+
+	if got := weapons[0]; got.Slug != "r-73" {
+		t.Fatalf("inserted weapon read back with slug %q, want r-73", got.Slug)
+	}
+
+	if got := weapons[0]; got.Mass.String() != "85.5" || got.GimbalLimit.String() != "45" {
+		t.Fatalf("inserted weapon read back with mass %q and gimbal limit %q", got.Mass, got.GimbalLimit)
+	}
+
+	if got := weapons[0].Mass; got.Kind != models.KindNumber || got.Magnitude != 85.5 || got.Unit != "kg" {
+		t.Fatalf("inserted mass read back as %+v, want 85.5 kg", *got)
+	}
+}
+
+func testInsertWeaponDuplicate(t *testing.T, ctx context.Context, s mongodb.Store) {
+	mustInsert(t, ctx, s, weapon("R-73", "ir-all-aspect"))
+
+	if err := s.InsertWeapon(ctx, weapon("R-73", "ir-rear-aspect")); !errors.Is(err, mongodb.ErrConflict) {
+		t.Fatalf("InsertWeapon of a duplicate name returned %v, want ErrConflict", err)
+	}
+
+	weapons, err := s.Weapons(ctx)
+	if err != nil {
+		t.Fatalf("Weapons: %v", err)
+	}
+
+	checkNames(t, "Weapons", names(weapons), []string{"R-73"})
+
+	if weapons[0].Category != "ir-all-aspect" {
+		t.Fatalf("rejected duplicate overwrote the category with %q", weapons[0].Category)
+	}
+}
+
+// testInsertWeaponDuplicateRace inserts the same name concurrently. A
+// check-then-insert without a uniqueness guarantee lets several through.
+func testInsertWeaponDuplicateRace(t *testing.T, ctx context.Context, s mongodb.Store) {
+	const workers = 16
+
+	var (
+		wg        sync.WaitGroup
+		mu        sync.Mutex
+		succeeded int
+	)
+
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			err := s.InsertWeapon(ctx, weapon("R-73", "ir-all-aspect"))
+
+			mu.Lock()
+			defer mu.Unlock()
+
+			switch {
+			case err == nil:
+				succeeded++
+			case !errors.Is(err, mongodb.ErrConflict):
+				t.Errorf("InsertWeapon of a taken name returned %v, want ErrConflict", err)
+			}
+		}()
+	}
+
+	wg.Wait()
+
+	if succeeded != 1 {
+		t.Fatalf("%d concurrent inserts of the same name succeeded, want 1", succeeded)
+	}
+
+	weapons, err := s.Weapons(ctx)
+	if err != nil {
+		t.Fatalf("Weapons: %v", err)
+	}
+
+	checkNames(t, "Weapons", names(weapons), []string{"R-73"})
+}
+
+func testUpdateWeapon(t *testing.T, ctx context.Context, s mongodb.Store) {
+	mustInsert(t, ctx, s,
+		weapon("R-73", "ir-all-aspect"),
+		weapon("R-60", "ir-all-aspect"),
+	)
+
+	update := weapon("R-73", "ir-all-aspect")
+	update.Mass = models.ParseValue("105")
+	update.GimbalLimit = models.ParseValue("45")
+
+	if err := s.UpdateWeapon(ctx, "R-73", update); err != nil {
+		t.Fatalf("UpdateWeapon: %v", err)
+	}
+
+	weapons, err := s.WeaponsByCategory(ctx, "ir-all-aspect")
+	if err != nil {
+		t.Fatalf("WeaponsByCategory: %v", err)
+	}
+
+	checkNames(t, "WeaponsByCategory", names(weapons), []string{"R-73", "R-60"})
+
+	if got := weapons[0]; got.Mass.String() != "105" || got.GimbalLimit.String() != "45" {
+		t.Fatalf("updated weapon read back with mass %q and gimbal limit %q", got.Mass, got.GimbalLimit)
+	}
+
+	if got := weapons[1]; got.Mass.String() != "85.5" {
+		t.Fatalf("UpdateWeapon changed another weapon: mass %q", got.Mass)
+	}
+}
+
+func testUpdateWeaponMissingName(t *testing.T, ctx context.Context, s mongodb.Store) {
+	if err := s.UpdateWeapon(ctx, "R-73", weapon("R-73", "ir-all-aspect")); !errors.Is(err, mongodb.ErrNothingFound) {
+		t.Fatalf("UpdateWeapon on an empty store returned %v, want ErrNothingFound", err)
+	}
+
+	mustInsert(t, ctx, s, weapon("R-60", "ir-all-aspect"))
+
+	if err := s.UpdateWeapon(ctx, "R-73", weapon("R-73", "ir-all-aspect")); !errors.Is(err, mongodb.ErrNothingFound) {
+		t.Fatalf("UpdateWeapon for a missing name returned %v, want ErrNothingFound", err)
+	}
+
+	weapons, err := s.Weapons(ctx)
+	if err != nil {
+		t.Fatalf("Weapons: %v", err)
+	}
+
+	checkNames(t, "Weapons", names(weapons), []string{"R-60"})
+}
+
+func testUpdateWeaponRename(t *testing.T, ctx context.Context, s mongodb.Store) {
+	mustInsert(t, ctx, s, weapon("R-73", "ir-all-aspect"))
+
+	if err := s.UpdateWeapon(ctx, "R-73", weapon("R-73E", "ir-all-aspect")); err != nil {
+		t.Fatalf("UpdateWeapon: %v", err)
+	}
+
+	weapons, err := s.Weapons(ctx)
+	if err != nil {
+		t.Fatalf("Weapons: %v", err)
+	}
+
+	checkNames(t, "Weapons", names(weapons), []string{"R-73E"})
+
+	if err := s.UpdateWeapon(ctx, "R-73", weapon("R-73", "ir-all-aspect")); err == nil {
+		t.Fatal("UpdateWeapon found a weapon under its old name after a rename")
+	}
+
+	if err := s.InsertWeapon(ctx, weapon("R-73", "ir-all-aspect")); err != nil {
+		t.Fatalf("InsertWeapon with the old name after a rename: %v", err)
+	}
+}
+
+func testWeaponBySlug(t *testing.T, ctx context.Context, s mongodb.Store) {
+	first, second := weapon("AIM-9L", "ir-all-aspect"), weapon("AIM 9L", "ir-all-aspect")
+	mustInsert(t, ctx, s, first, second)
+
+	if first.Slug != "aim-9l" || second.Slug != "aim-9l-2" {
+		t.Fatalf("InsertWeapon gave the slugs %q and %q, want aim-9l and aim-9l-2", first.Slug, second.Slug)
+	}
+
+	got, err := s.WeaponBySlug(ctx, "aim-9l-2")
+	if err != nil {
+		t.Fatalf("WeaponBySlug: %v", err)
+	}
+
+	if got.Name != "AIM 9L" {
+		t.Fatalf("WeaponBySlug returned %s, want AIM 9L", got.Name)
+	}
+
+	if _, err := s.WeaponBySlug(ctx, "r-73"); !errors.Is(err, mongodb.ErrNothingFound) {
+		t.Fatalf("WeaponBySlug of a weapon that was never inserted returned %v, want ErrNothingFound", err)
+	}
+}
+
+// testWeaponBySlugRace inserts names that share a slug concurrently. They
+// must all get in under different slugs.
+func testWeaponBySlugRace(t *testing.T, ctx context.Context, s mongodb.Store) {
+	sameSlug := []string{"AIM-9L", "AIM 9L", "aim-9l", "Aim 9L", "AIM-9l", "aim 9l", "AIM 9l", "aim-9L"}
+
+	var wg sync.WaitGroup
+
+	inserted := make([]*models.Params, len(sameSlug))
+
+	for i, name := range sameSlug {
+		inserted[i] = weapon(name, "ir-all-aspect")
+
+		wg.Add(1)
+		go func(w *models.Params) {
+			defer wg.Done()
+
+			if err := s.InsertWeapon(ctx, w); err != nil {
+				t.Errorf("InsertWeapon(%q): %v", w.Name, err)
+			}
+		}(inserted[i])
+	}
+
+	wg.Wait()
+
+	slugs := map[string]string{}
+
+	for _, w := range inserted {
+		got, err := s.WeaponBySlug(ctx, w.Slug)
+		if err != nil || got.Name != w.Name {
+			t.Errorf("WeaponBySlug(%q) returned %v, %v, want %s", w.Slug, got, err, w.Name)
+		}
+
+		if other, ok := slugs[w.Slug]; ok {
+			t.Errorf("%s and %s were both given the slug %s", other, w.Name, w.Slug)
+		}
+
+		slugs[w.Slug] = w.Name
+	}
+}
+
+func testWeaponBySlugRename(t *testing.T, ctx context.Context, s mongodb.Store) {
+	mustInsert(t, ctx, s, weapon("R-73", "ir-all-aspect"))
+
+	if err := s.UpdateWeapon(ctx, "R-73", weapon("R-73E", "ir-all-aspect")); err != nil {
+		t.Fatalf("UpdateWeapon: %v", err)
+	}
+
+	got, err := s.WeaponBySlug(ctx, "r-73")
+	if err != nil {
+		t.Fatalf("WeaponBySlug after a rename: %v", err)
+	}
+
+	if got.Name != "R-73E" || got.Slug != "r-73" {
+		t.Fatalf("WeaponBySlug returned %s under %s, want R-73E under r-73", got.Name, got.Slug)
+	}
+
+	// The old slug stays taken, so the new weapon must not steal the link.
+	renamed := weapon("R-73", "ir-all-aspect")
+	mustInsert(t, ctx, s, renamed)
+
+	if renamed.Slug != "r-73-2" {
+		t.Fatalf("InsertWeapon gave the slug %q, want r-73-2", renamed.Slug)
+	}
+}
+
+func testWeaponBySlugDeleted(t *testing.T, ctx context.Context, s mongodb.Store) {
+	mustInsert(t, ctx, s, weapon("R-60", "ir-all-aspect"))
+
+	if err := s.DeleteWeapon(ctx, "R-60", "duplicate entry"); err != nil {
+		t.Fatalf("DeleteWeapon: %v", err)
+	}
+
+	if _, err := s.WeaponBySlug(ctx, "r-60"); err == nil {
+		t.Fatal("WeaponBySlug found a deleted weapon")
+	}
+}
+
+func testUpdateWeaponRenameConflict(t *testing.T, ctx context.Context, s mongodb.Store) {
+	mustInsert(t, ctx, s, weapon("R-73", "ir-all-aspect"), weapon("R-60", "ir-all-aspect"))
+
+	if err := s.UpdateWeapon(ctx, "R-60", weapon("R-73", "ir-all-aspect")); !errors.Is(err, mongodb.ErrConflict) {
+		t.Fatalf("UpdateWeapon onto a taken name returned %v, want ErrConflict", err)
+	}
+
+	weapons, err := s.Weapons(ctx)
+	if err != nil {
+		t.Fatalf("Weapons: %v", err)
+	}
+
+	checkNames(t, "Weapons", names(weapons), []string{"R-73", "R-60"})
+}
+
+func testSearchWeapon(t *testing.T, ctx context.Context, s mongodb.Store) {
+	mustInsert(t, ctx, s,
+		weapon("AIM-9L", "ir-all-aspect"),
+		weapon("R-73", "ir-all-aspect"),
+		weapon("AIM-9B", "ir-rear-aspect"),
+	)
+
+	weapons, err := s.SearchWeapon(ctx, "aim-9")
+	if err != nil {
+		t.Fatalf("SearchWeapon: %v", err)
+	}
+
+	want := []models.Name{
+		{Name: "AIM-9L", Slug: "aim-9l", Category: "ir-all-aspect"},
+		{Name: "AIM-9B", Slug: "aim-9b", Category: "ir-rear-aspect"},
+	}
+
+	if fmt.Sprint(weapons) != fmt.Sprint(want) {
+		t.Fatalf("SearchWeapon returned %v, want %v", weapons, want)
+	}
+}
+
+func testSearchWeaponNothingFound(t *testing.T, ctx context.Context, s mongodb.Store) {
+	mustInsert(t, ctx, s, weapon("R-73", "ir-all-aspect"))
+
+	if _, err := s.SearchWeapon(ctx, "AIM"); !errors.Is(err, mongodb.ErrNothingFound) {
+		t.Fatalf("SearchWeapon without matches returned %v, want ErrNothingFound", err)
+	}
+}
+
+// testSearchWeaponMetacharacters checks that the keyword is matched
+// literally rather than compiled as a regular expression.
+func testSearchWeaponMetacharacters(t *testing.T, ctx context.Context, s mongodb.Store) {
+	mustInsert(t, ctx, s,
+		weapon("R.550 Magic", "ir-all-aspect"),
+		weapon("R-550 Magic", "ir-all-aspect"),
+		weapon("Mistral (SAM)", "sam-ir"),
+	)
+
+	weapons, err := s.SearchWeapon(ctx, "r.550")
+	if err != nil {
+		t.Fatalf("SearchWeapon: %v", err)
+	}
+
+	want := []models.Name{{Name: "R.550 Magic", Slug: "r-550-magic", Category: "ir-all-aspect"}}
+
+	if fmt.Sprint(weapons) != fmt.Sprint(want) {
+		t.Fatalf("SearchWeapon(%q) returned %v, want %v", "r.550", weapons, want)
+	}
+
+	weapons, err = s.SearchWeapon(ctx, "(sam")
+	if err != nil {
+		t.Fatalf("SearchWeapon: %v", err)
+	}
+
+	want = []models.Name{{Name: "Mistral (SAM)", Slug: "mistral-sam", Category: "sam-ir"}}
+
+	if fmt.Sprint(weapons) != fmt.Sprint(want) {
+		t.Fatalf("SearchWeapon(%q) returned %v, want %v", "(sam", weapons, want)
+	}
+
+	for _, keyWord := range []string{".*", "[", "R-5+0"} {
+		if _, err := s.SearchWeapon(ctx, keyWord); err == nil {
+			t.Errorf("SearchWeapon(%q) matched although no name contains it", keyWord)
+		}
+	}
+}
+
+func testDeleteWeapon(t *testing.T, ctx context.Context, s mongodb.Store) {
+	mustInsert(t, ctx, s,
+		weapon("R-73", "ir-all-aspect"),
+		weapon("R-60", "ir-all-aspect"),
+	)
+
+	if err := s.DeleteWeapon(ctx, "R-73", "duplicate entry"); err != nil {
+		t.Fatalf("DeleteWeapon: %v", err)
+	}
+
+	weapons, err := s.Weapons(ctx)
+	if err != nil {
+		t.Fatalf("Weapons: %v", err)
+	}
+
+	checkNames(t, "Weapons", names(weapons), []string{"R-60"})
+
+	weapons, err = s.WeaponsByCategory(ctx, "ir-all-aspect")
+	if err != nil {
+		t.Fatalf("WeaponsByCategory: %v", err)
+	}
+
+	checkNames(t, "WeaponsByCategory", names(weapons), []string{"R-60"})
+
+	if _, err := s.SearchWeapon(ctx, "R-73"); err == nil {
+		t.Error("SearchWeapon found a deleted weapon")
+	}
+}
+
+func testDeleteWeaponMissing(t *testing.T, ctx context.Context, s mongodb.Store) {
+	if err := s.DeleteWeapon(ctx, "R-73", ""); !errors.Is(err, mongodb.ErrNothingFound) {
+		t.Fatalf("DeleteWeapon of a missing weapon returned %v, want ErrNothingFound", err)
+	}
+}
+
+func testDeleteWeaponTwice(t *testing.T, ctx context.Context, s mongodb.Store) {
+	mustInsert(t, ctx, s, weapon("R-73", "ir-all-aspect"))
+
+	if err := s.DeleteWeapon(ctx, "R-73", ""); err != nil {
+		t.Fatalf("DeleteWeapon: %v", err)
+	}
+
+	if err := s.DeleteWeapon(ctx, "R-73", ""); !errors.Is(err, mongodb.ErrNothingFound) {
+		t.Fatalf("DeleteWeapon of an already deleted weapon returned %v, want ErrNothingFound", err)
+	}
+}
+
+func testDeletedWeapons(t *testing.T, ctx context.Context, s mongodb.Store) {
+	mustInsert(t, ctx, s,
+		weapon("R-73", "ir-all-aspect"),
+		weapon("R-60", "ir-all-aspect"),
+	)
+
+	if err := s.DeleteWeapon(ctx, "R-73", "duplicate entry"); err != nil {
+		t.Fatalf("DeleteWeapon: %v", err)
+	}
+
+	weapons, err := s.DeletedWeapons(ctx)
+	if err != nil {
+		t.Fatalf("DeletedWeapons: %v", err)
+	}
+
+	checkNames(t, "DeletedWeapons", names(weapons), []string{"R-73"})
+
+	if len(weapons) != 1 || weapons[0].Deleted == nil {
+		t.Fatal("DeletedWeapons didn't return the deletion record")
+	}
+
+	if weapons[0].Deleted.Reason != "duplicate entry" {
+		t.Errorf("deletion reason is %q, want %q", weapons[0].Deleted.Reason, "duplicate entry")
+	}
+
+	if weapons[0].Deleted.DeletedAt.IsZero() {
+		t.Error("deletion time isn't set")
+	}
+}
+
+func testRestoreWeapon(t *testing.T, ctx context.Context, s mongodb.Store) {
+	mustInsert(t, ctx, s,
+		weapon("R-73", "ir-all-aspect"),
+		weapon("R-60", "ir-all-aspect"),
+	)
+
+	if err := s.DeleteWeapon(ctx, "R-73", ""); err != nil {
+		t.Fatalf("DeleteWeapon: %v", err)
+	}
+
+	if err := s.RestoreWeapon(ctx, "R-73"); err != nil {
+		t.Fatalf("RestoreWeapon: %v", err)
+	}
+
+	weapons, err := s.Weapons(ctx)
+	if err != nil {
+		t.Fatalf("Weapons: %v", err)
+	}
+
+	checkNames(t, "Weapons", names(weapons), []string{"R-73", "R-60"})
+
+	for _, w := range weapons {
+		if w.Deleted != nil {
+			t.Errorf("%s is still marked as deleted after a restore", w.Name)
+		}
+	}
+
+	deleted, err := s.DeletedWeapons(ctx)
+	if err != nil {
+		t.Fatalf("DeletedWeapons: %v", err)
+	}
+
+	checkNames(t, "DeletedWeapons", names(deleted), []string{})
+}
+
+func testRestoreWeaponNotDeleted(t *testing.T, ctx context.Context, s mongodb.Store) {
+	mustInsert(t, ctx, s, weapon("R-73", "ir-all-aspect"))
+
+	if err := s.RestoreWeapon(ctx, "R-73"); !errors.Is(err, mongodb.ErrNothingFound) {
+		t.Fatalf("RestoreWeapon of a weapon that isn't deleted returned %v, want ErrNothingFound", err)
+	}
+
+	if err := s.RestoreWeapon(ctx, "R-60"); !errors.Is(err, mongodb.ErrNothingFound) {
+		t.Fatalf("RestoreWeapon of a missing weapon returned %v, want ErrNothingFound", err)
+	}
+}
+
+func testUpdateDeletedWeapon(t *testing.T, ctx context.Context, s mongodb.Store) {
+	mustInsert(t, ctx, s, weapon("R-73", "ir-all-aspect"))
+
+	if err := s.DeleteWeapon(ctx, "R-73", ""); err != nil {
+		t.Fatalf("DeleteWeapon: %v", err)
+	}
+
+	if err := s.UpdateWeapon(ctx, "R-73", weapon("R-73E", "ir-all-aspect")); !errors.Is(err, mongodb.ErrNothingFound) {
+		t.Fatalf("UpdateWeapon of a deleted weapon returned %v, want ErrNothingFound", err)
+	}
+}
+
+func testInsertDeletedWeaponName(t *testing.T, ctx context.Context, s mongodb.Store) {
+	mustInsert(t, ctx, s, weapon("R-73", "ir-all-aspect"))
+
+	if err := s.DeleteWeapon(ctx, "R-73", ""); err != nil {
+		t.Fatalf("DeleteWeapon: %v", err)
+	}
+
+	if err := s.InsertWeapon(ctx, weapon("R-73", "ir-all-aspect")); err == nil {
+		t.Fatal("InsertWeapon reused the name of a deleted weapon")
+	}
+}
+
+// fast returns w with its maximum speed set to speed.
+func fast(w *models.Params, speed string) *models.Params {
+	w.MaximumSpeed = models.ParseValue(speed)
+	return w
+}
+
+func mustList(t *testing.T, ctx context.Context, s mongodb.Store, opts mongodb.ListOptions) *mongodb.Page {
+	t.Helper()
+
+	page, err := s.ListWeapons(ctx, opts)
+	if err != nil {
+		t.Fatalf("ListWeapons(%+v): %v", opts, err)
+	}
+
+	return page
+}
+
+func testListWeaponsPages(t *testing.T, ctx context.Context, s mongodb.Store) {
+	mustInsert(t, ctx, s,
+		weapon("R-73", "ir-all-aspect"),
+		weapon("AIM-9B", "ir-rear-aspect"),
+		weapon("AIM-120A", "aam-arh"),
+		weapon("R-60", "ir-all-aspect"),
+		weapon("R-27ER", "aam-sarh"),
+	)
+
+	opts := mongodb.ListOptions{Limit: 2}
+
+	var got [][]string
+
+	for i := 0; i < 5; i++ {
+		page := mustList(t, ctx, s, opts)
+		got = append(got, names(page.Weapons))
+
+		if page.NextCursor == "" {
+			break
+		}
+
+		opts.Cursor = page.NextCursor
+	}
+
+	want := [][]string{{"AIM-120A", "AIM-9B"}, {"R-27ER", "R-60"}, {"R-73"}}
+	if fmt.Sprint(got) != fmt.Sprint(want) {
+		t.Fatalf("ListWeapons pages are %q, want %q", got, want)
+	}
+}
+
+func testListWeaponsPagesChanged(t *testing.T, ctx context.Context, s mongodb.Store) {
+	mustInsert(t, ctx, s,
+		weapon("AIM-9B", "ir-rear-aspect"),
+		weapon("AIM-9L", "ir-all-aspect"),
+		weapon("R-60", "ir-all-aspect"),
+		weapon("R-73", "ir-all-aspect"),
+	)
+
+	opts := mongodb.ListOptions{Limit: 2}
+
+	page := mustList(t, ctx, s, opts)
+	checkNames(t, "ListWeapons first page", names(page.Weapons), []string{"AIM-9B", "AIM-9L"})
+
+	// A page continues after the last weapon of the previous one,
+	// whatever was inserted or deleted before it in between.
+	mustInsert(t, ctx, s, weapon("AIM-54A", "aam-arh"), weapon("AIM-7F", "aam-sarh"))
+
+	if err := s.DeleteWeapon(ctx, "AIM-9B", ""); err != nil {
+		t.Fatalf("DeleteWeapon: %v", err)
+	}
+
+	opts.Cursor = page.NextCursor
+
+	page = mustList(t, ctx, s, opts)
+	checkNames(t, "ListWeapons second page", names(page.Weapons), []string{"R-60", "R-73"})
+
+	if page.NextCursor != "" {
+		t.Error("ListWeapons returned a cursor on the last page")
+	}
+}
+
+func testListWeaponsSort(t *testing.T, ctx context.Context, s mongodb.Store) {
+	mustInsert(t, ctx, s,
+		fast(weapon("D", "ir-all-aspect"), "500"),
+		weapon("B", "ir-all-aspect"),
+		fast(weapon("C", "ir-all-aspect"), "900"),
+		fast(weapon("A", "ir-all-aspect"), "500"),
+	)
+
+	page := mustList(t, ctx, s, mongodb.ListOptions{Sort: "flightProp.maximumSpeed"})
+	checkNames(t, "ListWeapons ascending", names(page.Weapons), []string{"B", "A", "D", "C"})
+
+	page = mustList(t, ctx, s, mongodb.ListOptions{Sort: "flightProp.maximumSpeed", Desc: true})
+	checkNames(t, "ListWeapons descending", names(page.Weapons), []string{"C", "A", "D", "B"})
+}
+
+func testListWeaponsSortPages(t *testing.T, ctx context.Context, s mongodb.Store) {
+	mustInsert(t, ctx, s,
+		fast(weapon("R-60", "ir-all-aspect"), "700"),
+		fast(weapon("R-73", "ir-all-aspect"), "800"),
+		fast(weapon("AIM-9L", "ir-all-aspect"), "850"),
+	)
+
+	opts := mongodb.ListOptions{Sort: "flightProp.maximumSpeed", Desc: true, Limit: 2}
+
+	page := mustList(t, ctx, s, opts)
+	checkNames(t, "ListWeapons first page", names(page.Weapons), []string{"AIM-9L", "R-73"})
+
+	opts.Cursor = page.NextCursor
+
+	page = mustList(t, ctx, s, opts)
+	checkNames(t, "ListWeapons second page", names(page.Weapons), []string{"R-60"})
+
+	if page.NextCursor != "" {
+		t.Error("ListWeapons returned a cursor on the last page")
+	}
+}
+
+func testListWeaponsSortPagesMissing(t *testing.T, ctx context.Context, s mongodb.Store) {
+	mustInsert(t, ctx, s,
+		fast(weapon("D", "ir-all-aspect"), "500"),
+		weapon("B", "ir-all-aspect"),
+		fast(weapon("C", "ir-all-aspect"), "900"),
+		fast(weapon("A", "ir-all-aspect"), "500"),
+		weapon("E", "ir-all-aspect"),
+		fast(weapon("F", "ir-all-aspect"), "N/A"),
+		fast(weapon("G", "ir-all-aspect"), "-1"),
+	)
+
+	// F is text, which sorts with the missing values rather than as 0.
+	for _, tc := range []struct {
+		desc bool
+		want []string
+	}{
+		{false, []string{"B", "E", "F", "G", "A", "D", "C"}},
+		{true, []string{"C", "A", "D", "G", "B", "E", "F"}},
+	} {
+		opts := mongodb.ListOptions{Sort: "flightProp.maximumSpeed", Desc: tc.desc, Limit: 1}
+
+		var got []string
+
+		for i := 0; i < 10; i++ {
+			page := mustList(t, ctx, s, opts)
+			got = append(got, names(page.Weapons)...)
+
+			if page.NextCursor == "" {
+				break
+			}
+
+			opts.Cursor = page.NextCursor
+		}
+
+		checkNames(t, fmt.Sprintf("ListWeapons pages (desc %t)", tc.desc), got, tc.want)
+	}
+}
+
+func testListWeaponsCategory(t *testing.T, ctx context.Context, s mongodb.Store) {
+	mustInsert(t, ctx, s,
+		weapon("AIM-9L", "ir-all-aspect"),
+		weapon("AIM-9B", "ir-rear-aspect"),
+		weapon("R-73", "ir-all-aspect"),
+	)
+
+	page := mustList(t, ctx, s, mongodb.ListOptions{Category: "ir-all-aspect"})
+	checkNames(t, "ListWeapons", names(page.Weapons), []string{"AIM-9L", "R-73"})
+
+	page = mustList(t, ctx, s, mongodb.ListOptions{Category: "aam-arh"})
+	if len(page.Weapons) != 0 || page.Weapons == nil {
+		t.Errorf("ListWeapons for an empty category returned %v, want an empty page", page.Weapons)
+	}
+}
+
+func testListWeaponsFields(t *testing.T, ctx context.Context, s mongodb.Store) {
+	w := fast(weapon("R-73", "ir-all-aspect"), "800")
+	mustInsert(t, ctx, s, w)
+
+	page := mustList(t, ctx, s, mongodb.ListOptions{Fields: []string{"flightProp.maximumSpeed"}})
+	if len(page.Weapons) != 1 {
+		t.Fatalf("ListWeapons returned %d weapons, want 1", len(page.Weapons))
+	}
+
+	got := page.Weapons[0]
+
+	if got.Name != "R-73" {
+		t.Errorf("projected weapon is named %q, want R-73", got.Name)
+	}
+
+	if got.MaximumSpeed.String() != "800" {
+		t.Errorf("projected maximum speed is %q, want 800", got.MaximumSpeed.String())
+	}
+
+	if got.Mass != nil || got.Category != "" {
+		t.Errorf("projection kept fields that weren't asked for: mass %q, category %q", got.Mass.String(), got.Category)
+	}
+}
+
+func testListWeaponsFilter(t *testing.T, ctx context.Context, s mongodb.Store) {
+	aim9l := weapon("AIM-9L", "ir-all-aspect")
+	aim9l.GimbalLimit = models.ParseValue("40")
+	aim9l.IRCCM = models.ParseValue("No")
+	aim9l.GuidanceType = models.ParseValue("IR")
+
+	r73 := weapon("R-73", "ir-all-aspect")
+	r73.GimbalLimit = models.ParseValue("60")
+	r73.IRCCM = models.ParseValue("Yes")
+	r73.GuidanceType = models.ParseValue("IR")
+
+	aim9b := weapon("AIM-9B", "ir-rear-aspect")
+	aim9b.GimbalLimit = models.ParseValue("25")
+	aim9b.GuidanceType = models.ParseValue("IR")
+
+	// Text values have no magnitude, numeric comparisons skip them.
+	aim120a := weapon("AIM-120A", "aam-arh")
+	aim120a.GimbalLimit = models.ParseValue("N/A")
+
+	mustInsert(t, ctx, s, aim9l, r73, aim9b, aim120a)
+
+	for _, tc := range []struct {
+		expr string
+		want []string
+	}{
+		{"guidanceProp.gimbalLimit >= 40", []string{"AIM-9L", "R-73"}},
+		{"guidanceProp.gimbalLimit < 30", []string{"AIM-9B"}},
+		{"guidanceProp.gimbalLimit >= 40 and guidanceProp.IRCCM = Yes", []string{"R-73"}},
+		{"guidanceProp.gimbalLimit < 30 or category = aam-arh", []string{"AIM-120A", "AIM-9B"}},
+		{"category in (ir-rear-aspect, aam-arh)", []string{"AIM-120A", "AIM-9B"}},
+		{"guidanceProp.IRCCM exists", []string{"AIM-9L", "R-73"}},
+		{"not guidanceProp.IRCCM exists", []string{"AIM-120A", "AIM-9B"}},
+		{"guidanceProp.IRCCM != Yes", []string{"AIM-9L"}},
+		{"not guidanceProp.IRCCM = Yes", []string{"AIM-120A", "AIM-9B", "AIM-9L"}},
+		{"guidanceProp.gimbalLimit != 40", []string{"AIM-9B", "R-73"}},
+		{"guidanceProp.guidanceType = ir", []string{"AIM-9B", "AIM-9L", "R-73"}},
+		{"guidanceProp.guidanceType != ir", []string{}},
+		{"name = R-73", []string{"R-73"}},
+		{"name != R-73 and guidanceProp.gimbalLimit exists", []string{"AIM-120A", "AIM-9B", "AIM-9L"}},
+	} {
+		node, err := filter.Parse(tc.expr)
+		if err != nil {
+			t.Fatalf("Parse(%q): %v", tc.expr, err)
+		}
+
+		page := mustList(t, ctx, s, mongodb.ListOptions{Filter: node})
+		checkNames(t, fmt.Sprintf("ListWeapons(%q)", tc.expr), names(page.Weapons), tc.want)
+	}
+}
+
+func testListWeaponsFilterPages(t *testing.T, ctx context.Context, s mongodb.Store) {
+	mustInsert(t, ctx, s,
+		fast(weapon("R-60", "ir-all-aspect"), "700"),
+		fast(weapon("R-73", "ir-all-aspect"), "800"),
+		fast(weapon("AIM-9L", "ir-all-aspect"), "850"),
+	)
+
+	node, err := filter.Parse("flightProp.maximumSpeed > 750")
+	if err != nil {
+		t.Fatalf("Parse: %v", err)
+	}
+
+	opts := mongodb.ListOptions{Filter: node, Limit: 1}
+
+	page := mustList(t, ctx, s, opts)
+	checkNames(t, "ListWeapons first page", names(page.Weapons), []string{"AIM-9L"})
+
+	// A cursor only continues the listing it came from.
+	if _, err := s.ListWeapons(ctx, mongodb.ListOptions{Limit: 1, Cursor: page.NextCursor}); err == nil {
+		t.Error("ListWeapons accepted a cursor from a filtered listing without the filter")
+	}
+
+	opts.Cursor = page.NextCursor
+
+	page = mustList(t, ctx, s, opts)
+	checkNames(t, "ListWeapons second page", names(page.Weapons), []string{"R-73"})
+
+	if page.NextCursor != "" {
+		t.Error("ListWeapons returned a cursor on the last page")
+	}
+}
+
+func testListWeaponsSkipsDeleted(t *testing.T, ctx context.Context, s mongodb.Store) {
+	mustInsert(t, ctx, s,
+		weapon("R-73", "ir-all-aspect"),
+		weapon("R-60", "ir-all-aspect"),
+	)
+
+	if err := s.DeleteWeapon(ctx, "R-73", ""); err != nil {
+		t.Fatalf("DeleteWeapon: %v", err)
+	}
+
+	page := mustList(t, ctx, s, mongodb.ListOptions{})
+	checkNames(t, "ListWeapons", names(page.Weapons), []string{"R-60"})
+}
+
+func testListWeaponsInvalidOptions(t *testing.T, ctx context.Context, s mongodb.Store) {
+	mustInsert(t, ctx, s,
+		weapon("R-73", "ir-all-aspect"),
+		weapon("R-60", "ir-all-aspect"),
+	)
+
+	page := mustList(t, ctx, s, mongodb.ListOptions{Limit: 1})
+
+	for _, opts := range []mongodb.ListOptions{
+		{Sort: "flightProp.nope"},
+		{Sort: "guidanceProp.guidanceType"},
+		{Fields: []string{"nope"}},
+		{Limit: mongodb.MaxLimit + 1},
+		{Cursor: "garbage"},
+		{Cursor: page.NextCursor, Sort: "flightProp.maximumSpeed"},
+	} {
+		if _, err := s.ListWeapons(ctx, opts); err == nil {
+			t.Errorf("ListWeapons(%+v) accepted invalid options", opts)
+		}
+	}
+}
+
+// release is the date of the first game version of the history tests,
+// later versions are a month apart.
+var release = time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+func mustInsertVersion(t *testing.T, ctx context.Context, s mongodb.Store, version string, months int) {
+	t.Helper()
+
+	v := &models.GameVersion{Version: version, Date: release.AddDate(0, months, 0)}
+	if err := s.InsertGameVersion(ctx, v); err != nil {
+		t.Fatalf("InsertGameVersion(%q): %v", version, err)
+	}
+}
+
+func mustUpdate(t *testing.T, ctx context.Context, s mongodb.Store, name, mass string) {
+	t.Helper()
+
+	w, err := s.Weapon(ctx, name)
+	if err != nil {
+		t.Fatalf("Weapon(%q): %v", name, err)
+	}
+
+	w.Mass = models.ParseValue(mass)
+
+	if err := s.UpdateWeapon(ctx, name, w); err != nil {
+		t.Fatalf("UpdateWeapon(%q): %v", name, err)
+	}
+}
+
+func checkMassAt(t *testing.T, ctx context.Context, s mongodb.Store, name, version, want string) {
+	t.Helper()
+
+	w, err := s.WeaponAt(ctx, name, version)
+	if err != nil {
+		t.Fatalf("WeaponAt(%q, %q): %v", name, version, err)
+	}
+
+	if w.Mass.String() != want {
+		t.Errorf("WeaponAt(%q, %q) has mass %q, want %q", name, version, w.Mass, want)
+	}
+}
+
+func testGameVersionsSortOrder(t *testing.T, ctx context.Context, s mongodb.Store) {
+	mustInsertVersion(t, ctx, s, "2.35", 2)
+	mustInsertVersion(t, ctx, s, "2.33", 0)
+	mustInsertVersion(t, ctx, s, "2.34", 1)
+
+	versions, err := s.GameVersions(ctx)
+	if err != nil {
+		t.Fatalf("GameVersions: %v", err)
+	}
+
+	var got []string
+	for _, v := range versions {
+		got = append(got, v.Version)
+	}
+
+	checkNames(t, "GameVersions", got, []string{"2.33", "2.34", "2.35"})
+}
+
+func testInsertGameVersionDuplicate(t *testing.T, ctx context.Context, s mongodb.Store) {
+	mustInsertVersion(t, ctx, s, "2.33", 0)
+
+	if err := s.InsertGameVersion(ctx, &models.GameVersion{Version: "2.33", Date: release}); !errors.Is(err, mongodb.ErrConflict) {
+		t.Fatalf("InsertGameVersion of a duplicate version returned %v, want ErrConflict", err)
+	}
+}
+
+func testWeaponAtBaseline(t *testing.T, ctx context.Context, s mongodb.Store) {
+	mustInsert(t, ctx, s, weapon("R-73", "ir-all-aspect"))
+	mustInsertVersion(t, ctx, s, "2.33", 0)
+
+	checkMassAt(t, ctx, s, "R-73", "2.33", "85.5")
+}
+
+func testWeaponAtUpdates(t *testing.T, ctx context.Context, s mongodb.Store) {
+	mustInsertVersion(t, ctx, s, "2.33", 0)
+	mustInsert(t, ctx, s, weapon("R-73", "ir-all-aspect"))
+
+	mustInsertVersion(t, ctx, s, "2.34", 1)
+	mustInsertVersion(t, ctx, s, "2.35", 2)
+	mustUpdate(t, ctx, s, "R-73", "105")
+
+	checkMassAt(t, ctx, s, "R-73", "2.33", "85.5")
+	checkMassAt(t, ctx, s, "R-73", "2.34", "85.5")
+	checkMassAt(t, ctx, s, "R-73", "2.35", "105")
+
+	// A second update in the same version replaces its snapshot.
+	mustUpdate(t, ctx, s, "R-73", "110")
+
+	checkMassAt(t, ctx, s, "R-73", "2.35", "110")
+}
+
+func testWeaponAtBeforeInsert(t *testing.T, ctx context.Context, s mongodb.Store) {
+	mustInsertVersion(t, ctx, s, "2.33", 0)
+	mustInsertVersion(t, ctx, s, "2.34", 1)
+	mustInsert(t, ctx, s, weapon("R-73", "ir-all-aspect"))
+
+	if _, err := s.WeaponAt(ctx, "R-73", "2.33"); !errors.Is(err, mongodb.ErrNothingFound) {
+		t.Fatalf("WeaponAt in a version before the weapon was added returned %v, want ErrNothingFound", err)
+	}
+
+	checkMassAt(t, ctx, s, "R-73", "2.34", "85.5")
+}
+
+func testWeaponAtUnknownVersion(t *testing.T, ctx context.Context, s mongodb.Store) {
+	mustInsertVersion(t, ctx, s, "2.33", 0)
+	mustInsert(t, ctx, s, weapon("R-73", "ir-all-aspect"))
+
+	if _, err := s.WeaponAt(ctx, "R-73", "1.0"); !errors.Is(err, mongodb.ErrUnknownVersion) {
+		t.Fatalf("WeaponAt for an unknown version returned %v, want ErrUnknownVersion", err)
+	}
+
+	if _, err := s.WeaponsByCategoryAt(ctx, "ir-all-aspect", "1.0"); !errors.Is(err, mongodb.ErrUnknownVersion) {
+		t.Fatalf("WeaponsByCategoryAt for an unknown version returned %v, want ErrUnknownVersion", err)
+	}
+}
+
+func testWeaponHistory(t *testing.T, ctx context.Context, s mongodb.Store) {
+	mustInsertVersion(t, ctx, s, "2.33", 0)
+	mustInsert(t, ctx, s, weapon("R-73", "ir-all-aspect"))
+	mustInsertVersion(t, ctx, s, "2.34", 1)
+	mustUpdate(t, ctx, s, "R-73", "105")
+
+	history, err := s.WeaponHistory(ctx, "R-73")
+	if err != nil {
+		t.Fatalf("WeaponHistory: %v", err)
+	}
+
+	var got []string
+	for _, snapshot := range history {
+		got = append(got, snapshot.Version+"="+snapshot.Params.Mass.String())
+	}
+
+	checkNames(t, "WeaponHistory", got, []string{"2.33=85.5", "2.34=105"})
+
+	if _, err := s.WeaponHistory(ctx, "R-60"); err == nil {
+		t.Fatal("WeaponHistory returned the history of a missing weapon")
+	}
+}
+
+func testWeaponAtDeleted(t *testing.T, ctx context.Context, s mongodb.Store) {
+	mustInsertVersion(t, ctx, s, "2.33", 0)
+	mustInsert(t, ctx, s, weapon("R-73", "ir-all-aspect"))
+	mustInsertVersion(t, ctx, s, "2.34", 1)
+
+	if err := s.DeleteWeapon(ctx, "R-73", "removed from the game"); err != nil {
+		t.Fatalf("DeleteWeapon: %v", err)
+	}
+
+	checkMassAt(t, ctx, s, "R-73", "2.33", "85.5")
+
+	if _, err := s.WeaponAt(ctx, "R-73", "2.34"); err == nil {
+		t.Fatal("WeaponAt returned a weapon in the version it was deleted in")
+	}
+
+	history, err := s.WeaponHistory(ctx, "R-73")
+	if err != nil {
+		t.Fatalf("WeaponHistory of a deleted weapon: %v", err)
+	}
+
+	if len(history) != 2 || history[1].Params.Deleted == nil {
+		t.Fatalf("WeaponHistory doesn't end with the deletion: %+v", history)
+	}
+
+	mustInsertVersion(t, ctx, s, "2.35", 2)
+
+	if err := s.RestoreWeapon(ctx, "R-73"); err != nil {
+		t.Fatalf("RestoreWeapon: %v", err)
+	}
+
+	checkMassAt(t, ctx, s, "R-73", "2.35", "85.5")
+
+	weapons, err := s.WeaponsAt(ctx, "2.34")
+	if err != nil {
+		t.Fatalf("WeaponsAt: %v", err)
+	}
+
+	if len(weapons) != 0 {
+		t.Fatalf("WeaponsAt returned %q in the version the weapon was deleted in", names(weapons))
+	}
+}
+
+func testWeaponHistoryRename(t *testing.T, ctx context.Context, s mongodb.Store) {
+	mustInsertVersion(t, ctx, s, "2.33", 0)
+	mustInsert(t, ctx, s, weapon("R-73", "ir-all-aspect"))
+	mustInsertVersion(t, ctx, s, "2.34", 1)
+
+	if err := s.UpdateWeapon(ctx, "R-73", weapon("R-73E", "ir-all-aspect")); err != nil {
+		t.Fatalf("UpdateWeapon: %v", err)
+	}
+
+	w, err := s.WeaponAt(ctx, "R-73E", "2.33")
+	if err != nil {
+		t.Fatalf("WeaponAt under the new name: %v", err)
+	}
+
+	if w.Name != "R-73E" {
+		t.Errorf("WeaponAt returned %q, want R-73E", w.Name)
+	}
+
+	if _, err := s.WeaponAt(ctx, "R-73", "2.33"); err == nil {
+		t.Fatal("WeaponAt found a weapon under its old name after a rename")
+	}
+}
+
+func testWeaponsByCategoryAt(t *testing.T, ctx context.Context, s mongodb.Store) {
+	mustInsertVersion(t, ctx, s, "2.33", 0)
+	mustInsert(t, ctx, s,
+		weapon("R-73", "ir-rear-aspect"),
+		weapon("R-60", "ir-all-aspect"),
+	)
+	mustInsertVersion(t, ctx, s, "2.34", 1)
+	mustInsert(t, ctx, s, weapon("AIM-9L", "ir-all-aspect"))
+
+	if err := s.UpdateWeapon(ctx, "R-73", weapon("R-73", "ir-all-aspect")); err != nil {
+		t.Fatalf("UpdateWeapon: %v", err)
+	}
+
+	old, err := s.WeaponsByCategoryAt(ctx, "ir-all-aspect", "2.33")
+	if err != nil {
+		t.Fatalf("WeaponsByCategoryAt: %v", err)
+	}
+
+	checkNames(t, "WeaponsByCategoryAt(2.33)", names(old), []string{"R-60"})
+
+	current, err := s.WeaponsByCategoryAt(ctx, "ir-all-aspect", "2.34")
+	if err != nil {
+		t.Fatalf("WeaponsByCategoryAt: %v", err)
+	}
+
+	checkNames(t, "WeaponsByCategoryAt(2.34)", names(current), []string{"R-73", "R-60", "AIM-9L"})
+
+	if _, err := s.WeaponsByCategoryAt(ctx, "aam-arh", "2.34"); !errors.Is(err, mongodb.ErrNothingFound) {
+		t.Fatalf("WeaponsByCategoryAt for an empty category returned %v, want ErrNothingFound", err)
+	}
+}
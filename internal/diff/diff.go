@@ -0,0 +1,187 @@
+// Package diff compares the weapons of two game versions and reports what
+// a patch changed: weapons added and removed and every parameter that got
+// a different value, grouped by category.
+package diff
+
+import (
+	"math"
+	"slices"
+	"strings"
+
+	"github.com/zeze322/wt-guided-weaponry/models"
+)
+
+type Report struct {
+	From       models.GameVersion `json:"from"`
+	To         models.GameVersion `json:"to"`
+	Summary    Summary            `json:"summary"`
+	Categories []Category         `json:"categories"`
+}
+
+type Summary struct {
+	Added   int `json:"added"`
+	Removed int `json:"removed"`
+	Changed int `json:"changed"`
+}
+
+// Category lists the changes to the weapons of a category. Weapons are
+// filed under the category they have in the newer version.
+type Category struct {
+	Slug    string   `json:"slug"`
+	Name    string   `json:"name"`
+	Added   []string `json:"added"`
+	Removed []string `json:"removed"`
+	Changed []Weapon `json:"changed"`
+}
+
+type Weapon struct {
+	Name    string   `json:"name"`
+	Changes []Change `json:"changes"`
+}
+
+// Change is a parameter with a different value in the newer version. Old
+// or New is nil when the parameter was added or removed. A move to another
+// category is a change of the "category" key.
+type Change struct {
+	Key   string        `json:"key"`
+	Label string        `json:"label"`
+	Unit  string        `json:"unit,omitempty"`
+	Old   *models.Value `json:"old"`
+	New   *models.Value `json:"new"`
+
+	// Delta is the relative change of a number in percent, nil when either
+	// value isn't a plain number, such as a range, or the old one is zero.
+	Delta *float64 `json:"delta"`
+}
+
+// Compare reports the changes from the weapons of one version to those of
+// another. categories names and orders the categories, the ones it doesn't
+// list come last.
+func Compare(from, to models.GameVersion, before, after []*models.Params, categories []models.Category) *Report {
+	report := &Report{From: from, To: to, Categories: []Category{}}
+
+	byName := make(map[string]*models.Params, len(before))
+	for _, weapon := range before {
+		byName[weapon.Name] = weapon
+	}
+
+	seen := map[string]bool{}
+	changes := map[string]*Category{}
+
+	category := func(slug string) *Category {
+		c, ok := changes[slug]
+		if !ok {
+			c = &Category{Slug: slug, Name: slug, Added: []string{}, Removed: []string{}, Changed: []Weapon{}}
+			changes[slug] = c
+		}
+		return c
+	}
+
+	for _, weapon := range after {
+		seen[weapon.Name] = true
+
+		prev, ok := byName[weapon.Name]
+		if !ok {
+			c := category(weapon.Category)
+			c.Added = append(c.Added, weapon.Name)
+			report.Summary.Added++
+			continue
+		}
+
+		if diff := Params(prev, weapon); len(diff) > 0 {
+			c := category(weapon.Category)
+			c.Changed = append(c.Changed, Weapon{Name: weapon.Name, Changes: diff})
+			report.Summary.Changed++
+		}
+	}
+
+	for _, weapon := range before {
+		if !seen[weapon.Name] {
+			c := category(weapon.Category)
+			c.Removed = append(c.Removed, weapon.Name)
+			report.Summary.Removed++
+		}
+	}
+
+	for _, c := range categories {
+		if changed, ok := changes[c.Slug]; ok {
+			changed.Name = c.Name
+			report.Categories = append(report.Categories, *changed)
+			delete(changes, c.Slug)
+		}
+	}
+
+	rest := make([]string, 0, len(changes))
+	for slug := range changes {
+		rest = append(rest, slug)
+	}
+
+	slices.Sort(rest)
+
+	for _, slug := range rest {
+		report.Categories = append(report.Categories, *changes[slug])
+	}
+
+	return report
+}
+
+// Params lists the parameters that differ between before and after, in the
+// order of the registry.
+func Params(before, after *models.Params) []Change {
+	var changes []Change
+
+	if before.Category != after.Category {
+		changes = append(changes, Change{
+			Key:   "category",
+			Label: "Category",
+			Old:   models.ParseValue(before.Category),
+			New:   models.ParseValue(after.Category),
+		})
+	}
+
+	for _, field := range models.Fields() {
+		a, b := field.Value(before), field.Value(after)
+		if equal(a, b) {
+			continue
+		}
+
+		changes = append(changes, Change{
+			Key:   field.Key,
+			Label: field.Label,
+			Unit:  field.Unit,
+			Old:   a,
+			New:   b,
+			Delta: delta(a, b),
+		})
+	}
+
+	return changes
+}
+
+// equal reports whether a and b are the same value. Numbers only written
+// differently, such as "105" and "105 kg", are equal.
+func equal(a, b *models.Value) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+
+	if strings.TrimSpace(a.Text) == strings.TrimSpace(b.Text) {
+		return true
+	}
+
+	return a.Kind == models.KindNumber && b.Kind == models.KindNumber &&
+		a.Magnitude == b.Magnitude && (a.Unit == b.Unit || a.Unit == "" || b.Unit == "")
+}
+
+// delta is only computed for plain numbers: the magnitude of a range is
+// its upper bound and that of a tuple its first element, whose change
+// doesn't say how the whole value changed.
+func delta(a, b *models.Value) *float64 {
+	if a == nil || b == nil || a.Kind != models.KindNumber || b.Kind != models.KindNumber || a.Magnitude == 0 {
+		return nil
+	}
+
+	d := math.Round((b.Magnitude-a.Magnitude)/math.Abs(a.Magnitude)*1000) / 10
+
+	return &d
+}
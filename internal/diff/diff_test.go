@@ -0,0 +1,203 @@
+package diff
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/zeze322/wt-guided-weaponry/models"
+)
+
+func weapon(name, category, mass string) *models.Params {
+	params := &models.Params{Name: name, Category: category}
+	params.Mass = models.ParseValue(mass)
+	return params
+}
+
+func massChange(old, new string, delta *float64) Change {
+	field, _ := models.FieldByKey("physicalProp.mass")
+
+	return Change{
+		Key:   field.Key,
+		Label: field.Label,
+		Unit:  field.Unit,
+		Old:   models.ParseValue(old),
+		New:   models.ParseValue(new),
+		Delta: delta,
+	}
+}
+
+func ptr(f float64) *float64 {
+	return &f
+}
+
+func TestCompare(t *testing.T) {
+	from := models.GameVersion{Version: "2.33"}
+	to := models.GameVersion{Version: "2.35"}
+
+	before := []*models.Params{
+		weapon("Mistral", models.CategoryIRHeli, "18.7"),
+		weapon("Stinger", models.CategoryIRHeli, "10.1"),
+		weapon("AIM-120A", models.CategoryAAMARH, "157"),
+		weapon("Igla", models.CategoryIRHeli, "10.8"),
+	}
+
+	after := []*models.Params{
+		weapon("Mistral", models.CategoryIRHeli, "19.7 kg"),
+		weapon("Stinger", models.CategoryIRHeli, "10.1 kg"),
+		weapon("Igla", models.CategoryAAMARH, "10.8"),
+		weapon("Custom", "zz-custom", "1"),
+	}
+
+	categories := []models.Category{
+		{Slug: models.CategoryAAMARH, Name: "AAM (ARH)"},
+		{Slug: models.CategoryIRHeli, Name: "AAM (IR heli)"},
+	}
+
+	want := &Report{
+		From:    from,
+		To:      to,
+		Summary: Summary{Added: 1, Removed: 1, Changed: 2},
+		Categories: []Category{
+			{
+				Slug:    models.CategoryAAMARH,
+				Name:    "AAM (ARH)",
+				Added:   []string{},
+				Removed: []string{"AIM-120A"},
+				Changed: []Weapon{{Name: "Igla", Changes: []Change{{
+					Key:   "category",
+					Label: "Category",
+					Old:   models.ParseValue(models.CategoryIRHeli),
+					New:   models.ParseValue(models.CategoryAAMARH),
+				}}}},
+			},
+			{
+				Slug:    models.CategoryIRHeli,
+				Name:    "AAM (IR heli)",
+				Added:   []string{},
+				Removed: []string{},
+				Changed: []Weapon{{Name: "Mistral", Changes: []Change{massChange("18.7", "19.7 kg", ptr(5.3))}}},
+			},
+			{
+				Slug:    "zz-custom",
+				Name:    "zz-custom",
+				Added:   []string{"Custom"},
+				Removed: []string{},
+				Changed: []Weapon{},
+			},
+		},
+	}
+
+	if got := Compare(from, to, before, after, categories); !reflect.DeepEqual(got, want) {
+		t.Fatalf("Compare = %+v, want %+v", got, want)
+	}
+
+	empty := Compare(from, to, before, before, categories)
+	if empty.Summary != (Summary{}) || len(empty.Categories) != 0 {
+		t.Fatalf("Compare of a version with itself = %+v", empty)
+	}
+}
+
+func TestEqual(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want bool
+	}{
+		{"", "", true},
+		{"", "105", false},
+		{"105", "105", true},
+		{"105", "105 kg", true},
+		{"105 kg", "105 lb", false},
+		{"105", "105.0", true},
+		{"105", "106", false},
+		{"IR", "IR", true},
+		{"IR", "ir", false},
+		{"Yes", "No", false},
+		{"±45°", "±45 °", false},
+		{"30/20", "30/20", true},
+	}
+
+	for _, tt := range tests {
+		if got := equal(models.ParseValue(tt.a), models.ParseValue(tt.b)); got != tt.want {
+			t.Errorf("equal(%q, %q) = %t, want %t", tt.a, tt.b, got, tt.want)
+		}
+	}
+}
+
+func TestDelta(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want *float64
+	}{
+		{"100", "105", ptr(5)},
+		{"100", "95", ptr(-5)},
+		{"-100", "-50", ptr(50)},
+		{"3", "4", ptr(33.3)},
+		{"0", "5", nil},
+		{"", "5", nil},
+		{"5", "", nil},
+		{"Yes", "No", nil},
+		{"IR", "5", nil},
+		{"±30", "±45", nil},
+		{"10-30", "10-40", nil},
+		{"30/20", "40/20", nil},
+		{"5", "10-20", nil},
+	}
+
+	for _, tt := range tests {
+		got := delta(models.ParseValue(tt.a), models.ParseValue(tt.b))
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("delta(%q, %q) = %s, want %s", tt.a, tt.b, FormatDelta(got), FormatDelta(tt.want))
+		}
+	}
+}
+
+func TestWriteMarkdown(t *testing.T) {
+	report := &Report{
+		From: models.GameVersion{Version: "2.33"},
+		To:   models.GameVersion{Version: "2.35"},
+		Categories: []Category{{
+			Name:    "AAM (IR heli)",
+			Added:   []string{"Igla", "Stinger"},
+			Removed: []string{"Mistral"},
+			Changed: []Weapon{{Name: "9K38", Changes: []Change{
+				massChange("10.8", "11", ptr(1.9)),
+				{Key: "guidanceProp.guidanceType", Label: "Guidance", Old: models.ParseValue("IR|UV"), New: nil},
+			}}},
+		}},
+	}
+
+	want := `# Changes from 2.33 to 2.35
+
+## AAM (IR heli)
+
+**Added:** Igla, Stinger
+
+**Removed:** Mistral
+
+### 9K38
+
+| Parameter | 2.33 | 2.35 | Δ |
+| --- | --- | --- | --- |
+| Mass [kg] | 10.8 | 11 | +1.9% |
+| Guidance | IR\|UV | — | — |
+`
+
+	var b strings.Builder
+	if err := WriteMarkdown(&b, report); err != nil {
+		t.Fatal(err)
+	}
+
+	if b.String() != want {
+		t.Fatalf("WriteMarkdown wrote\n%s\nwant\n%s", b.String(), want)
+	}
+
+	b.Reset()
+	if err := WriteMarkdown(&b, &Report{From: report.From, To: report.To}); err != nil {
+		t.Fatal(err)
+	}
+
+	if want := "# Changes from 2.33 to 2.35\n\nNo weapon changed.\n"; b.String() != want {
+		t.Fatalf("WriteMarkdown of an empty report wrote %q, want %q", b.String(), want)
+	}
+}
@@ -0,0 +1,76 @@
+package diff
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/zeze322/wt-guided-weaponry/models"
+)
+
+// WriteMarkdown writes the report as Markdown for patch notes, a section
+// per category with a table of the changed parameters of each weapon.
+func WriteMarkdown(w io.Writer, r *Report) error {
+	bw := bufio.NewWriter(w)
+
+	fmt.Fprintf(bw, "# Changes from %s to %s\n", r.From.Version, r.To.Version)
+
+	if len(r.Categories) == 0 {
+		fmt.Fprintf(bw, "\nNo weapon changed.\n")
+	}
+
+	for _, c := range r.Categories {
+		fmt.Fprintf(bw, "\n## %s\n", c.Name)
+
+		if len(c.Added) > 0 {
+			fmt.Fprintf(bw, "\n**Added:** %s\n", strings.Join(c.Added, ", "))
+		}
+
+		if len(c.Removed) > 0 {
+			fmt.Fprintf(bw, "\n**Removed:** %s\n", strings.Join(c.Removed, ", "))
+		}
+
+		for _, weapon := range c.Changed {
+			fmt.Fprintf(bw, "\n### %s\n\n", weapon.Name)
+			fmt.Fprintf(bw, "| Parameter | %s | %s | Δ |\n", r.From.Version, r.To.Version)
+			fmt.Fprintf(bw, "| --- | --- | --- | --- |\n")
+
+			for _, change := range weapon.Changes {
+				fmt.Fprintf(bw, "| %s | %s | %s | %s |\n",
+					cell(Label(change)), cell(Text(change.Old)), cell(Text(change.New)), FormatDelta(change.Delta))
+			}
+		}
+	}
+
+	return bw.Flush()
+}
+
+// Label is the label of the changed parameter with its unit.
+func Label(c Change) string {
+	if c.Unit == "" {
+		return c.Label
+	}
+	return fmt.Sprintf("%s [%s]", c.Label, c.Unit)
+}
+
+// Text is the value as written, "—" when it's absent.
+func Text(v *models.Value) string {
+	if v == nil {
+		return "—"
+	}
+	return v.String()
+}
+
+// FormatDelta formats a delta like "+4.8%", "—" when there is none.
+func FormatDelta(d *float64) string {
+	if d == nil {
+		return "—"
+	}
+	return fmt.Sprintf("%+.1f%%", *d)
+}
+
+func cell(s string) string {
+	s = strings.ReplaceAll(s, "|", `\|`)
+	return strings.ReplaceAll(s, "\n", " ")
+}
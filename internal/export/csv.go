@@ -0,0 +1,34 @@
+package export
+
+import (
+	"encoding/csv"
+	"io"
+)
+
+// WriteCSV writes the notes, an empty line and the rows of t. Section rows
+// only hold their title.
+func WriteCSV(w io.Writer, t *Table) error {
+	cw := csv.NewWriter(w)
+
+	for _, note := range t.Notes {
+		if err := cw.Write([]string{note}); err != nil {
+			return err
+		}
+	}
+
+	if len(t.Notes) > 0 {
+		if err := cw.Write([]string{""}); err != nil {
+			return err
+		}
+	}
+
+	for _, row := range t.Rows {
+		if err := cw.Write(row.Cells); err != nil {
+			return err
+		}
+	}
+
+	cw.Flush()
+
+	return cw.Error()
+}
@@ -0,0 +1,42 @@
+package export
+
+import (
+	"bytes"
+	"encoding/csv"
+	"reflect"
+	"testing"
+)
+
+// TestWriteCSVRoundTrip reads the written CSV back: the notes and the rows,
+// with cells that need quoting kept intact. The reader skips the empty line
+// between them.
+func TestWriteCSVRoundTrip(t *testing.T) {
+	table, _ := sampleTable(t)
+	table.Rows = append(table.Rows, Row{RowData, []string{`Say "hi", twice:`, "a,b", "line\nbreak"}})
+
+	var buf bytes.Buffer
+	if err := WriteCSV(&buf, table); err != nil {
+		t.Fatal(err)
+	}
+
+	if !bytes.Contains(buf.Bytes(), []byte("2 weapons\"\n\nName,")) {
+		t.Fatalf("no empty line between the notes and the rows:\n%s", buf.String())
+	}
+
+	r := csv.NewReader(&buf)
+	r.FieldsPerRecord = -1
+
+	got, err := r.ReadAll()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := [][]string{{table.Notes[0]}, {table.Notes[1]}}
+	for _, row := range table.Rows {
+		want = append(want, row.Cells)
+	}
+
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("read back %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,121 @@
+// Package export writes comparison tables as CSV and XLSX files.
+package export
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/zeze322/wt-guided-weaponry/models"
+)
+
+type RowKind int
+
+const (
+	RowData RowKind = iota
+	RowHeader
+	RowSection
+)
+
+type Row struct {
+	Kind  RowKind
+	Cells []string
+}
+
+// Table is a comparison table laid out as on the site: a header row with the
+// weapon names, then one row per parameter, grouped into sections.
+type Table struct {
+	Title string
+
+	// Notes are written above the table, e.g. when the data was taken.
+	Notes []string
+
+	Rows []Row
+}
+
+// New builds the table of weapons over fields, with the same labels and
+// values as the HTML table. snapshot is the time the weapons were read.
+func New(title string, fields []models.Field, weapons []*models.Params, snapshot time.Time) *Table {
+	t := &Table{
+		Title: title,
+		Notes: []string{
+			title,
+			fmt.Sprintf("Data snapshot: %s, %s", snapshot.UTC().Format("2006-01-02 15:04 MST"), count(len(weapons))),
+		},
+	}
+
+	header := []string{"Name"}
+	for _, weapon := range weapons {
+		header = append(header, weapon.Name)
+	}
+
+	t.Rows = append(t.Rows, Row{Kind: RowHeader, Cells: header})
+
+	var group models.Group
+
+	for _, field := range fields {
+		if field.Group != group {
+			group = field.Group
+			t.Rows = append(t.Rows, Row{Kind: RowSection, Cells: []string{group.Title()}})
+		}
+
+		cells := []string{field.DisplayLabel()}
+		for _, weapon := range weapons {
+			cells = append(cells, field.Value(weapon).String())
+		}
+
+		t.Rows = append(t.Rows, Row{Kind: RowData, Cells: cells})
+	}
+
+	return t
+}
+
+func count(n int) string {
+	if n == 1 {
+		return "1 weapon"
+	}
+	return fmt.Sprintf("%d weapons", n)
+}
+
+// Transpose turns the weapons into rows and the parameters into columns.
+// The section titles move into a header row above the parameter labels.
+func (t *Table) Transpose() *Table {
+	var (
+		sections = []string{""}
+		columns  [][]string
+	)
+
+	for _, row := range t.Rows {
+		switch row.Kind {
+		case RowSection:
+			// The title is placed above the first parameter of the section.
+			sections = append(sections, row.Cells[0])
+		default:
+			if len(sections) <= len(columns) {
+				sections = append(sections, "")
+			}
+			columns = append(columns, row.Cells)
+		}
+	}
+
+	res := &Table{Title: t.Title, Notes: t.Notes}
+
+	res.Rows = append(res.Rows, Row{Kind: RowSection, Cells: sections})
+
+	for i := 0; len(columns) > 0 && i < len(columns[0]); i++ {
+		kind := RowData
+		if i == 0 {
+			kind = RowHeader
+		}
+
+		cells := make([]string, len(columns))
+		for j, column := range columns {
+			if i < len(column) {
+				cells[j] = column[i]
+			}
+		}
+
+		res.Rows = append(res.Rows, Row{Kind: kind, Cells: cells})
+	}
+
+	return res
+}
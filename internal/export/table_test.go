@@ -0,0 +1,94 @@
+package export
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/zeze322/wt-guided-weaponry/models"
+)
+
+func fields(t *testing.T, keys ...string) []models.Field {
+	t.Helper()
+
+	var res []models.Field
+	for _, key := range keys {
+		field, ok := models.FieldByKey(key)
+		if !ok {
+			t.Fatalf("unknown field %s", key)
+		}
+		res = append(res, field)
+	}
+	return res
+}
+
+// sampleTable is the table of two weapons over two sections, the first
+// with two parameters.
+func sampleTable(t *testing.T) (*Table, []models.Field) {
+	t.Helper()
+
+	fs := fields(t, "physicalProp.mass", "physicalProp.length", "guidanceProp.gimbalLimit")
+
+	r73 := &models.Params{Name: "R-73"}
+	r73.Mass = models.ParseValue("105")
+	r73.Length = models.ParseValue("2.9")
+	r73.GimbalLimit = models.ParseValue("±60")
+
+	aim9l := &models.Params{Name: "AIM-9L"}
+	aim9l.Mass = models.ParseValue("85.5")
+
+	snapshot := time.Date(2024, 5, 1, 12, 30, 0, 0, time.FixedZone("CEST", 2*60*60))
+
+	return New("AAM (IR all-aspect)", fs, []*models.Params{r73, aim9l}, snapshot), fs
+}
+
+func TestNew(t *testing.T) {
+	table, fs := sampleTable(t)
+
+	want := &Table{
+		Title: "AAM (IR all-aspect)",
+		Notes: []string{"AAM (IR all-aspect)", "Data snapshot: 2024-05-01 10:30 UTC, 2 weapons"},
+		Rows: []Row{
+			{RowHeader, []string{"Name", "R-73", "AIM-9L"}},
+			{RowSection, []string{fs[0].Group.Title()}},
+			{RowData, []string{fs[0].DisplayLabel(), "105", "85.5"}},
+			{RowData, []string{fs[1].DisplayLabel(), "2.9", ""}},
+			{RowSection, []string{fs[2].Group.Title()}},
+			{RowData, []string{fs[2].DisplayLabel(), "±60", ""}},
+		},
+	}
+
+	if !reflect.DeepEqual(table, want) {
+		t.Fatalf("New = %+v, want %+v", table, want)
+	}
+
+	if got := New("x", nil, []*models.Params{{Name: "R-73"}}, time.Time{}).Notes[1]; got != "Data snapshot: 0001-01-01 00:00 UTC, 1 weapon" {
+		t.Fatalf("snapshot note of one weapon = %q", got)
+	}
+}
+
+// TestTranspose checks that each section title ends up above the first
+// parameter of its section.
+func TestTranspose(t *testing.T) {
+	table, fs := sampleTable(t)
+
+	want := &Table{
+		Title: table.Title,
+		Notes: table.Notes,
+		Rows: []Row{
+			{RowSection, []string{"", fs[0].Group.Title(), "", fs[2].Group.Title()}},
+			{RowHeader, []string{"Name", fs[0].DisplayLabel(), fs[1].DisplayLabel(), fs[2].DisplayLabel()}},
+			{RowData, []string{"R-73", "105", "2.9", "±60"}},
+			{RowData, []string{"AIM-9L", "85.5", "", ""}},
+		},
+	}
+
+	if got := table.Transpose(); !reflect.DeepEqual(got, want) {
+		t.Fatalf("Transpose = %+v, want %+v", got, want)
+	}
+
+	empty := (&Table{Title: "x"}).Transpose()
+	if want := []Row{{RowSection, []string{""}}}; !reflect.DeepEqual(empty.Rows, want) {
+		t.Fatalf("Transpose of an empty table = %+v, want %+v", empty.Rows, want)
+	}
+}
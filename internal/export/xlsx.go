@@ -0,0 +1,187 @@
+package export
+
+import (
+	"archive/zip"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// Cell styles, indexes into cellXfs of xlsxStyles.
+const (
+	styleDefault = iota
+	styleHeader
+	styleSection
+	styleNote
+)
+
+var rowStyles = map[RowKind]int{
+	RowData:    styleDefault,
+	RowHeader:  styleHeader,
+	RowSection: styleSection,
+}
+
+// WriteXLSX writes t as a workbook with a single sheet. The notes come
+// first, the header row and the first column are frozen.
+func WriteXLSX(w io.Writer, t *Table) error {
+	zw := zip.NewWriter(w)
+
+	files := []struct {
+		name, content string
+	}{
+		{"[Content_Types].xml", xlsxContentTypes},
+		{"_rels/.rels", xlsxRels},
+		{"xl/workbook.xml", fmt.Sprintf(xlsxWorkbook, escape(sheetName(t.Title)))},
+		{"xl/_rels/workbook.xml.rels", xlsxWorkbookRels},
+		{"xl/styles.xml", xlsxStyles},
+		{"xl/worksheets/sheet1.xml", sheet(t)},
+	}
+
+	for _, f := range files {
+		fw, err := zw.Create(f.name)
+		if err != nil {
+			return err
+		}
+
+		if _, err := io.WriteString(fw, f.content); err != nil {
+			return err
+		}
+	}
+
+	return zw.Close()
+}
+
+func sheet(t *Table) string {
+	var b strings.Builder
+
+	columns := 0
+	for _, row := range t.Rows {
+		columns = max(columns, len(row.Cells))
+	}
+
+	b.WriteString(xml.Header)
+	b.WriteString(`<worksheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main">`)
+
+	// The header row follows the notes and the empty line after them.
+	headerRow := len(t.Notes) + 1
+	if len(t.Notes) > 0 {
+		headerRow++
+	}
+
+	for i, row := range t.Rows {
+		if row.Kind == RowHeader {
+			headerRow += i
+			break
+		}
+	}
+
+	fmt.Fprintf(&b, `<sheetViews><sheetView workbookViewId="0"><pane xSplit="1" ySplit="%d" topLeftCell="B%d" activePane="bottomRight" state="frozen"/></sheetView></sheetViews>`, headerRow, headerRow+1)
+
+	b.WriteString(`<cols><col min="1" max="1" width="45" customWidth="1"/>`)
+	if columns > 1 {
+		fmt.Fprintf(&b, `<col min="2" max="%d" width="20" customWidth="1"/>`, columns)
+	}
+	b.WriteString(`</cols><sheetData>`)
+
+	n := 0
+	writeRow := func(cells []string, style int) {
+		n++
+		fmt.Fprintf(&b, `<row r="%d">`, n)
+		for i, cell := range cells {
+			if cell == "" && style == styleDefault {
+				continue
+			}
+			fmt.Fprintf(&b, `<c r="%s%d" s="%d" t="inlineStr"><is><t xml:space="preserve">%s</t></is></c>`, column(i), n, style, escape(cell))
+		}
+		b.WriteString(`</row>`)
+	}
+
+	for _, note := range t.Notes {
+		writeRow([]string{note}, styleNote)
+	}
+
+	if len(t.Notes) > 0 {
+		writeRow(nil, styleDefault)
+	}
+
+	for _, row := range t.Rows {
+		writeRow(row.Cells, rowStyles[row.Kind])
+	}
+
+	b.WriteString(`</sheetData></worksheet>`)
+
+	return b.String()
+}
+
+// column returns the letters of the column at index i, e.g. "A", "Z", "AA".
+func column(i int) string {
+	var s string
+	for i++; i > 0; i = (i - 1) / 26 {
+		s = string(rune('A'+(i-1)%26)) + s
+	}
+	return s
+}
+
+func escape(s string) string {
+	var b strings.Builder
+	xml.EscapeText(&b, []byte(s))
+	return b.String()
+}
+
+// sheetName trims title to the 31 characters Excel allows and drops the
+// characters it forbids in sheet names.
+func sheetName(title string) string {
+	name := strings.Map(func(r rune) rune {
+		if strings.ContainsRune(`[]:*?/\`, r) {
+			return -1
+		}
+		return r
+	}, title)
+
+	if name == "" {
+		name = "Sheet1"
+	}
+
+	if r := []rune(name); len(r) > 31 {
+		name = string(r[:31])
+	}
+
+	return name
+}
+
+const xlsxContentTypes = xml.Header + `<Types xmlns="http://schemas.openxmlformats.org/package/2006/content-types">` +
+	`<Default Extension="rels" ContentType="application/vnd.openxmlformats-package.relationships+xml"/>` +
+	`<Default Extension="xml" ContentType="application/xml"/>` +
+	`<Override PartName="/xl/workbook.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.sheet.main+xml"/>` +
+	`<Override PartName="/xl/worksheets/sheet1.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.worksheet+xml"/>` +
+	`<Override PartName="/xl/styles.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.styles+xml"/>` +
+	`</Types>`
+
+const xlsxRels = xml.Header + `<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">` +
+	`<Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/officeDocument" Target="xl/workbook.xml"/>` +
+	`</Relationships>`
+
+const xlsxWorkbook = xml.Header + `<workbook xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main" xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships">` +
+	`<sheets><sheet name="%s" sheetId="1" r:id="rId1"/></sheets>` +
+	`</workbook>`
+
+const xlsxWorkbookRels = xml.Header + `<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">` +
+	`<Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/worksheet" Target="worksheets/sheet1.xml"/>` +
+	`<Relationship Id="rId2" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/styles" Target="styles.xml"/>` +
+	`</Relationships>`
+
+// xlsxStyles defines the cell styles in the order of the style constants:
+// default, bold header, bold section title on grey, italic note.
+const xlsxStyles = xml.Header + `<styleSheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main">` +
+	`<fonts count="3"><font><sz val="11"/><name val="Calibri"/></font><font><b/><sz val="11"/><name val="Calibri"/></font><font><i/><sz val="11"/><name val="Calibri"/></font></fonts>` +
+	`<fills count="3"><fill><patternFill patternType="none"/></fill><fill><patternFill patternType="gray125"/></fill><fill><patternFill patternType="solid"><fgColor rgb="FFD9D9D9"/></patternFill></fill></fills>` +
+	`<borders count="1"><border><left/><right/><top/><bottom/><diagonal/></border></borders>` +
+	`<cellStyleXfs count="1"><xf numFmtId="0" fontId="0" fillId="0" borderId="0"/></cellStyleXfs>` +
+	`<cellXfs count="4">` +
+	`<xf numFmtId="0" fontId="0" fillId="0" borderId="0" xfId="0"/>` +
+	`<xf numFmtId="0" fontId="1" fillId="0" borderId="0" xfId="0" applyFont="1"/>` +
+	`<xf numFmtId="0" fontId="1" fillId="2" borderId="0" xfId="0" applyFont="1" applyFill="1"/>` +
+	`<xf numFmtId="0" fontId="2" fillId="0" borderId="0" xfId="0" applyFont="1"/>` +
+	`</cellXfs>` +
+	`</styleSheet>`
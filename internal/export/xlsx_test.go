@@ -0,0 +1,161 @@
+package export
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/xml"
+	"io"
+	"reflect"
+	"testing"
+)
+
+type xlsxSheet struct {
+	Pane struct {
+		YSplit      int    `xml:"ySplit,attr"`
+		TopLeftCell string `xml:"topLeftCell,attr"`
+	} `xml:"sheetViews>sheetView>pane"`
+	Rows []struct {
+		R     int `xml:"r,attr"`
+		Cells []struct {
+			R     string `xml:"r,attr"`
+			Style int    `xml:"s,attr"`
+			Text  string `xml:"is>t"`
+		} `xml:"c"`
+	} `xml:"sheetData>row"`
+}
+
+// readXLSX returns the parts of the workbook written for table.
+func readXLSX(t *testing.T, table *Table) map[string][]byte {
+	t.Helper()
+
+	var buf bytes.Buffer
+	if err := WriteXLSX(&buf, table); err != nil {
+		t.Fatal(err)
+	}
+
+	zr, err := zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	parts := map[string][]byte{}
+
+	for _, f := range zr.File {
+		rc, err := f.Open()
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		data, err := io.ReadAll(rc)
+		rc.Close()
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		var v any
+		if err := xml.Unmarshal(data, &v); err != nil {
+			t.Fatalf("%s isn't XML: %v", f.Name, err)
+		}
+
+		parts[f.Name] = data
+	}
+
+	return parts
+}
+
+func TestWriteXLSX(t *testing.T) {
+	table, _ := sampleTable(t)
+	table.Rows = append(table.Rows, Row{RowData, []string{"<b>&</b>", "", "x"}})
+
+	parts := readXLSX(t, table)
+
+	for _, name := range []string{"[Content_Types].xml", "_rels/.rels", "xl/workbook.xml", "xl/_rels/workbook.xml.rels", "xl/styles.xml", "xl/worksheets/sheet1.xml"} {
+		if parts[name] == nil {
+			t.Errorf("the workbook has no %s", name)
+		}
+	}
+
+	var workbook struct {
+		Sheets []struct {
+			Name string `xml:"name,attr"`
+		} `xml:"sheets>sheet"`
+	}
+	if err := xml.Unmarshal(parts["xl/workbook.xml"], &workbook); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(workbook.Sheets) != 1 || workbook.Sheets[0].Name != "AAM (IR all-aspect)" {
+		t.Errorf("sheets %+v, want one named after the title", workbook.Sheets)
+	}
+
+	var sheet xlsxSheet
+	if err := xml.Unmarshal(parts["xl/worksheets/sheet1.xml"], &sheet); err != nil {
+		t.Fatal(err)
+	}
+
+	// Two notes and an empty row come before the header row, which is frozen.
+	if sheet.Pane.YSplit != 4 || sheet.Pane.TopLeftCell != "B5" {
+		t.Errorf("pane %+v, want the first four rows frozen", sheet.Pane)
+	}
+
+	type cell struct {
+		R     string
+		Style int
+		Text  string
+	}
+
+	var got [][]cell
+	for i, row := range sheet.Rows {
+		if row.R != i+1 {
+			t.Errorf("row %d is numbered %d", i+1, row.R)
+		}
+
+		cells := []cell{}
+		for _, c := range row.Cells {
+			cells = append(cells, cell(c))
+		}
+		got = append(got, cells)
+	}
+
+	want := [][]cell{
+		{{"A1", styleNote, table.Notes[0]}},
+		{{"A2", styleNote, table.Notes[1]}},
+		{},
+		{{"A4", styleHeader, "Name"}, {"B4", styleHeader, "R-73"}, {"C4", styleHeader, "AIM-9L"}},
+		{{"A5", styleSection, table.Rows[1].Cells[0]}},
+		{{"A6", styleDefault, table.Rows[2].Cells[0]}, {"B6", styleDefault, "105"}, {"C6", styleDefault, "85.5"}},
+		{{"A7", styleDefault, table.Rows[3].Cells[0]}, {"B7", styleDefault, "2.9"}},
+		{{"A8", styleSection, table.Rows[4].Cells[0]}},
+		{{"A9", styleDefault, table.Rows[5].Cells[0]}, {"B9", styleDefault, "±60"}},
+		{{"A10", styleDefault, "<b>&</b>"}, {"C10", styleDefault, "x"}},
+	}
+
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("sheet rows\n%+v\nwant\n%+v", got, want)
+	}
+}
+
+func TestColumn(t *testing.T) {
+	for i, want := range map[int]string{0: "A", 1: "B", 25: "Z", 26: "AA", 27: "AB", 51: "AZ", 52: "BA", 701: "ZZ", 702: "AAA"} {
+		if got := column(i); got != want {
+			t.Errorf("column(%d) = %s, want %s", i, got, want)
+		}
+	}
+}
+
+func TestSheetName(t *testing.T) {
+	tests := map[string]string{
+		"AAM (IR all-aspect)":                  "AAM (IR all-aspect)",
+		"a/b\\c[d]:e*f?":                       "abcdef",
+		"":                                     "Sheet1",
+		"[]":                                   "Sheet1",
+		"Weapons compared across 3 categories": "Weapons compared across 3 categ",
+		"ÄÖÜ ÄÖÜ ÄÖÜ ÄÖÜ ÄÖÜ ÄÖÜ ÄÖÜ ÄÖÜ": "ÄÖÜ ÄÖÜ ÄÖÜ ÄÖÜ ÄÖÜ ÄÖÜ ÄÖÜ ÄÖÜ",
+	}
+
+	for title, want := range tests {
+		if got := sheetName(title); got != want {
+			t.Errorf("sheetName(%q) = %q, want %q", title, got, want)
+		}
+	}
+}
@@ -0,0 +1,132 @@
+// Package filter parses weapon filter expressions such as
+//
+//	category in (ir-all-aspect, ir-heli) and guidanceProp.gimbalLimit >= 40 and guidanceProp.IRCCM = Yes
+//
+// into a backend-neutral syntax tree. Stores translate the tree into their own
+// queries; Eval evaluates it against a single weapon.
+package filter
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/zeze322/wt-guided-weaponry/models"
+)
+
+// Node is a node of a filter expression.
+type Node interface {
+	String() string
+}
+
+type Op string
+
+const (
+	OpEq Op = "="
+	OpNe Op = "!="
+	OpLt Op = "<"
+	OpLe Op = "<="
+	OpGt Op = ">"
+	OpGe Op = ">="
+)
+
+// And matches weapons matched by every one of its operands.
+type And struct {
+	Operands []Node
+}
+
+// Or matches weapons matched by any of its operands.
+type Or struct {
+	Operands []Node
+}
+
+// Not matches weapons its operand doesn't match.
+type Not struct {
+	Operand Node
+}
+
+// Compare compares a field with a literal. Comparisons never match weapons
+// that lack the field, not even with OpNe.
+type Compare struct {
+	Field Field
+	Op    Op
+	Value Literal
+}
+
+// In matches weapons whose field equals any of the values.
+type In struct {
+	Field  Field
+	Values []Literal
+}
+
+// Exists matches weapons that have the field.
+type Exists struct {
+	Field Field
+}
+
+// Field is a field a filter refers to: "name", "category" or a parameter.
+type Field struct {
+	Key string
+
+	// Param is the parameter the key refers to, nil for name and category.
+	Param *models.Field
+}
+
+// Literal is a value in a filter expression.
+type Literal struct {
+	// Raw is the literal as written, without quotes.
+	Raw string
+
+	// Value is Raw parsed like a parameter value.
+	Value *models.Value
+}
+
+func (n And) String() string { return join(n.Operands, " and ") }
+func (n Or) String() string  { return join(n.Operands, " or ") }
+func (n Not) String() string { return "not " + wrap(n.Operand) }
+
+func (n Compare) String() string {
+	return fmt.Sprintf("%s %s %s", n.Field.Key, n.Op, n.Value)
+}
+
+func (n In) String() string {
+	values := make([]string, len(n.Values))
+	for i, v := range n.Values {
+		values[i] = v.String()
+	}
+
+	return fmt.Sprintf("%s in (%s)", n.Field.Key, strings.Join(values, ", "))
+}
+
+func (n Exists) String() string { return n.Field.Key + " exists" }
+
+// String quotes literals the lexer wouldn't read back as a single word.
+// Strings have no escapes, so the quote is one the literal doesn't hold;
+// Parse never produces a literal with both.
+func (l Literal) String() string {
+	if l.Raw != "" && !strings.ContainsFunc(l.Raw, endsWord) {
+		return l.Raw
+	}
+
+	if strings.ContainsRune(l.Raw, '"') {
+		return "'" + l.Raw + "'"
+	}
+
+	return `"` + l.Raw + `"`
+}
+
+func join(nodes []Node, sep string) string {
+	parts := make([]string, len(nodes))
+	for i, n := range nodes {
+		parts[i] = wrap(n)
+	}
+	return strings.Join(parts, sep)
+}
+
+// wrap parenthesizes compound nodes so String round-trips through Parse.
+func wrap(n Node) string {
+	switch n.(type) {
+	case And, Or:
+		return "(" + n.String() + ")"
+	}
+	return n.String()
+}
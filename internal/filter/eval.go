@@ -0,0 +1,98 @@
+package filter
+
+import (
+	"strings"
+
+	"github.com/zeze322/wt-guided-weaponry/models"
+)
+
+// Eval reports whether params matches the expression. It defines the
+// semantics the store translations must reproduce.
+func Eval(n Node, params *models.Params) bool {
+	switch n := n.(type) {
+	case And:
+		for _, operand := range n.Operands {
+			if !Eval(operand, params) {
+				return false
+			}
+		}
+		return true
+	case Or:
+		for _, operand := range n.Operands {
+			if Eval(operand, params) {
+				return true
+			}
+		}
+		return false
+	case Not:
+		return !Eval(n.Operand, params)
+	case Exists:
+		return n.Field.Param == nil || n.Field.Param.Value(params) != nil
+	case In:
+		for _, value := range n.Values {
+			if Eval(Compare{Field: n.Field, Op: OpEq, Value: value}, params) {
+				return true
+			}
+		}
+		return false
+	case Compare:
+		return compare(n, params)
+	default:
+		return false
+	}
+}
+
+func compare(n Compare, params *models.Params) bool {
+	if n.Field.Param == nil {
+		actual := params.Name
+		if n.Field.Key == "category" {
+			actual = params.Category
+		}
+
+		if n.Op == OpNe {
+			return actual != n.Value.Raw
+		}
+		return actual == n.Value.Raw
+	}
+
+	v := n.Field.Param.Value(params)
+	if v == nil {
+		return false
+	}
+
+	var equal bool
+
+	switch n.Field.Param.Kind {
+	case models.KindNumber:
+		// Text such as "N/A" has no magnitude to compare.
+		magnitude, ok := v.Float()
+		return ok && compareNumbers(magnitude, n.Op, n.Value.Value.Magnitude)
+	case models.KindBool:
+		equal = v.Kind == models.KindBool && v.Magnitude == n.Value.Value.Magnitude
+	default:
+		equal = strings.EqualFold(v.Text, n.Value.Raw)
+	}
+
+	if n.Op == OpNe {
+		return !equal
+	}
+	return equal
+}
+
+func compareNumbers(a float64, op Op, b float64) bool {
+	switch op {
+	case OpEq:
+		return a == b
+	case OpNe:
+		return a != b
+	case OpLt:
+		return a < b
+	case OpLe:
+		return a <= b
+	case OpGt:
+		return a > b
+	case OpGe:
+		return a >= b
+	}
+	return false
+}
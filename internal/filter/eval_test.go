@@ -0,0 +1,45 @@
+package filter
+
+import (
+	"testing"
+
+	"github.com/zeze322/wt-guided-weaponry/models"
+)
+
+func TestEval(t *testing.T) {
+	weapon := func(mass string) *models.Params {
+		params := &models.Params{Name: "R-73", Category: models.CategoryIRAllAspect}
+		params.Mass = models.ParseValue(mass)
+		return params
+	}
+
+	tests := []struct {
+		expr   string
+		weapon *models.Params
+		want   bool
+	}{
+		{"physicalProp.mass < 110", weapon("105 kg"), true},
+		{"physicalProp.mass < 110", weapon("115 kg"), false},
+		{"physicalProp.mass < 110", weapon("100-120 kg"), false},
+		{"physicalProp.mass < 110", weapon("100/120"), true},
+		{"physicalProp.mass < 110", weapon(""), false},
+		{"physicalProp.mass < 110", weapon("N/A"), false},
+		{"physicalProp.mass != 110", weapon("N/A"), false},
+		{"physicalProp.mass = 0", weapon("N/A"), false},
+		{"physicalProp.mass exists", weapon("N/A"), true},
+		{"not physicalProp.mass < 110", weapon("N/A"), true},
+		{"name = r-73 and category in (ir-heli, ir-all-aspect)", weapon(""), false},
+		{"name = R-73 and category in (ir-heli, ir-all-aspect)", weapon(""), true},
+	}
+
+	for _, tt := range tests {
+		node, err := Parse(tt.expr)
+		if err != nil {
+			t.Fatalf("Parse(%q): %v", tt.expr, err)
+		}
+
+		if got := Eval(node, tt.weapon); got != tt.want {
+			t.Errorf("Eval(%q) with mass %q = %t, want %t", tt.expr, tt.weapon.Mass, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,359 @@
+package filter
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/zeze322/wt-guided-weaponry/models"
+)
+
+// SyntaxError describes why an expression couldn't be parsed.
+type SyntaxError struct {
+	Pos int
+	Msg string
+}
+
+func (e *SyntaxError) Error() string {
+	return fmt.Sprintf("filter: %s at position %d", e.Msg, e.Pos+1)
+}
+
+type tokenKind int
+
+const (
+	tokenEOF tokenKind = iota
+	tokenWord
+	tokenString
+	tokenOp
+	tokenLParen
+	tokenRParen
+	tokenComma
+)
+
+type token struct {
+	kind tokenKind
+	text string
+	pos  int
+}
+
+// opChars start a comparison operator and end a word.
+const opChars = "=!<>≤≥"
+
+// endsWord reports whether r ends a word, or can't be part of one.
+func endsWord(r rune) bool {
+	return unicode.IsSpace(r) || strings.ContainsRune("(),\"'"+opChars, r)
+}
+
+var opAliases = map[string]Op{
+	"=":  OpEq,
+	"==": OpEq,
+	"!=": OpNe,
+	"<>": OpNe,
+	"<":  OpLt,
+	"<=": OpLe,
+	"≤":  OpLe,
+	">":  OpGt,
+	">=": OpGe,
+	"≥":  OpGe,
+}
+
+func lex(s string) ([]token, error) {
+	var tokens []token
+
+	for i := 0; i < len(s); {
+		r, size := utf8.DecodeRuneInString(s[i:])
+
+		switch {
+		case unicode.IsSpace(r):
+			i += size
+		case r == '(':
+			tokens = append(tokens, token{kind: tokenLParen, text: "(", pos: i})
+			i += size
+		case r == ')':
+			tokens = append(tokens, token{kind: tokenRParen, text: ")", pos: i})
+			i += size
+		case r == ',':
+			tokens = append(tokens, token{kind: tokenComma, text: ",", pos: i})
+			i += size
+		case r == '"' || r == '\'':
+			end := strings.IndexRune(s[i+size:], r)
+			if end == -1 {
+				return nil, &SyntaxError{Pos: i, Msg: "unterminated string"}
+			}
+
+			tokens = append(tokens, token{kind: tokenString, text: s[i+size : i+size+end], pos: i})
+			i += size + end + size
+		case strings.ContainsRune(opChars, r):
+			start := i
+			for i < len(s) {
+				r, size := utf8.DecodeRuneInString(s[i:])
+				if !strings.ContainsRune(opChars, r) {
+					break
+				}
+				i += size
+			}
+
+			if _, ok := opAliases[s[start:i]]; !ok {
+				return nil, &SyntaxError{Pos: start, Msg: fmt.Sprintf("unknown operator %q", s[start:i])}
+			}
+
+			tokens = append(tokens, token{kind: tokenOp, text: s[start:i], pos: start})
+		default:
+			start := i
+			for i < len(s) {
+				r, size := utf8.DecodeRuneInString(s[i:])
+				if endsWord(r) {
+					break
+				}
+				i += size
+			}
+
+			tokens = append(tokens, token{kind: tokenWord, text: s[start:i], pos: start})
+		}
+	}
+
+	return append(tokens, token{kind: tokenEOF, pos: len(s)}), nil
+}
+
+type parser struct {
+	tokens []token
+	pos    int
+}
+
+// Parse parses a filter expression. Fields are checked against the parameter
+// registry, and operators and values against the kind of the field.
+//
+// The grammar, with keywords matched case-insensitively:
+//
+//	expr       = and { "or" and }
+//	and        = unary { "and" unary }
+//	unary      = "not" unary | "(" expr ")" | condition
+//	condition  = field op value | field "in" "(" value { "," value } ")" | field "exists"
+//	op         = "=" | "!=" | "<" | "<=" | ">" | ">="
+//	value      = word | quoted string
+func Parse(s string) (Node, error) {
+	tokens, err := lex(s)
+	if err != nil {
+		return nil, err
+	}
+
+	p := &parser{tokens: tokens}
+
+	node, err := p.or()
+	if err != nil {
+		return nil, err
+	}
+
+	if t := p.peek(); t.kind != tokenEOF {
+		return nil, &SyntaxError{Pos: t.pos, Msg: fmt.Sprintf("unexpected %q", t.text)}
+	}
+
+	return node, nil
+}
+
+func (p *parser) peek() token {
+	return p.tokens[p.pos]
+}
+
+func (p *parser) next() token {
+	t := p.tokens[p.pos]
+	if t.kind != tokenEOF {
+		p.pos++
+	}
+	return t
+}
+
+func (p *parser) keyword(word string) bool {
+	t := p.peek()
+	if t.kind == tokenWord && strings.EqualFold(t.text, word) {
+		p.pos++
+		return true
+	}
+	return false
+}
+
+func (p *parser) or() (Node, error) {
+	node, err := p.and()
+	if err != nil {
+		return nil, err
+	}
+
+	operands := []Node{node}
+
+	for p.keyword("or") {
+		node, err := p.and()
+		if err != nil {
+			return nil, err
+		}
+		operands = append(operands, node)
+	}
+
+	if len(operands) == 1 {
+		return operands[0], nil
+	}
+
+	return Or{Operands: operands}, nil
+}
+
+func (p *parser) and() (Node, error) {
+	node, err := p.unary()
+	if err != nil {
+		return nil, err
+	}
+
+	operands := []Node{node}
+
+	for p.keyword("and") {
+		node, err := p.unary()
+		if err != nil {
+			return nil, err
+		}
+		operands = append(operands, node)
+	}
+
+	if len(operands) == 1 {
+		return operands[0], nil
+	}
+
+	return And{Operands: operands}, nil
+}
+
+func (p *parser) unary() (Node, error) {
+	if p.keyword("not") {
+		node, err := p.unary()
+		if err != nil {
+			return nil, err
+		}
+		return Not{Operand: node}, nil
+	}
+
+	if p.peek().kind == tokenLParen {
+		p.next()
+
+		node, err := p.or()
+		if err != nil {
+			return nil, err
+		}
+
+		if t := p.next(); t.kind != tokenRParen {
+			return nil, &SyntaxError{Pos: t.pos, Msg: "expected \")\""}
+		}
+
+		return node, nil
+	}
+
+	return p.condition()
+}
+
+func (p *parser) condition() (Node, error) {
+	t := p.next()
+	if t.kind != tokenWord {
+		return nil, &SyntaxError{Pos: t.pos, Msg: "expected a field"}
+	}
+
+	field, err := lookupField(t)
+	if err != nil {
+		return nil, err
+	}
+
+	switch {
+	case p.keyword("exists"):
+		return Exists{Field: field}, nil
+	case p.keyword("in"):
+		return p.in(field, t.pos)
+	}
+
+	opToken := p.next()
+	if opToken.kind != tokenOp {
+		return nil, &SyntaxError{Pos: opToken.pos, Msg: fmt.Sprintf("expected an operator after %s", field.Key)}
+	}
+
+	op := opAliases[opToken.text]
+
+	if op != OpEq && op != OpNe && !field.numeric() {
+		return nil, &SyntaxError{Pos: opToken.pos, Msg: fmt.Sprintf("%s isn't numeric, only = and != apply", field.Key)}
+	}
+
+	value, err := p.literal(field)
+	if err != nil {
+		return nil, err
+	}
+
+	return Compare{Field: field, Op: op, Value: value}, nil
+}
+
+func (p *parser) in(field Field, pos int) (Node, error) {
+	if t := p.next(); t.kind != tokenLParen {
+		return nil, &SyntaxError{Pos: t.pos, Msg: "expected \"(\" after in"}
+	}
+
+	var values []Literal
+
+	for {
+		value, err := p.literal(field)
+		if err != nil {
+			return nil, err
+		}
+
+		values = append(values, value)
+
+		t := p.next()
+		if t.kind == tokenRParen {
+			break
+		}
+
+		if t.kind != tokenComma {
+			return nil, &SyntaxError{Pos: t.pos, Msg: "expected \",\" or \")\""}
+		}
+	}
+
+	return In{Field: field, Values: values}, nil
+}
+
+func (p *parser) literal(field Field) (Literal, error) {
+	t := p.next()
+	if t.kind != tokenWord && t.kind != tokenString {
+		return Literal{}, &SyntaxError{Pos: t.pos, Msg: "expected a value"}
+	}
+
+	lit := Literal{Raw: t.text, Value: models.ParseValue(t.text)}
+
+	if field.Param == nil {
+		return lit, nil
+	}
+
+	switch field.Param.Kind {
+	case models.KindNumber:
+		if lit.Value == nil || lit.Value.Kind != models.KindNumber {
+			return Literal{}, &SyntaxError{Pos: t.pos, Msg: fmt.Sprintf("%s expects a number, got %q", field.Key, t.text)}
+		}
+
+		if lit.Value.Unit != "" && lit.Value.Unit != field.Param.Unit {
+			return Literal{}, &SyntaxError{Pos: t.pos, Msg: fmt.Sprintf("%s is in %s, got %s", field.Key, field.Param.Unit, lit.Value.Unit)}
+		}
+	case models.KindBool:
+		if lit.Value == nil || lit.Value.Kind != models.KindBool {
+			return Literal{}, &SyntaxError{Pos: t.pos, Msg: fmt.Sprintf("%s expects Yes or No, got %q", field.Key, t.text)}
+		}
+	}
+
+	return lit, nil
+}
+
+func lookupField(t token) (Field, error) {
+	if t.text == "name" || t.text == "category" {
+		return Field{Key: t.text}, nil
+	}
+
+	param, ok := models.FieldByKey(t.text)
+	if !ok {
+		return Field{}, &SyntaxError{Pos: t.pos, Msg: fmt.Sprintf("unknown field %q", t.text)}
+	}
+
+	return Field{Key: t.text, Param: &param}, nil
+}
+
+func (f Field) numeric() bool {
+	return f.Param != nil && f.Param.Kind == models.KindNumber
+}
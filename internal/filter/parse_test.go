@@ -0,0 +1,159 @@
+package filter
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestLex(t *testing.T) {
+	tests := []struct {
+		expr string
+		want []token
+	}{
+		{"", []token{{kind: tokenEOF}}},
+		{"mass>=10", []token{
+			{kind: tokenWord, text: "mass"},
+			{kind: tokenOp, text: ">=", pos: 4},
+			{kind: tokenWord, text: "10", pos: 6},
+			{kind: tokenEOF, pos: 8},
+		}},
+		{`name in ("AIM-9L", 'it''s')`, []token{
+			{kind: tokenWord, text: "name"},
+			{kind: tokenWord, text: "in", pos: 5},
+			{kind: tokenLParen, text: "(", pos: 8},
+			{kind: tokenString, text: "AIM-9L", pos: 9},
+			{kind: tokenComma, text: ",", pos: 17},
+			{kind: tokenString, text: "it", pos: 19},
+			{kind: tokenString, text: "s", pos: 23},
+			{kind: tokenRParen, text: ")", pos: 26},
+			{kind: tokenEOF, pos: 27},
+		}},
+		{"a ≤ 1,5 m", []token{
+			{kind: tokenWord, text: "a"},
+			{kind: tokenOp, text: "≤", pos: 2},
+			{kind: tokenWord, text: "1", pos: 6},
+			{kind: tokenComma, text: ",", pos: 7},
+			{kind: tokenWord, text: "5", pos: 8},
+			{kind: tokenWord, text: "m", pos: 10},
+			{kind: tokenEOF, pos: 11},
+		}},
+	}
+
+	for _, tt := range tests {
+		got, err := lex(tt.expr)
+		if err != nil {
+			t.Errorf("lex(%q): %v", tt.expr, err)
+			continue
+		}
+
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("lex(%q) = %+v, want %+v", tt.expr, got, tt.want)
+		}
+	}
+}
+
+func TestLexErrors(t *testing.T) {
+	tests := []struct {
+		expr string
+		pos  int
+	}{
+		{`name = "AIM-9L`, 7},
+		{"name = 'AIM", 7},
+		{"mass =< 10", 5},
+		{"mass !! 10", 5},
+	}
+
+	for _, tt := range tests {
+		_, err := lex(tt.expr)
+
+		var syntaxErr *SyntaxError
+		if !errors.As(err, &syntaxErr) || syntaxErr.Pos != tt.pos {
+			t.Errorf("lex(%q) returned %v, want a syntax error at %d", tt.expr, err, tt.pos)
+		}
+	}
+}
+
+// TestParse checks the tree of each expression through its String, which
+// must also parse back to the same tree.
+func TestParse(t *testing.T) {
+	tests := []struct {
+		expr string
+		want string
+	}{
+		{"physicalProp.mass < 10", "physicalProp.mass < 10"},
+		{"physicalProp.mass<=10kg", "physicalProp.mass <= 10kg"},
+		{"physicalProp.mass ≥ 10", "physicalProp.mass >= 10"},
+		{"physicalProp.mass <> 10", "physicalProp.mass != 10"},
+		{"physicalProp.mass == 10", "physicalProp.mass = 10"},
+		{"name = 'R-73' AND category = aam-arh", "name = R-73 and category = aam-arh"},
+		{"name = x or name = y and name = z", "name = x or (name = y and name = z)"},
+		{"(name = x or name = y) and name = z", "(name = x or name = y) and name = z"},
+		{"not not guidanceProp.IRCCM exists", "not not guidanceProp.IRCCM exists"},
+		{"not (name = x or name = y)", "not (name = x or name = y)"},
+		{"guidanceProp.IRCCM = yes", "guidanceProp.IRCCM = yes"},
+		{`category in (ir-heli , "aam-arh")`, "category in (ir-heli, aam-arh)"},
+		{`name = "AIM-9L (late)"`, `name = "AIM-9L (late)"`},
+		{`name = ""`, `name = ""`},
+		{`name = "it's"`, `name = "it's"`},
+		{`name = 'say "hi"'`, `name = 'say "hi"'`},
+		{`name = "a=b"`, `name = "a=b"`},
+		{"name = \"tab\there\"", "name = \"tab\there\""},
+		{`name = "x\y"`, `name = x\y`},
+		{`name = and`, `name = and`},
+	}
+
+	for _, tt := range tests {
+		node, err := Parse(tt.expr)
+		if err != nil {
+			t.Errorf("Parse(%q): %v", tt.expr, err)
+			continue
+		}
+
+		got := node.String()
+		if got != tt.want {
+			t.Errorf("Parse(%q) = %s, want %s", tt.expr, got, tt.want)
+		}
+
+		again, err := Parse(got)
+		if err != nil {
+			t.Errorf("Parse(%q), the String of %q: %v", got, tt.expr, err)
+			continue
+		}
+
+		if !reflect.DeepEqual(again, node) {
+			t.Errorf("Parse(%q) = %#v, want %#v", got, again, node)
+		}
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	tests := []struct {
+		expr string
+		pos  int
+	}{
+		{"", 0},
+		{"mass < 10", 0},
+		{"physicalProp.mass", 17},
+		{"physicalProp.mass < ", 20},
+		{"physicalProp.mass < heavy", 20},
+		{"physicalProp.mass < 10 m", 23},
+		{"physicalProp.mass < 10 s", 23},
+		{"guidanceProp.IRCCM = maybe", 21},
+		{"name < R-73", 5},
+		{"name in R-73", 8},
+		{"name in (R-73 R-60)", 14},
+		{"(name = R-73", 12},
+		{"name = R-73)", 11},
+		{"name = R-73 and", 15},
+	}
+
+	for _, tt := range tests {
+		_, err := Parse(tt.expr)
+
+		var syntaxErr *SyntaxError
+		if !errors.As(err, &syntaxErr) || syntaxErr.Pos != tt.pos {
+			t.Errorf("Parse(%q) returned %v, want a syntax error at %d", tt.expr, err, tt.pos)
+		}
+	}
+}
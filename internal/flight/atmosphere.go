@@ -0,0 +1,71 @@
+package flight
+
+import "math"
+
+const (
+	// g0 is the standard gravity, in m/s².
+	g0 = 9.80665
+
+	// gasConstant is the specific gas constant of dry air, in J/(kg·K).
+	gasConstant = 287.053
+
+	// heatRatio is the ratio of specific heats of air.
+	heatRatio = 1.4
+
+	// tropopause is the altitude above which the temperature stops
+	// falling, in m.
+	tropopause = 11000
+)
+
+// Air is the state of the atmosphere at an altitude.
+type Air struct {
+	// Density in kg/m³.
+	Density float64
+
+	// SoundSpeed is the speed of sound in m/s.
+	SoundSpeed float64
+}
+
+// Atmosphere returns the air at altitude, in m, in the International
+// Standard Atmosphere: a troposphere cooling by 6.5 K per km up to 11 km
+// and an isothermal layer above it. Negative altitudes are sea level.
+func Atmosphere(altitude float64) Air {
+	altitude = max(altitude, 0)
+
+	var temperature, pressure float64
+
+	if altitude <= tropopause {
+		temperature = 288.15 - 0.0065*altitude
+		pressure = 101325 * math.Pow(temperature/288.15, g0/(gasConstant*0.0065))
+	} else {
+		temperature = 216.65
+		pressure = 22632.06 * math.Exp(-g0*(altitude-tropopause)/(gasConstant*temperature))
+	}
+
+	return Air{
+		Density:    pressure / (gasConstant * temperature),
+		SoundSpeed: math.Sqrt(heatRatio * gasConstant * temperature),
+	}
+}
+
+// dragCoefficient is a rough zero-lift drag coefficient of a slender body
+// of the given fineness, its length over its calibre, at mach. Wave drag
+// rises through the transonic region and falls off as the weapon goes
+// faster; skin friction grows with the wetted area.
+func dragCoefficient(mach, fineness float64) float64 {
+	var wave float64
+
+	switch {
+	case mach < 0.8:
+		wave = 0.12
+	case mach < 1.2:
+		wave = 0.12 + (mach-0.8)/0.4*0.33
+	default:
+		wave = 0.45 * math.Sqrt(1.2/mach)
+	}
+
+	// The wetted area is 4·fineness times the cross-section.
+	friction := 0.0025 * 4 * fineness
+
+	return wave + friction
+}
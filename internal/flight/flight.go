@@ -0,0 +1,338 @@
+// Package flight integrates the flight of a rocket or missile as a point
+// mass, to estimate the speed and range its engine and physical parameters
+// give instead of copying the figures by hand.
+//
+// The weapon flies in the vertical plane with its thrust along its
+// velocity, slowed by drag and pulled down by gravity. Without gravity it
+// keeps to the line it was launched on, so the model covers both the 1D
+// flights of the in-game figures and 2D ballistic ones.
+package flight
+
+import (
+	"errors"
+	"fmt"
+	"math"
+
+	"github.com/zeze322/wt-guided-weaponry/models"
+)
+
+// Stage is a burn of the motor. Thrust is constant over the burn and the
+// mass falls linearly to EndMass.
+type Stage struct {
+	// Start is when the burn starts after launch, in s.
+	Start float64
+
+	// Force is the thrust in N, BurnTime in s and EndMass in kg.
+	Force    float64
+	BurnTime float64
+	EndMass  float64
+}
+
+// Missile is what the simulation needs of a weapon, in SI units: the
+// calibre is in m, not mm as stored.
+type Missile struct {
+	Name    string
+	Mass    float64
+	Calibre float64
+	Length  float64
+
+	// Stages holds the booster, then the sustainer when there is one.
+	Stages []Stage
+}
+
+// FromParams reads the launch mass, calibre, length and motor stages of a
+// weapon. The mass, calibre and length are required; weapons without a
+// motor, such as guided bombs, get no stage. A stage without an end mass
+// burns no propellant.
+func FromParams(params *models.Params) (*Missile, error) {
+	m := &Missile{Name: params.Name}
+
+	var missing []string
+
+	read := func(key string, v *models.Value, unit string, dst *float64) {
+		n, ok := number(v, unit)
+		if !ok {
+			missing = append(missing, key)
+			return
+		}
+		*dst = n
+	}
+
+	read("physicalProp.mass", params.PhysicalProp.Mass, "kg", &m.Mass)
+	read("physicalProp.calibre", params.PhysicalProp.Calibre, "mm", &m.Calibre)
+	read("physicalProp.length", params.PhysicalProp.Length, "m", &m.Length)
+
+	if len(missing) > 0 {
+		return nil, fmt.Errorf("%s: missing or not a number in the stored unit: %v", params.Name, missing)
+	}
+
+	m.Calibre /= 1000
+
+	engine := params.EngineProp
+	mass := m.Mass
+
+	var start float64
+	if delay, ok := number(engine.BoosterStartDelay, "s"); ok {
+		start = delay
+	}
+
+	stages := []struct {
+		force, burnTime, endMass *models.Value
+	}{
+		{engine.ForceExertedByBooster, engine.BurnTimeOfBooster, params.PhysicalProp.MassAtEndOfBoosterBurn},
+		{engine.ForceExertedBySustainer, engine.BurnTimeOfSustainer, params.PhysicalProp.MassAtEndOfSustainerBurn},
+	}
+
+	for _, s := range stages {
+		force, okF := number(s.force, "N")
+		burnTime, okT := number(s.burnTime, "s")
+
+		if !okF || !okT || force <= 0 || burnTime <= 0 {
+			break
+		}
+
+		endMass, ok := number(s.endMass, "kg")
+		if !ok || endMass <= 0 || endMass > mass {
+			endMass = mass
+		}
+
+		m.Stages = append(m.Stages, Stage{Start: start, Force: force, BurnTime: burnTime, EndMass: endMass})
+
+		start += burnTime
+		mass = endMass
+	}
+
+	return m, nil
+}
+
+// number returns the magnitude of v when it's written in unit or without
+// a unit.
+func number(v *models.Value, unit string) (float64, bool) {
+	n, ok := v.Float()
+	if !ok || v.Unit != "" && v.Unit != unit {
+		return 0, false
+	}
+	return n, true
+}
+
+// Config sets up a flight. The zero value launches level from sea level at
+// rest, with drag and gravity, and flies for a minute or until the weapon
+// hits the ground, which it does at once unless it's pitched up or
+// launched higher.
+type Config struct {
+	// Speed is the launch speed in m/s, Altitude the launch altitude in
+	// m and Pitch the launch angle above the horizon in degrees.
+	Speed    float64
+	Altitude float64
+	Pitch    float64
+
+	// Duration is how long the flight lasts at most, 60 s by default.
+	Duration float64
+
+	// Step is the integration step, 10 ms by default, and Interval the
+	// time between samples, 100 ms by default.
+	Step     float64
+	Interval float64
+
+	NoDrag    bool
+	NoGravity bool
+}
+
+// Sample is the state of the weapon at a time of the flight.
+type Sample struct {
+	Time     float64 `json:"time"`
+	Speed    float64 `json:"speed"`
+	Mach     float64 `json:"mach"`
+	Altitude float64 `json:"altitude"`
+
+	// Range is the distance flown over the ground.
+	Range float64 `json:"range"`
+}
+
+// Result is a simulated flight.
+type Result struct {
+	Samples []Sample `json:"samples"`
+
+	MaxSpeed float64 `json:"maxSpeed"`
+
+	// DeltaSpeeds holds the speed gained during each stage's burn, which
+	// matches its ΔV without drag and gravity.
+	DeltaSpeeds []float64 `json:"deltaSpeeds"`
+
+	// Impact reports whether the flight ended on the ground.
+	Impact bool `json:"impact"`
+}
+
+// Last returns the state at the end of the flight.
+func (r *Result) Last() Sample {
+	return r.Samples[len(r.Samples)-1]
+}
+
+// ErrNoMass is returned for weapons without a positive mass, which can't
+// be accelerated.
+var ErrNoMass = errors.New("the mass must be positive")
+
+// state is the position and velocity of the weapon in the vertical plane.
+type state struct {
+	x, y, vx, vy float64
+}
+
+func (s state) add(d state, k float64) state {
+	return state{s.x + d.x*k, s.y + d.y*k, s.vx + d.vx*k, s.vy + d.vy*k}
+}
+
+// Simulate flies m with the fourth-order Runge-Kutta method.
+func Simulate(m *Missile, cfg Config) (*Result, error) {
+	if m.Mass <= 0 {
+		return nil, ErrNoMass
+	}
+
+	if !cfg.NoDrag && (m.Calibre <= 0 || m.Length <= 0) {
+		return nil, fmt.Errorf("%s: drag needs a calibre and a length", m.Name)
+	}
+
+	duration := orDefault(cfg.Duration, 60)
+	step := orDefault(cfg.Step, 0.01)
+	interval := orDefault(cfg.Interval, 0.1)
+
+	pitch := cfg.Pitch * math.Pi / 180
+
+	// heading is the direction of the thrust while the weapon is at rest.
+	heading := state{vx: math.Cos(pitch), vy: math.Sin(pitch)}
+
+	s := state{y: cfg.Altitude, vx: cfg.Speed * heading.vx, vy: cfg.Speed * heading.vy}
+
+	deriv := func(t float64, s state, force float64) state {
+		speed := math.Hypot(s.vx, s.vy)
+
+		dx, dy := heading.vx, heading.vy
+		if speed > 0 {
+			dx, dy = s.vx/speed, s.vy/speed
+		}
+
+		mass := m.massAt(t)
+
+		if !cfg.NoDrag {
+			air := Atmosphere(s.y)
+			area := math.Pi * m.Calibre * m.Calibre / 4
+			cd := dragCoefficient(speed/air.SoundSpeed, m.Length/m.Calibre)
+
+			force -= 0.5 * air.Density * speed * speed * cd * area
+		}
+
+		a := state{x: s.vx, y: s.vy, vx: force / mass * dx, vy: force / mass * dy}
+
+		if !cfg.NoGravity {
+			a.vy -= g0
+		}
+
+		return a
+	}
+
+	res := &Result{DeltaSpeeds: make([]float64, len(m.Stages))}
+
+	// Stage boundaries are stepped onto exactly, so the speed at the end
+	// of a burn is sampled without the next stage's thrust.
+	var events []float64
+	for _, stage := range m.Stages {
+		events = append(events, stage.Start, stage.Start+stage.BurnTime)
+	}
+
+	record := func(t float64, s state) {
+		speed := math.Hypot(s.vx, s.vy)
+
+		res.Samples = append(res.Samples, Sample{
+			Time:     t,
+			Speed:    speed,
+			Mach:     speed / Atmosphere(s.y).SoundSpeed,
+			Altitude: s.y,
+			Range:    s.x,
+		})
+
+		res.MaxSpeed = max(res.MaxSpeed, speed)
+	}
+
+	speedAt := map[float64]float64{}
+
+	t, next := 0.0, interval
+	record(t, s)
+	speedAt[0] = math.Hypot(s.vx, s.vy)
+
+	for t < duration {
+		h := min(step, duration-t)
+		for _, e := range events {
+			if e > t+1e-9 && e < t+h {
+				h = e - t
+			}
+		}
+
+		// Steps don't straddle a stage boundary, so the thrust in the
+		// middle of the step holds over all of it.
+		thrust := m.thrustAt(t + h/2)
+
+		k1 := deriv(t, s, thrust)
+		k2 := deriv(t+h/2, s.add(k1, h/2), thrust)
+		k3 := deriv(t+h/2, s.add(k2, h/2), thrust)
+		k4 := deriv(t+h, s.add(k3, h), thrust)
+
+		s = s.add(k1, h/6).add(k2, h/3).add(k3, h/3).add(k4, h/6)
+		t += h
+
+		for _, e := range events {
+			if math.Abs(t-e) < 1e-9 {
+				speedAt[e] = math.Hypot(s.vx, s.vy)
+			}
+		}
+
+		if !cfg.NoGravity && s.y < 0 && s.vy < 0 {
+			res.Impact = true
+			record(t, s)
+			break
+		}
+
+		if t >= next-1e-9 || t >= duration {
+			record(t, s)
+			next += interval
+		}
+	}
+
+	for i, stage := range m.Stages {
+		res.DeltaSpeeds[i] = speedAt[stage.Start+stage.BurnTime] - speedAt[stage.Start]
+	}
+
+	return res, nil
+}
+
+// massAt returns the mass at time t of the flight.
+func (m *Missile) massAt(t float64) float64 {
+	mass := m.Mass
+
+	for _, stage := range m.Stages {
+		switch {
+		case t <= stage.Start:
+			return mass
+		case t < stage.Start+stage.BurnTime:
+			return mass - (mass-stage.EndMass)*(t-stage.Start)/stage.BurnTime
+		}
+		mass = stage.EndMass
+	}
+
+	return mass
+}
+
+// thrustAt returns the thrust at time t of the flight.
+func (m *Missile) thrustAt(t float64) float64 {
+	for _, stage := range m.Stages {
+		if t >= stage.Start && t < stage.Start+stage.BurnTime {
+			return stage.Force
+		}
+	}
+	return 0
+}
+
+func orDefault(v, def float64) float64 {
+	if v <= 0 {
+		return def
+	}
+	return v
+}
@@ -0,0 +1,192 @@
+package flight
+
+import (
+	"math"
+	"testing"
+
+	"github.com/zeze322/wt-guided-weaponry/models"
+)
+
+// tolerance is how far, relative to the expected ΔV, a simulated one may be.
+const tolerance = 1e-4
+
+// TestDeltaSpeeds flies the samples of internal/blkx/testdata in a vacuum
+// without gravity, where a burn adds exactly its ΔV to the speed.
+func TestDeltaSpeeds(t *testing.T) {
+	tests := []struct {
+		missile Missile
+		want    []float64
+	}{
+		{
+			missile: Missile{Name: "9M113", Mass: 25.2, Stages: []Stage{
+				{Force: 3500, BurnTime: 0.6, EndMass: 22.5},
+				{Start: 0.6, Force: 600, BurnTime: 9, EndMass: 19.2},
+			}},
+			want: []float64{88.145, 259.536},
+		},
+		{
+			missile: Missile{Name: "AGM-114B", Mass: 45, Stages: []Stage{
+				{Force: 5800, BurnTime: 2.5, EndMass: 39},
+			}},
+			want: []float64{345.827},
+		},
+		{
+			missile: Missile{Name: "AIM-120A", Mass: 157, Stages: []Stage{
+				{Force: 13000, BurnTime: 7.5, EndMass: 111},
+			}},
+			want: []float64{734.886},
+		},
+		{
+			missile: Missile{Name: "AIM-7F", Mass: 231, Stages: []Stage{
+				{Force: 25600, BurnTime: 4.5, EndMass: 186},
+				{Start: 4.5, Force: 4800, BurnTime: 11, EndMass: 163},
+			}},
+			want: []float64{554.678, 303.018},
+		},
+		{
+			missile: Missile{Name: "AIM-9B", Mass: 75.3, Stages: []Stage{
+				{Force: 17300, BurnTime: 2.2, EndMass: 56.7},
+			}},
+			want: []float64{580.529},
+		},
+		{
+			missile: Missile{Name: "AIM-9L", Mass: 85.5, Stages: []Stage{
+				{Force: 13000, BurnTime: 5.2, EndMass: 58},
+			}},
+			want: []float64{953.955},
+		},
+		{
+			// A delayed start and a stage burning no propellant, which
+			// gains F·t/m.
+			missile: Missile{Name: "delayed", Mass: 100, Stages: []Stage{
+				{Start: 0.35, Force: 1000, BurnTime: 2, EndMass: 100},
+			}},
+			want: []float64{20},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.missile.Name, func(t *testing.T) {
+			res, err := Simulate(&tt.missile, Config{NoDrag: true, NoGravity: true})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if len(res.DeltaSpeeds) != len(tt.want) {
+				t.Fatalf("got %d burns, want %d", len(res.DeltaSpeeds), len(tt.want))
+			}
+
+			var total float64
+			for i, got := range res.DeltaSpeeds {
+				if math.Abs(got-tt.want[i]) > tolerance*tt.want[i] {
+					t.Errorf("stage %d gains %.3f m/s, want %.3f m/s", i+1, got, tt.want[i])
+				}
+				total += tt.want[i]
+			}
+
+			if got := res.Last().Speed; math.Abs(got-total) > tolerance*total {
+				t.Errorf("final speed %.3f m/s, want %.3f m/s", got, total)
+			}
+		})
+	}
+}
+
+func TestFromParams(t *testing.T) {
+	params := &models.Params{
+		Name: "AIM-7F",
+		PhysicalProp: models.PhysicalProp{
+			Mass:                     models.NewNumber(231, "kg"),
+			MassAtEndOfBoosterBurn:   models.NewNumber(186, "kg"),
+			MassAtEndOfSustainerBurn: models.NewNumber(163, "kg"),
+			Calibre:                  models.NewNumber(203, "mm"),
+			Length:                   models.NewNumber(3.66, "m"),
+		},
+		EngineProp: models.EngineProp{
+			ForceExertedByBooster:   models.NewNumber(25600, "N"),
+			BurnTimeOfBooster:       models.NewNumber(4.5, "s"),
+			BoosterStartDelay:       models.NewNumber(0.2, "s"),
+			ForceExertedBySustainer: models.NewNumber(4800, "N"),
+			BurnTimeOfSustainer:     models.NewNumber(11, "s"),
+		},
+	}
+
+	m, err := FromParams(params)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []Stage{
+		{Start: 0.2, Force: 25600, BurnTime: 4.5, EndMass: 186},
+		{Start: 4.7, Force: 4800, BurnTime: 11, EndMass: 163},
+	}
+
+	if m.Mass != 231 || m.Calibre != 0.203 || m.Length != 3.66 {
+		t.Errorf("got mass %g, calibre %g, length %g", m.Mass, m.Calibre, m.Length)
+	}
+
+	if len(m.Stages) != len(want) {
+		t.Fatalf("got stages %+v, want %+v", m.Stages, want)
+	}
+
+	for i := range want {
+		if math.Abs(m.Stages[i].Start-want[i].Start) > 1e-9 || m.Stages[i].Force != want[i].Force ||
+			m.Stages[i].BurnTime != want[i].BurnTime || m.Stages[i].EndMass != want[i].EndMass {
+			t.Errorf("stage %d is %+v, want %+v", i+1, m.Stages[i], want[i])
+		}
+	}
+
+	params.PhysicalProp.Calibre = models.NewNumber(8, "in")
+
+	if _, err := FromParams(params); err == nil {
+		t.Error("a calibre in inches was read as millimetres")
+	}
+}
+
+// TestFreeFall drops a weapon without a motor in a vacuum, where it falls
+// g0·t²/2.
+func TestFreeFall(t *testing.T) {
+	m := &Missile{Name: "GBU-12", Mass: 277}
+
+	res, err := Simulate(m, Config{Altitude: 1000, Duration: 10, NoDrag: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	last := res.Last()
+
+	if want := 1000 - g0*10*10/2; math.Abs(last.Altitude-want) > 1e-6 {
+		t.Errorf("altitude after 10 s is %.3f m, want %.3f m", last.Altitude, want)
+	}
+
+	if want := g0 * 10; math.Abs(last.Speed-want) > 1e-6 {
+		t.Errorf("speed after 10 s is %.3f m/s, want %.3f m/s", last.Speed, want)
+	}
+}
+
+// TestAtmosphere checks the air against the tables of the standard
+// atmosphere, by geopotential altitude.
+func TestAtmosphere(t *testing.T) {
+	tests := []struct {
+		altitude   float64
+		density    float64
+		soundSpeed float64
+	}{
+		{-100, 1.2250, 340.29},
+		{0, 1.2250, 340.29},
+		{5000, 0.73643, 320.53},
+		{11000, 0.36392, 295.07},
+		{20000, 0.08803, 295.07},
+	}
+
+	for _, tt := range tests {
+		air := Atmosphere(tt.altitude)
+
+		if math.Abs(air.Density-tt.density) > 1e-3*tt.density {
+			t.Errorf("density at %g m is %.5f kg/m³, want %.5f kg/m³", tt.altitude, air.Density, tt.density)
+		}
+
+		if math.Abs(air.SoundSpeed-tt.soundSpeed) > 1e-3*tt.soundSpeed {
+			t.Errorf("speed of sound at %g m is %.2f m/s, want %.2f m/s", tt.altitude, air.SoundSpeed, tt.soundSpeed)
+		}
+	}
+}
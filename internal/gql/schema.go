@@ -0,0 +1,651 @@
+// Package gql exposes the weapon catalog as a GraphQL schema. The weapon
+// types are generated from the parameter registry in package models, so new
+// parameters show up without changes here.
+package gql
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"slices"
+	"strings"
+
+	"github.com/graphql-go/graphql"
+
+	"github.com/zeze322/wt-guided-weaponry/internal/db/mongodb"
+	"github.com/zeze322/wt-guided-weaponry/internal/filter"
+	"github.com/zeze322/wt-guided-weaponry/internal/validation"
+	"github.com/zeze322/wt-guided-weaponry/lib"
+	"github.com/zeze322/wt-guided-weaponry/models"
+)
+
+// group is a parameter group as it appears in the schema, e.g. the
+// guidanceProp field of a weapon.
+type group struct {
+	key    string
+	fields []models.Field
+}
+
+// groups splits the registry by the key prefix of the fields.
+func groups() []group {
+	var res []group
+
+	for _, field := range models.Fields() {
+		key, _, _ := strings.Cut(field.Key, ".")
+
+		if len(res) == 0 || res[len(res)-1].key != key {
+			res = append(res, group{key: key})
+		}
+
+		res[len(res)-1].fields = append(res[len(res)-1].fields, field)
+	}
+
+	return res
+}
+
+// FieldName turns the JSON key of a parameter into a valid GraphQL name:
+// "%" becomes "Pct" and any other character GraphQL doesn't allow is dropped.
+func FieldName(key string) string {
+	var b strings.Builder
+
+	for _, r := range strings.ReplaceAll(key, "%", "Pct") {
+		if r == '_' || r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9' {
+			b.WriteRune(r)
+		}
+	}
+
+	return b.String()
+}
+
+func typeName(key string) string {
+	return strings.ToUpper(key[:1]) + key[1:]
+}
+
+// schema holds the store the resolvers read from and write to.
+type schema struct {
+	store mongodb.Store
+}
+
+// NewSchema builds the schema over store. Mutations are validated the same
+// way as the REST API validates requests.
+func NewSchema(store mongodb.Store) (graphql.Schema, error) {
+	s := &schema{store: store}
+
+	weapon := s.weaponType()
+	category := s.categoryType(weapon)
+
+	query := graphql.NewObject(graphql.ObjectConfig{
+		Name: "Query",
+		Fields: graphql.Fields{
+			"weapons": &graphql.Field{
+				Type:        graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(weapon))),
+				Description: "Weapons, optionally of one category and matching a filter expression.",
+				Args: graphql.FieldConfigArgument{
+					"category": {Type: graphql.String},
+					"filter":   {Type: graphql.String, Description: "Filter expression, e.g. \"flightProp.maximumSpeed > 800\"."},
+				},
+				Resolve: s.resolveWeapons,
+			},
+			"weapon": &graphql.Field{
+				Type: weapon,
+				Args: graphql.FieldConfigArgument{
+					"name": {Type: graphql.NewNonNull(graphql.String)},
+				},
+				Resolve: func(p graphql.ResolveParams) (any, error) {
+					w, err := s.store.Weapon(p.Context, p.Args["name"].(string))
+					if err != nil {
+						return nil, nil
+					}
+					return w, nil
+				},
+			},
+			"search": &graphql.Field{
+				Type: graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(nameType))),
+				Args: graphql.FieldConfigArgument{
+					"q": {Type: graphql.NewNonNull(graphql.String)},
+				},
+				Resolve: func(p graphql.ResolveParams) (any, error) {
+					names, err := s.store.SearchWeapon(p.Context, p.Args["q"].(string))
+					if err != nil && !errors.Is(err, mongodb.ErrNothingFound) {
+						return nil, err
+					}
+					if names == nil {
+						names = []models.Name{}
+					}
+					return names, nil
+				},
+			},
+			"categories": &graphql.Field{
+				Type: graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(category))),
+				Resolve: func(p graphql.ResolveParams) (any, error) {
+					return s.store.Categories(p.Context)
+				},
+			},
+			"category": &graphql.Field{
+				Type: category,
+				Args: graphql.FieldConfigArgument{
+					"slug": {Type: graphql.NewNonNull(graphql.String)},
+				},
+				Resolve: func(p graphql.ResolveParams) (any, error) {
+					c, err := s.store.Category(p.Context, p.Args["slug"].(string))
+					if err != nil {
+						return nil, nil
+					}
+					return c, nil
+				},
+			},
+			"fields": &graphql.Field{
+				Type: graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(fieldType))),
+				Args: graphql.FieldConfigArgument{
+					"category": {Type: graphql.String},
+				},
+				Resolve: func(p graphql.ResolveParams) (any, error) {
+					if category, ok := p.Args["category"].(string); ok {
+						return models.FieldsFor(category), nil
+					}
+					return models.Fields(), nil
+				},
+			},
+		},
+	})
+
+	weaponInput := weaponInputType()
+
+	mutation := graphql.NewObject(graphql.ObjectConfig{
+		Name: "Mutation",
+		Fields: graphql.Fields{
+			"createWeapon": &graphql.Field{
+				Type: graphql.NewNonNull(weapon),
+				Args: graphql.FieldConfigArgument{
+					"input": {Type: graphql.NewNonNull(weaponInput)},
+				},
+				Resolve: s.resolveCreateWeapon,
+			},
+			"updateWeapon": &graphql.Field{
+				Type: graphql.NewNonNull(weapon),
+				Args: graphql.FieldConfigArgument{
+					"name":  {Type: graphql.NewNonNull(graphql.String)},
+					"input": {Type: graphql.NewNonNull(weaponInput)},
+				},
+				Resolve: s.resolveUpdateWeapon,
+			},
+			"createCategory": &graphql.Field{
+				Type: graphql.NewNonNull(category),
+				Args: graphql.FieldConfigArgument{
+					"input": {Type: graphql.NewNonNull(categoryInput)},
+				},
+				Resolve: s.resolveCreateCategory,
+			},
+			"updateCategory": &graphql.Field{
+				Type: graphql.NewNonNull(category),
+				Args: graphql.FieldConfigArgument{
+					"slug":  {Type: graphql.NewNonNull(graphql.String)},
+					"input": {Type: graphql.NewNonNull(categoryInput)},
+				},
+				Resolve: s.resolveUpdateCategory,
+			},
+		},
+	})
+
+	return graphql.NewSchema(graphql.SchemaConfig{
+		Query:    query,
+		Mutation: mutation,
+	})
+}
+
+var kindEnum = graphql.NewEnum(graphql.EnumConfig{
+	Name: "ValueKind",
+	Values: graphql.EnumValueConfigMap{
+		"number": {Value: models.KindNumber},
+		"range":  {Value: models.KindRange},
+		"tuple":  {Value: models.KindTuple},
+		"bool":   {Value: models.KindBool},
+		"text":   {Value: models.KindText},
+	},
+})
+
+var betterEnum = graphql.NewEnum(graphql.EnumConfig{
+	Name: "Better",
+	Values: graphql.EnumValueConfigMap{
+		"higher": {Value: models.HigherIsBetter},
+		"lower":  {Value: models.LowerIsBetter},
+	},
+})
+
+// classEnum lists the classes of the default categories.
+var classEnum = func() *graphql.Enum {
+	values := graphql.EnumValueConfigMap{}
+	for _, c := range models.DefaultCategories {
+		values[string(c.Class)] = &graphql.EnumValueConfig{Value: c.Class}
+	}
+
+	return graphql.NewEnum(graphql.EnumConfig{Name: "Class", Values: values})
+}()
+
+var valueType = graphql.NewObject(graphql.ObjectConfig{
+	Name:        "Value",
+	Description: "A parameter value. text keeps the value as it was entered, the other fields hold its parsed form.",
+	Fields: graphql.Fields{
+		"text":      {Type: graphql.NewNonNull(graphql.String)},
+		"kind":      {Type: graphql.NewNonNull(kindEnum)},
+		"magnitude": {Type: graphql.NewNonNull(graphql.Float)},
+		"unit":      {Type: graphql.String},
+		"range":     {Type: graphql.NewList(graphql.NewNonNull(graphql.Float))},
+		"tuple":     {Type: graphql.NewList(graphql.NewNonNull(graphql.Float))},
+	},
+})
+
+var nameType = graphql.NewObject(graphql.ObjectConfig{
+	Name: "WeaponName",
+	Fields: graphql.Fields{
+		"name":     {Type: graphql.NewNonNull(graphql.String)},
+		"slug":     {Type: graphql.String},
+		"category": {Type: graphql.NewNonNull(graphql.String)},
+	},
+})
+
+var fieldType = graphql.NewObject(graphql.ObjectConfig{
+	Name:        "Field",
+	Description: "A weapon parameter.",
+	Fields: graphql.Fields{
+		"key":         {Type: graphql.NewNonNull(graphql.String)},
+		"name":        {Type: graphql.NewNonNull(graphql.String), Description: "The name of the field in this schema.", Resolve: resolveFieldName},
+		"label":       {Type: graphql.NewNonNull(graphql.String)},
+		"unit":        {Type: graphql.String},
+		"group":       {Type: graphql.NewNonNull(graphql.String)},
+		"description": {Type: graphql.NewNonNull(graphql.String)},
+		"categories":  {Type: graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(graphql.String)))},
+		"kind":        {Type: kindEnum},
+		"limits": {Type: graphql.NewObject(graphql.ObjectConfig{
+			Name: "Limits",
+			Fields: graphql.Fields{
+				"min": {Type: graphql.NewNonNull(graphql.Float)},
+				"max": {Type: graphql.NewNonNull(graphql.Float)},
+			},
+		})},
+		"better": {Type: betterEnum},
+	},
+})
+
+func resolveFieldName(p graphql.ResolveParams) (any, error) {
+	key := p.Source.(models.Field).Key
+	_, name, _ := strings.Cut(key, ".")
+	return FieldName(name), nil
+}
+
+// weaponType generates the Weapon type with one object type per parameter
+// group, e.g. Weapon.guidanceProp of type GuidanceProp.
+func (s *schema) weaponType() *graphql.Object {
+	fields := graphql.Fields{
+		"name":     {Type: graphql.NewNonNull(graphql.String)},
+		"slug":     {Type: graphql.String, Description: "Names the weapon in URLs."},
+		"category": {Type: graphql.NewNonNull(graphql.String)},
+	}
+
+	for _, g := range groups() {
+		groupFields := graphql.Fields{}
+
+		for _, field := range g.fields {
+			_, name, _ := strings.Cut(field.Key, ".")
+
+			description := field.Label
+			if field.Unit != "" {
+				description += " (" + field.Unit + ")"
+			}
+			if field.Description != "" {
+				description += ". " + field.Description
+			}
+
+			groupFields[FieldName(name)] = &graphql.Field{
+				Type:        valueType,
+				Description: description,
+				Resolve:     resolveValue(field),
+			}
+		}
+
+		fields[g.key] = &graphql.Field{
+			Type: graphql.NewNonNull(graphql.NewObject(graphql.ObjectConfig{
+				Name:   typeName(g.key),
+				Fields: groupFields,
+			})),
+			// The group resolves to the weapon itself, its fields read the
+			// parameters through the registry.
+			Resolve: func(p graphql.ResolveParams) (any, error) {
+				return p.Source, nil
+			},
+		}
+	}
+
+	return graphql.NewObject(graphql.ObjectConfig{
+		Name:   "Weapon",
+		Fields: fields,
+	})
+}
+
+func resolveValue(field models.Field) graphql.FieldResolveFn {
+	return func(p graphql.ResolveParams) (any, error) {
+		v := field.Value(p.Source.(*models.Params))
+		if v == nil {
+			return nil, nil
+		}
+		return v, nil
+	}
+}
+
+func (s *schema) categoryType(weapon *graphql.Object) *graphql.Object {
+	return graphql.NewObject(graphql.ObjectConfig{
+		Name: "Category",
+		Fields: graphql.Fields{
+			"slug":        {Type: graphql.NewNonNull(graphql.String)},
+			"name":        {Type: graphql.NewNonNull(graphql.String)},
+			"class":       {Type: classEnum},
+			"guidance":    {Type: graphql.NewNonNull(graphql.String)},
+			"sortOrder":   {Type: graphql.NewNonNull(graphql.Int)},
+			"description": {Type: graphql.NewNonNull(graphql.String)},
+			"fields": {
+				Type:        graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(fieldType))),
+				Description: "The parameters shown for weapons of the category.",
+				Resolve: func(p graphql.ResolveParams) (any, error) {
+					return orEmpty(models.FieldsFor(slug(p.Source))), nil
+				},
+			},
+			"requiredFields": {
+				Type:        graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(fieldType))),
+				Description: "The parameters a weapon of the category must have.",
+				Resolve: func(p graphql.ResolveParams) (any, error) {
+					return orEmpty(models.RequiredFor(slug(p.Source))), nil
+				},
+			},
+			"weapons": {
+				Type: graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(weapon))),
+				Resolve: func(p graphql.ResolveParams) (any, error) {
+					weapons, err := s.store.WeaponsByCategory(p.Context, slug(p.Source))
+					if err != nil && !errors.Is(err, mongodb.ErrNothingFound) {
+						return nil, err
+					}
+					return orEmpty(weapons), nil
+				},
+			},
+		},
+	})
+}
+
+func slug(source any) string {
+	switch c := source.(type) {
+	case models.Category:
+		return c.Slug
+	case *models.Category:
+		return c.Slug
+	}
+	return ""
+}
+
+func orEmpty[T any](s []T) []T {
+	if s == nil {
+		return []T{}
+	}
+	return s
+}
+
+func (s *schema) resolveWeapons(p graphql.ResolveParams) (any, error) {
+	var node filter.Node
+
+	if expr, ok := p.Args["filter"].(string); ok && expr != "" {
+		n, err := filter.Parse(expr)
+		if err != nil {
+			return nil, fieldErrors{lib.InvalidFields([]lib.FieldError{{Field: "filter", Msg: err.Error()}})}
+		}
+		node = n
+	}
+
+	var (
+		weapons []*models.Params
+		err     error
+	)
+
+	if category, ok := p.Args["category"].(string); ok && category != "" {
+		weapons, err = s.store.WeaponsByCategory(p.Context, category)
+	} else {
+		weapons, err = s.store.Weapons(p.Context)
+	}
+
+	if err != nil && !errors.Is(err, mongodb.ErrNothingFound) {
+		return nil, err
+	}
+
+	if node != nil {
+		weapons = slices.DeleteFunc(weapons, func(w *models.Params) bool {
+			return !filter.Eval(node, w)
+		})
+	}
+
+	return orEmpty(weapons), nil
+}
+
+// weaponInputType mirrors Weapon with every parameter as a string, parsed
+// the same way as strings sent to the REST API.
+func weaponInputType() *graphql.InputObject {
+	fields := graphql.InputObjectConfigFieldMap{
+		"name":     {Type: graphql.NewNonNull(graphql.String)},
+		"category": {Type: graphql.NewNonNull(graphql.String)},
+	}
+
+	for _, g := range groups() {
+		groupFields := graphql.InputObjectConfigFieldMap{}
+
+		for _, field := range g.fields {
+			_, name, _ := strings.Cut(field.Key, ".")
+			groupFields[FieldName(name)] = &graphql.InputObjectFieldConfig{
+				Type:        graphql.String,
+				Description: field.Label,
+			}
+		}
+
+		fields[g.key] = &graphql.InputObjectFieldConfig{
+			Type: graphql.NewInputObject(graphql.InputObjectConfig{
+				Name:   typeName(g.key) + "Input",
+				Fields: groupFields,
+			}),
+		}
+	}
+
+	return graphql.NewInputObject(graphql.InputObjectConfig{
+		Name:   "WeaponInput",
+		Fields: fields,
+	})
+}
+
+var categoryInput = graphql.NewInputObject(graphql.InputObjectConfig{
+	Name: "CategoryInput",
+	Fields: graphql.InputObjectConfigFieldMap{
+		"slug":        {Type: graphql.NewNonNull(graphql.String)},
+		"name":        {Type: graphql.NewNonNull(graphql.String)},
+		"class":       {Type: classEnum},
+		"guidance":    {Type: graphql.String},
+		"sortOrder":   {Type: graphql.Int},
+		"description": {Type: graphql.String},
+	},
+})
+
+// weaponParams decodes a WeaponInput. The GraphQL names are mapped back to
+// the JSON keys, so the input goes through the same decoder as REST bodies.
+func weaponParams(input map[string]any) (*models.Params, error) {
+	doc := map[string]any{
+		"name":     input["name"],
+		"category": input["category"],
+	}
+
+	for _, g := range groups() {
+		values, ok := input[g.key].(map[string]any)
+		if !ok {
+			continue
+		}
+
+		group := map[string]any{}
+		for _, field := range g.fields {
+			_, name, _ := strings.Cut(field.Key, ".")
+			if v, ok := values[FieldName(name)]; ok && v != nil {
+				group[name] = v
+			}
+		}
+
+		doc[g.key] = group
+	}
+
+	data, err := json.Marshal(doc)
+	if err != nil {
+		return nil, err
+	}
+
+	params := new(models.Params)
+	if err := json.Unmarshal(data, params); err != nil {
+		return nil, err
+	}
+
+	return params, nil
+}
+
+func (s *schema) validate(ctx context.Context, params *models.Params) error {
+	errs, err := validation.Weapon(ctx, s.store, params)
+	if err != nil {
+		return err
+	}
+
+	if len(errs) > 0 {
+		return fieldErrors{lib.InvalidFields(errs)}
+	}
+
+	return nil
+}
+
+func (s *schema) resolveCreateWeapon(p graphql.ResolveParams) (any, error) {
+	params, err := weaponParams(p.Args["input"].(map[string]any))
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.validate(p.Context, params); err != nil {
+		return nil, err
+	}
+
+	err = s.store.InsertWeapon(p.Context, params)
+	if errors.Is(err, mongodb.ErrConflict) {
+		return nil, fieldErrors{lib.Conflict(params.Name)}
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return models.NewWeapon(params), nil
+}
+
+func (s *schema) resolveUpdateWeapon(p graphql.ResolveParams) (any, error) {
+	name := p.Args["name"].(string)
+
+	params, err := weaponParams(p.Args["input"].(map[string]any))
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := s.store.Weapon(p.Context, name); err != nil {
+		return nil, fieldErrors{lib.NotFound(name)}
+	}
+
+	if err := s.validate(p.Context, params); err != nil {
+		return nil, err
+	}
+
+	err = s.store.UpdateWeapon(p.Context, name, params)
+	if errors.Is(err, mongodb.ErrConflict) {
+		return nil, fieldErrors{lib.Conflict(params.Name)}
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return models.NewWeapon(params), nil
+}
+
+func categoryParams(input map[string]any) *models.Category {
+	category := &models.Category{
+		Slug: input["slug"].(string),
+		Name: input["name"].(string),
+	}
+
+	if class, ok := input["class"].(models.Class); ok {
+		category.Class = class
+	}
+	if guidance, ok := input["guidance"].(string); ok {
+		category.Guidance = guidance
+	}
+	if sortOrder, ok := input["sortOrder"].(int); ok {
+		category.SortOrder = sortOrder
+	}
+	if description, ok := input["description"].(string); ok {
+		category.Description = description
+	}
+
+	return category
+}
+
+func validateCategory(category *models.Category) error {
+	if errs := validation.Category(category); len(errs) > 0 {
+		return fieldErrors{lib.InvalidFields(errs)}
+	}
+
+	return nil
+}
+
+func (s *schema) resolveCreateCategory(p graphql.ResolveParams) (any, error) {
+	category := categoryParams(p.Args["input"].(map[string]any))
+
+	if err := validateCategory(category); err != nil {
+		return nil, err
+	}
+
+	if err := s.store.InsertCategory(p.Context, category); err != nil {
+		return nil, fieldErrors{lib.Conflict(category.Slug)}
+	}
+
+	return category, nil
+}
+
+func (s *schema) resolveUpdateCategory(p graphql.ResolveParams) (any, error) {
+	slug := p.Args["slug"].(string)
+	category := categoryParams(p.Args["input"].(map[string]any))
+
+	if _, err := s.store.Category(p.Context, slug); err != nil {
+		return nil, fieldErrors{lib.NotFound(slug)}
+	}
+
+	if err := validateCategory(category); err != nil {
+		return nil, err
+	}
+
+	// The category exists, so a failed update means the new slug is taken.
+	if err := s.store.UpdateCategory(p.Context, slug, category); err != nil {
+		return nil, fieldErrors{lib.Conflict(category.Slug)}
+	}
+
+	return category, nil
+}
+
+// fieldErrors reports an APIError as a GraphQL error, with the status code
+// and field errors as extensions.
+type fieldErrors struct {
+	err lib.APIError
+}
+
+func (e fieldErrors) Error() string {
+	return e.err.Msg
+}
+
+func (e fieldErrors) Extensions() map[string]any {
+	ext := map[string]any{"statusCode": e.err.StatusCode}
+	if len(e.err.Errors) > 0 {
+		ext["errors"] = e.err.Errors
+	}
+	return ext
+}
@@ -0,0 +1,241 @@
+package importer
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"path/filepath"
+	"strings"
+
+	"github.com/zeze322/wt-guided-weaponry/internal/validation"
+	"github.com/zeze322/wt-guided-weaponry/models"
+)
+
+type Format string
+
+const (
+	FormatJSON   Format = "json"
+	FormatNDJSON Format = "ndjson"
+	FormatCSV    Format = "csv"
+)
+
+var ErrUnknownFormat = errors.New("unknown import format")
+
+var mediaTypes = map[string]Format{
+	"application/json":     FormatJSON,
+	"application/x-ndjson": FormatNDJSON,
+	"application/ndjson":   FormatNDJSON,
+	"application/jsonl":    FormatNDJSON,
+	"text/csv":             FormatCSV,
+}
+
+var extensions = map[string]Format{
+	".json":   FormatJSON,
+	".ndjson": FormatNDJSON,
+	".jsonl":  FormatNDJSON,
+	".csv":    FormatCSV,
+}
+
+// FormatOf returns the format of a content type such as "text/csv".
+func FormatOf(contentType string) (Format, error) {
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return "", fmt.Errorf("%w: %s", ErrUnknownFormat, contentType)
+	}
+
+	format, ok := mediaTypes[mediaType]
+	if !ok {
+		return "", fmt.Errorf("%w: %s", ErrUnknownFormat, mediaType)
+	}
+
+	return format, nil
+}
+
+// FormatOfFile returns the format of a file by its extension.
+func FormatOfFile(name string) (Format, error) {
+	format, ok := extensions[strings.ToLower(filepath.Ext(name))]
+	if !ok {
+		return "", fmt.Errorf("%w: %s", ErrUnknownFormat, name)
+	}
+
+	return format, nil
+}
+
+// Record is a weapon read from an import. Err is set instead of Params when
+// the record itself couldn't be decoded, the import carries on with the
+// next one.
+type Record struct {
+	// Line is the line the record starts on, 0 for JSON arrays.
+	Line   int
+	Params *models.Params
+	Err    error
+}
+
+// Decode reads every record of r. The error is only set when the input as a
+// whole is unreadable, e.g. a CSV file without a usable header.
+func Decode(r io.Reader, format Format) ([]Record, error) {
+	switch format {
+	case FormatJSON:
+		return decodeJSON(r)
+	case FormatNDJSON:
+		return decodeNDJSON(r)
+	case FormatCSV:
+		return decodeCSV(r)
+	}
+
+	return nil, fmt.Errorf("%w: %s", ErrUnknownFormat, format)
+}
+
+func decodeJSON(r io.Reader) ([]Record, error) {
+	dec := json.NewDecoder(r)
+
+	t, err := dec.Token()
+	if err != nil {
+		return nil, err
+	}
+
+	if t != json.Delim('[') {
+		return nil, errors.New("expected a JSON array of weapons")
+	}
+
+	var records []Record
+
+	for dec.More() {
+		var raw json.RawMessage
+		if err := dec.Decode(&raw); err != nil {
+			return nil, fmt.Errorf("record %d: %w", len(records)+1, err)
+		}
+
+		records = append(records, record(0, raw))
+	}
+
+	if _, err := dec.Token(); err != nil {
+		return nil, err
+	}
+
+	return records, nil
+}
+
+func decodeNDJSON(r io.Reader) ([]Record, error) {
+	var records []Record
+
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(nil, 1<<20)
+
+	for line := 1; scanner.Scan(); line++ {
+		data := bytes.TrimSpace(scanner.Bytes())
+		if len(data) == 0 {
+			continue
+		}
+
+		records = append(records, record(line, data))
+	}
+
+	return records, scanner.Err()
+}
+
+func record(line int, data []byte) Record {
+	if err := validation.Keys(data); err != nil {
+		return Record{Line: line, Err: err}
+	}
+
+	params := new(models.Params)
+	if err := json.Unmarshal(data, params); err != nil {
+		return Record{Line: line, Err: err}
+	}
+
+	return Record{Line: line, Params: params}
+}
+
+// decodeCSV reads one weapon per row. The header names the columns by
+// parameter key ("physicalProp.mass"), label ("Mass") or the label shown in
+// the tables ("Mass: [kg]"), besides "name" and "category".
+func decodeCSV(r io.Reader) ([]Record, error) {
+	cr := csv.NewReader(r)
+	cr.FieldsPerRecord = -1
+
+	header, err := cr.Read()
+	if err != nil {
+		return nil, fmt.Errorf("reading CSV header: %w", err)
+	}
+
+	columns := make([]string, len(header))
+
+	for i, name := range header {
+		key, ok := columnKey(strings.TrimSpace(strings.TrimPrefix(name, "\ufeff")))
+		if !ok {
+			return nil, fmt.Errorf("unknown CSV column %q", name)
+		}
+
+		columns[i] = key
+	}
+
+	var records []Record
+
+	for {
+		row, err := cr.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+
+		if err != nil {
+			var parseErr *csv.ParseError
+			if !errors.As(err, &parseErr) {
+				return nil, err
+			}
+
+			records = append(records, Record{Line: parseErr.StartLine, Err: err})
+			continue
+		}
+
+		line, _ := cr.FieldPos(0)
+
+		params := new(models.Params)
+
+		for i, cell := range row {
+			if i >= len(columns) || strings.TrimSpace(cell) == "" {
+				continue
+			}
+
+			cell = strings.TrimSpace(cell)
+
+			switch key := columns[i]; key {
+			case "name":
+				params.Name = cell
+			case "category":
+				params.Category = cell
+			default:
+				field, _ := models.FieldByKey(key)
+				field.SetValue(params, models.ParseValue(cell))
+			}
+		}
+
+		records = append(records, Record{Line: line, Params: params})
+	}
+
+	return records, nil
+}
+
+func columnKey(name string) (string, bool) {
+	switch strings.ToLower(name) {
+	case "name", "category":
+		return strings.ToLower(name), true
+	}
+
+	if _, ok := models.FieldByKey(name); ok {
+		return name, true
+	}
+
+	for _, field := range models.Fields() {
+		if strings.EqualFold(name, field.Label) || strings.EqualFold(name, field.DisplayLabel()) {
+			return field.Key, true
+		}
+	}
+
+	return "", false
+}
@@ -0,0 +1,262 @@
+// Package importer loads many weapons at once from JSON arrays, NDJSON or
+// CSV, validating every record and reporting what happened to each.
+package importer
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/zeze322/wt-guided-weaponry/internal/db/mongodb"
+	"github.com/zeze322/wt-guided-weaponry/internal/validation"
+	"github.com/zeze322/wt-guided-weaponry/lib"
+	"github.com/zeze322/wt-guided-weaponry/models"
+)
+
+type Mode string
+
+const (
+	// ModeInsert only creates weapons, existing ones are skipped.
+	ModeInsert Mode = "insert"
+
+	// ModeUpsert creates new weapons and merges the imported parameters
+	// into existing ones, keeping the parameters the record leaves out.
+	ModeUpsert Mode = "upsert"
+
+	// ModeReplace creates new weapons and replaces existing ones with the
+	// imported record.
+	ModeReplace Mode = "replace"
+)
+
+// ParseMode parses a mode name, ModeInsert when s is empty.
+func ParseMode(s string) (Mode, error) {
+	switch mode := Mode(strings.ToLower(s)); mode {
+	case "":
+		return ModeInsert, nil
+	case ModeInsert, ModeUpsert, ModeReplace:
+		return mode, nil
+	}
+
+	return "", fmt.Errorf("unknown import mode %s, expected insert, upsert or replace", s)
+}
+
+// Store is what an import reads and writes. mongodb.Store satisfies it.
+// Weapon must wrap mongodb.ErrNothingFound when the weapon doesn't exist
+// or is deleted.
+type Store interface {
+	validation.Categories
+	Weapon(context.Context, string) (*models.Params, error)
+	DeletedWeapons(context.Context) ([]*models.Params, error)
+	InsertWeapon(context.Context, *models.Params) error
+	UpdateWeapon(context.Context, string, *models.Params) error
+}
+
+type Options struct {
+	Mode Mode
+
+	// DryRun validates and reports without writing anything.
+	DryRun bool
+}
+
+type Status string
+
+const (
+	StatusCreated Status = "created"
+	StatusUpdated Status = "updated"
+	StatusSkipped Status = "skipped"
+	StatusFailed  Status = "failed"
+)
+
+// Result is the outcome of a single record.
+type Result struct {
+	// Index is the position of the record in the import, starting at 1.
+	Index  int              `json:"index"`
+	Line   int              `json:"line,omitempty"`
+	Name   string           `json:"name,omitempty"`
+	Status Status           `json:"status"`
+	Msg    string           `json:"msg,omitempty"`
+	Errors []lib.FieldError `json:"errors,omitempty"`
+}
+
+type Summary struct {
+	Created int `json:"created"`
+	Updated int `json:"updated"`
+	Skipped int `json:"skipped"`
+	Failed  int `json:"failed"`
+}
+
+type Report struct {
+	Mode    Mode     `json:"mode"`
+	DryRun  bool     `json:"dryRun"`
+	Summary Summary  `json:"summary"`
+	Records []Result `json:"records"`
+}
+
+// Run imports records into store. A failed record doesn't stop the import.
+// The error is only set when the store can't be read at all.
+func Run(ctx context.Context, store Store, records []Record, opts Options) (*Report, error) {
+	if opts.Mode == "" {
+		opts.Mode = ModeInsert
+	}
+
+	report := &Report{Mode: opts.Mode, DryRun: opts.DryRun, Records: []Result{}}
+
+	// seen maps the names imported so far to their index, so a weapon
+	// listed twice fails instead of being written twice.
+	seen := map[string]int{}
+
+	// Weapon doesn't find deleted weapons, but their names stay taken.
+	deletedWeapons, err := store.DeletedWeapons(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	deleted := make(map[string]bool, len(deletedWeapons))
+	for _, weapon := range deletedWeapons {
+		deleted[weapon.Name] = true
+	}
+
+	for i, rec := range records {
+		res := Result{Index: i + 1, Line: rec.Line}
+
+		if rec.Params != nil {
+			res.Name = rec.Params.Name
+		}
+
+		if err := run(ctx, store, rec, opts, seen, deleted, &res); err != nil {
+			return nil, err
+		}
+
+		if res.Status != StatusFailed && res.Name != "" {
+			seen[res.Name] = res.Index
+		}
+
+		report.add(res)
+	}
+
+	return report, nil
+}
+
+func (r *Report) add(res Result) {
+	switch res.Status {
+	case StatusCreated:
+		r.Summary.Created++
+	case StatusUpdated:
+		r.Summary.Updated++
+	case StatusSkipped:
+		r.Summary.Skipped++
+	case StatusFailed:
+		r.Summary.Failed++
+	}
+
+	r.Records = append(r.Records, res)
+}
+
+func run(ctx context.Context, store Store, rec Record, opts Options, seen map[string]int, deleted map[string]bool, res *Result) error {
+	fail := func(msg string) {
+		res.Status = StatusFailed
+		res.Msg = msg
+	}
+
+	if rec.Err != nil {
+		fail(rec.Err.Error())
+		return nil
+	}
+
+	params := rec.Params
+
+	if index, ok := seen[params.Name]; ok {
+		fail(fmt.Sprintf("%s was already imported by record %d", params.Name, index))
+		return nil
+	}
+
+	// Importing doesn't undo a deletion, the weapon has to be restored
+	// first.
+	if deleted[params.Name] {
+		res.Status = StatusSkipped
+		res.Msg = fmt.Sprintf("%s is deleted, restore it to import it", params.Name)
+		return nil
+	}
+
+	// Only a missing weapon is inserted. Any other error means the store
+	// can't tell, and writing blindly could clobber or duplicate it.
+	existing, err := store.Weapon(ctx, params.Name)
+	if err != nil && !errors.Is(err, mongodb.ErrNothingFound) {
+		return err
+	}
+
+	if existing != nil {
+		switch opts.Mode {
+		case ModeInsert:
+			res.Status = StatusSkipped
+			res.Msg = fmt.Sprintf("%s already exists", params.Name)
+			return nil
+		case ModeUpsert:
+			params = merge(existing, params)
+		}
+	}
+
+	errs, err := validation.Weapon(ctx, store, params)
+	if err != nil {
+		return err
+	}
+
+	if len(errs) > 0 {
+		res.Errors = errs
+		fail("invalid fields")
+		return nil
+	}
+
+	if existing == nil {
+		res.Status = StatusCreated
+		if !opts.DryRun {
+			if err := store.InsertWeapon(ctx, params); err != nil {
+				fail(err.Error())
+			}
+		}
+		return nil
+	}
+
+	if same(existing, params) {
+		res.Status = StatusSkipped
+		res.Msg = "unchanged"
+		return nil
+	}
+
+	res.Status = StatusUpdated
+	if !opts.DryRun {
+		if err := store.UpdateWeapon(ctx, existing.Name, params); err != nil {
+			fail(err.Error())
+		}
+	}
+
+	return nil
+}
+
+// merge returns existing with the category and parameters set in params.
+func merge(existing, params *models.Params) *models.Params {
+	merged := existing.Clone()
+
+	if params.Category != "" {
+		merged.Category = params.Category
+	}
+
+	for _, field := range models.Fields() {
+		if v := field.Value(params); v != nil {
+			field.SetValue(merged, v.Clone())
+		}
+	}
+
+	return merged
+}
+
+// same reports whether storing b would leave a unchanged.
+func same(a, b *models.Params) bool {
+	ja, errA := json.Marshal(models.NewWeapon(a))
+	jb, errB := json.Marshal(models.NewWeapon(b))
+
+	return errA == nil && errB == nil && bytes.Equal(ja, jb)
+}
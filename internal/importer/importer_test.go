@@ -0,0 +1,156 @@
+package importer_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"os"
+	"testing"
+
+	"github.com/zeze322/wt-guided-weaponry/internal/db/memory"
+	"github.com/zeze322/wt-guided-weaponry/internal/db/mongodb"
+	"github.com/zeze322/wt-guided-weaponry/internal/importer"
+	"github.com/zeze322/wt-guided-weaponry/models"
+)
+
+var errDown = errors.New("store is down")
+
+// downStore fails to read weapons, as a store does during an outage.
+type downStore struct {
+	*memory.MemoryStore
+}
+
+func (downStore) Weapon(context.Context, string) (*models.Params, error) {
+	return nil, errDown
+}
+
+func newStore(t *testing.T) *memory.MemoryStore {
+	t.Helper()
+
+	store := memory.New()
+
+	if err := mongodb.SeedCategories(context.Background(), store, models.DefaultCategories); err != nil {
+		t.Fatal(err)
+	}
+
+	return store
+}
+
+func records(weapons ...*models.Params) []importer.Record {
+	var recs []importer.Record
+	for _, w := range weapons {
+		recs = append(recs, importer.Record{Params: w})
+	}
+	return recs
+}
+
+// aim9l returns the AIM-9L in testdata/aim-9l.json with its mass set.
+func aim9l(t *testing.T, mass string) *models.Params {
+	t.Helper()
+
+	data, err := os.ReadFile("testdata/aim-9l.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	params := new(models.Params)
+	if err := json.Unmarshal(data, params); err != nil {
+		t.Fatal(err)
+	}
+
+	params.Mass = models.ParseValue(mass)
+
+	return params
+}
+
+func TestRun(t *testing.T) {
+	ctx := context.Background()
+	store := newStore(t)
+
+	report, err := importer.Run(ctx, store, records(aim9l(t, "85.5 kg")), importer.Options{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if report.Summary != (importer.Summary{Created: 1}) {
+		t.Fatalf("first import: %+v", report.Records)
+	}
+
+	report, err = importer.Run(ctx, store, records(aim9l(t, "86 kg")), importer.Options{Mode: importer.ModeUpsert})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if report.Summary != (importer.Summary{Updated: 1}) {
+		t.Fatalf("upsert: %+v", report.Records)
+	}
+
+	weapon, err := store.Weapon(ctx, "AIM-9L")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if weapon.Mass.String() != "86 kg" {
+		t.Fatalf("upsert stored mass %q, want 86 kg", weapon.Mass)
+	}
+}
+
+// TestRunStoreDown checks that an import stops when the store can't say
+// whether a weapon exists, instead of inserting it.
+func TestRunStoreDown(t *testing.T) {
+	ctx := context.Background()
+	store := newStore(t)
+
+	for _, mode := range []importer.Mode{importer.ModeInsert, importer.ModeUpsert, importer.ModeReplace} {
+		_, err := importer.Run(ctx, downStore{store}, records(aim9l(t, "85.5 kg")), importer.Options{Mode: mode})
+		if !errors.Is(err, errDown) {
+			t.Errorf("%s import returned %v, want the store error", mode, err)
+		}
+	}
+
+	weapons, err := store.Weapons(ctx)
+	if err != nil && !errors.Is(err, mongodb.ErrNothingFound) {
+		t.Fatal(err)
+	}
+
+	if len(weapons) != 0 {
+		t.Fatalf("the import inserted %d weapons while the store was down", len(weapons))
+	}
+}
+
+// TestRunDeleted checks that importing the name of a deleted weapon is
+// skipped in every mode, dry run or not, and leaves the weapon deleted.
+func TestRunDeleted(t *testing.T) {
+	ctx := context.Background()
+	store := newStore(t)
+
+	if err := store.InsertWeapon(ctx, aim9l(t, "85.5 kg")); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := store.DeleteWeapon(ctx, "AIM-9L", "duplicate"); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, mode := range []importer.Mode{importer.ModeInsert, importer.ModeUpsert, importer.ModeReplace} {
+		for _, dryRun := range []bool{true, false} {
+			report, err := importer.Run(ctx, store, records(aim9l(t, "86 kg")), importer.Options{Mode: mode, DryRun: dryRun})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if report.Summary != (importer.Summary{Skipped: 1}) || report.Records[0].Msg != "AIM-9L is deleted, restore it to import it" {
+				t.Errorf("%s import (dry run %t) of a deleted weapon: %+v", mode, dryRun, report.Records)
+			}
+		}
+	}
+
+	if _, err := store.Weapon(ctx, "AIM-9L"); !errors.Is(err, mongodb.ErrNothingFound) {
+		t.Fatalf("Weapon after the imports returned %v, want ErrNothingFound", err)
+	}
+
+	deleted, err := store.DeletedWeapons(ctx)
+	if err != nil || len(deleted) != 1 || deleted[0].Mass.String() != "85.5 kg" {
+		t.Fatalf("DeletedWeapons after the imports returned %+v, %v", deleted, err)
+	}
+}
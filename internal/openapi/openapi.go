@@ -0,0 +1,468 @@
+// Package openapi builds OpenAPI 3 documents from a list of endpoints,
+// deriving the schemas of request and response bodies from Go types.
+package openapi
+
+import (
+	"fmt"
+	"net/http"
+	"reflect"
+	"regexp"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const Version = "3.0.3"
+
+type Document struct {
+	OpenAPI    string               `json:"openapi"`
+	Info       Info                 `json:"info"`
+	Servers    []Server             `json:"servers,omitempty"`
+	Tags       []Tag                `json:"tags,omitempty"`
+	Paths      map[string]*PathItem `json:"paths"`
+	Components Components           `json:"components"`
+}
+
+type Info struct {
+	Title       string `json:"title"`
+	Description string `json:"description,omitempty"`
+	Version     string `json:"version"`
+}
+
+type Server struct {
+	URL string `json:"url"`
+}
+
+type Tag struct {
+	Name        string `json:"name"`
+	Description string `json:"description,omitempty"`
+}
+
+type Components struct {
+	Schemas map[string]*Schema `json:"schemas"`
+}
+
+// PathItem holds the operations of a path, keyed by lowercase method.
+type PathItem map[string]*Operation
+
+type Operation struct {
+	OperationID string              `json:"operationId"`
+	Summary     string              `json:"summary"`
+	Description string              `json:"description,omitempty"`
+	Tags        []string            `json:"tags,omitempty"`
+	Parameters  []Parameter         `json:"parameters,omitempty"`
+	RequestBody *RequestBody        `json:"requestBody,omitempty"`
+	Responses   map[string]Response `json:"responses"`
+}
+
+type Parameter struct {
+	Name        string  `json:"name"`
+	In          string  `json:"in"`
+	Description string  `json:"description,omitempty"`
+	Required    bool    `json:"required,omitempty"`
+	Schema      *Schema `json:"schema"`
+}
+
+type RequestBody struct {
+	Required bool                 `json:"required,omitempty"`
+	Content  map[string]MediaType `json:"content"`
+}
+
+type Response struct {
+	Description string               `json:"description"`
+	Content     map[string]MediaType `json:"content,omitempty"`
+}
+
+type MediaType struct {
+	Schema *Schema `json:"schema"`
+}
+
+type Schema struct {
+	Ref                  string             `json:"$ref,omitempty"`
+	Type                 string             `json:"type,omitempty"`
+	Format               string             `json:"format,omitempty"`
+	Description          string             `json:"description,omitempty"`
+	Enum                 []string           `json:"enum,omitempty"`
+	Items                *Schema            `json:"items,omitempty"`
+	Properties           map[string]*Schema `json:"properties,omitempty"`
+	Required             []string           `json:"required,omitempty"`
+	AdditionalProperties *Schema            `json:"additionalProperties,omitempty"`
+	OneOf                []*Schema          `json:"oneOf,omitempty"`
+	Nullable             bool               `json:"nullable,omitempty"`
+}
+
+// Param documents a query parameter.
+type Param struct {
+	Name        string
+	Description string
+	Type        string
+	Required    bool
+}
+
+// Endpoint documents a single route.
+type Endpoint struct {
+	Method string
+
+	// Path is relative to the server URL and uses {name} for path
+	// parameters, like chi patterns.
+	Path string
+
+	Summary     string
+	Description string
+	Tag         string
+	Query       []Param
+
+	// Request and Response are sample values whose types describe the
+	// bodies. A nil Request means no body, a nil Response an empty 204.
+	Request any
+
+	OptionalRequest bool
+
+	// RequestTypes lists the accepted content types, application/json
+	// when empty.
+	RequestTypes []string
+
+	Response any
+	Meta     any
+
+	// Status is the status of a successful response, 200 when zero.
+	Status int
+
+	// Errors lists the error statuses the endpoint can return.
+	Errors []int
+}
+
+// Generator turns endpoints into a Document.
+type Generator struct {
+	Info      Info
+	ServerURL string
+	Tags      []Tag
+
+	// Schemas are added to the components as they are.
+	Schemas map[string]*Schema
+
+	// Overrides replace the schema derived for a type, for types with
+	// custom JSON encoding.
+	Overrides map[reflect.Type]*Schema
+
+	// Envelope wraps a response schema and a meta schema, which may be nil,
+	// in the response body schema. Bodies are used as they are when it's nil.
+	Envelope func(data, meta *Schema) *Schema
+
+	// Error is the body of error responses.
+	Error any
+
+	schemas map[string]*Schema
+	names   map[reflect.Type]string
+}
+
+var pathParamRe = regexp.MustCompile(`\{(\w+)\}`)
+
+func (g *Generator) Build(endpoints []Endpoint) *Document {
+	g.schemas = map[string]*Schema{}
+	g.names = map[reflect.Type]string{}
+
+	for name, s := range g.Schemas {
+		g.schemas[name] = s
+	}
+
+	doc := &Document{
+		OpenAPI: Version,
+		Info:    g.Info,
+		Tags:    g.Tags,
+		Paths:   map[string]*PathItem{},
+	}
+
+	if g.ServerURL != "" {
+		doc.Servers = []Server{{URL: g.ServerURL}}
+	}
+
+	for _, e := range endpoints {
+		item, ok := doc.Paths[e.Path]
+		if !ok {
+			item = &PathItem{}
+			doc.Paths[e.Path] = item
+		}
+
+		(*item)[strings.ToLower(e.Method)] = g.operation(e)
+	}
+
+	doc.Components.Schemas = g.schemas
+
+	return doc
+}
+
+func (g *Generator) operation(e Endpoint) *Operation {
+	op := &Operation{
+		OperationID: operationID(e),
+		Summary:     e.Summary,
+		Description: e.Description,
+		Responses:   map[string]Response{},
+	}
+
+	if e.Tag != "" {
+		op.Tags = []string{e.Tag}
+	}
+
+	for _, m := range pathParamRe.FindAllStringSubmatch(e.Path, -1) {
+		op.Parameters = append(op.Parameters, Parameter{
+			Name:     m[1],
+			In:       "path",
+			Required: true,
+			Schema:   &Schema{Type: "string"},
+		})
+	}
+
+	for _, q := range e.Query {
+		typ := q.Type
+		if typ == "" {
+			typ = "string"
+		}
+
+		op.Parameters = append(op.Parameters, Parameter{
+			Name:        q.Name,
+			In:          "query",
+			Description: q.Description,
+			Required:    q.Required,
+			Schema:      &Schema{Type: typ},
+		})
+	}
+
+	if e.Request != nil {
+		types := e.RequestTypes
+		if len(types) == 0 {
+			types = []string{"application/json"}
+		}
+
+		body := &RequestBody{Required: !e.OptionalRequest, Content: map[string]MediaType{}}
+		for _, t := range types {
+			body.Content[t] = MediaType{Schema: g.schema(reflect.TypeOf(e.Request))}
+		}
+
+		op.RequestBody = body
+	}
+
+	status := e.Status
+	if status == 0 {
+		status = http.StatusOK
+	}
+
+	if e.Response == nil {
+		op.Responses[strconv.Itoa(status)] = Response{Description: http.StatusText(status)}
+	} else {
+		data := g.schema(reflect.TypeOf(e.Response))
+
+		var meta *Schema
+		if e.Meta != nil {
+			meta = g.schema(reflect.TypeOf(e.Meta))
+		}
+
+		if g.Envelope != nil {
+			data = g.Envelope(data, meta)
+		}
+
+		op.Responses[strconv.Itoa(status)] = Response{
+			Description: http.StatusText(status),
+			Content:     map[string]MediaType{"application/json": {Schema: data}},
+		}
+	}
+
+	for _, code := range e.Errors {
+		resp := Response{Description: http.StatusText(code)}
+		if g.Error != nil {
+			resp.Content = map[string]MediaType{"application/json": {Schema: g.schema(reflect.TypeOf(g.Error))}}
+		}
+
+		op.Responses[strconv.Itoa(code)] = resp
+	}
+
+	return op
+}
+
+// operationID derives an id such as "getWeaponsByName" from the method and path.
+func operationID(e Endpoint) string {
+	var b strings.Builder
+
+	b.WriteString(strings.ToLower(e.Method))
+
+	for _, part := range strings.Split(e.Path, "/") {
+		if part == "" {
+			continue
+		}
+
+		if m := pathParamRe.FindStringSubmatch(part); m != nil {
+			b.WriteString("By")
+			part = m[1]
+		}
+
+		for _, word := range strings.FieldsFunc(part, func(r rune) bool { return r == '-' || r == '_' }) {
+			b.WriteString(strings.ToUpper(word[:1]) + word[1:])
+		}
+	}
+
+	return b.String()
+}
+
+var timeType = reflect.TypeOf(time.Time{})
+
+func (g *Generator) schema(t reflect.Type) *Schema {
+	if s, ok := g.Overrides[t]; ok {
+		return s
+	}
+
+	switch t.Kind() {
+	case reflect.Pointer:
+		return g.schema(t.Elem())
+	case reflect.Interface:
+		return &Schema{}
+	case reflect.String:
+		return &Schema{Type: "string"}
+	case reflect.Bool:
+		return &Schema{Type: "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return &Schema{Type: "integer"}
+	case reflect.Float32, reflect.Float64:
+		return &Schema{Type: "number"}
+	case reflect.Slice, reflect.Array:
+		return &Schema{Type: "array", Items: g.schema(t.Elem())}
+	case reflect.Map:
+		return &Schema{Type: "object", AdditionalProperties: g.schema(t.Elem())}
+	case reflect.Struct:
+		if t == timeType {
+			return &Schema{Type: "string", Format: "date-time"}
+		}
+		return g.component(t)
+	}
+
+	panic(fmt.Sprintf("openapi: unsupported type %s", t))
+}
+
+// component registers the schema of a named struct under components and
+// returns a reference to it.
+func (g *Generator) component(t reflect.Type) *Schema {
+	if t.Name() == "" {
+		return g.object(t)
+	}
+
+	name, ok := g.names[t]
+	if !ok {
+		name = t.Name()
+		for i := 2; g.schemas[name] != nil; i++ {
+			name = fmt.Sprintf("%s%d", t.Name(), i)
+		}
+
+		g.names[t] = name
+
+		// Register before descending, so recursive types terminate.
+		g.schemas[name] = &Schema{}
+		*g.schemas[name] = *g.object(t)
+	}
+
+	return &Schema{Ref: "#/components/schemas/" + name}
+}
+
+func (g *Generator) object(t reflect.Type) *Schema {
+	s := &Schema{Type: "object", Properties: map[string]*Schema{}}
+
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if !f.IsExported() {
+			continue
+		}
+
+		tag := f.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+
+		// Untagged embedded structs are flattened, as encoding/json does.
+		if f.Anonymous && tag == "" && f.Type.Kind() == reflect.Struct {
+			embedded := g.object(f.Type)
+			for name, prop := range embedded.Properties {
+				s.Properties[name] = prop
+			}
+			s.Required = append(s.Required, embedded.Required...)
+			continue
+		}
+
+		name, opts, _ := strings.Cut(tag, ",")
+		if name == "" {
+			name = f.Name
+		}
+
+		s.Properties[name] = g.schema(f.Type)
+
+		if !strings.Contains(opts, "omitempty") && f.Type.Kind() != reflect.Pointer {
+			s.Required = append(s.Required, name)
+		}
+	}
+
+	sort.Strings(s.Required)
+
+	return s
+}
+
+// OperationRef is an operation together with the path and method it's
+// served at.
+type OperationRef struct {
+	Method string
+	Path   string
+	*Operation
+}
+
+var methodOrder = []string{"get", "post", "put", "patch", "delete"}
+
+// Operations lists the operations of the document ordered by tag, then
+// path and method.
+func (d *Document) Operations() []OperationRef {
+	tagOrder := map[string]int{}
+	for i, tag := range d.Tags {
+		tagOrder[tag.Name] = i + 1
+	}
+
+	var ops []OperationRef
+
+	for path, item := range d.Paths {
+		for method, op := range *item {
+			ops = append(ops, OperationRef{Method: method, Path: path, Operation: op})
+		}
+	}
+
+	sort.Slice(ops, func(i, j int) bool {
+		a, b := ops[i], ops[j]
+
+		if ta, tb := tagOrder[a.tag()], tagOrder[b.tag()]; ta != tb {
+			return ta < tb
+		}
+
+		if a.Path != b.Path {
+			return a.Path < b.Path
+		}
+
+		return slices.Index(methodOrder, a.Method) < slices.Index(methodOrder, b.Method)
+	})
+
+	return ops
+}
+
+func (o OperationRef) tag() string {
+	if len(o.Tags) == 0 {
+		return ""
+	}
+	return o.Tags[0]
+}
+
+// ContentTypes lists the content types the request body accepts.
+func (b *RequestBody) ContentTypes() []string {
+	var types []string
+	for t := range b.Content {
+		types = append(types, t)
+	}
+
+	sort.Strings(types)
+
+	return types
+}
@@ -0,0 +1,166 @@
+package validation
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"slices"
+	"strings"
+
+	"github.com/zeze322/wt-guided-weaponry/lib"
+	"github.com/zeze322/wt-guided-weaponry/models"
+)
+
+// Categories lists the categories a weapon may belong to. mongodb.Store
+// satisfies it.
+type Categories interface {
+	Categories(context.Context) ([]models.Category, error)
+}
+
+// Weapon checks params before it is stored. It returns one FieldError per
+// problem found, or nil if params is valid. The error is only set when the
+// categories can't be loaded.
+func Weapon(ctx context.Context, categories Categories, params *models.Params) ([]lib.FieldError, error) {
+	var errs []lib.FieldError
+
+	if strings.TrimSpace(params.Name) == "" {
+		errs = append(errs, lib.FieldError{Field: "name", Msg: "name is required"})
+	}
+
+	known, err := categories.Categories(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	switch {
+	case params.Category == "":
+		errs = append(errs, lib.FieldError{Field: "category", Msg: "category is required"})
+	case !slices.ContainsFunc(known, func(c models.Category) bool { return c.Slug == params.Category }):
+		errs = append(errs, lib.FieldError{Field: "category", Msg: fmt.Sprintf("unknown category %q", params.Category)})
+	default:
+		for _, field := range models.RequiredFor(params.Category) {
+			if field.Value(params) == nil {
+				errs = append(errs, lib.FieldError{Field: field.Key, Msg: fmt.Sprintf("%s is required", field.Label)})
+			}
+		}
+	}
+
+	for _, field := range models.Fields() {
+		v := field.Value(params)
+		if v == nil {
+			continue
+		}
+
+		if msg := checkValue(field, v); msg != "" {
+			errs = append(errs, lib.FieldError{Field: field.Key, Msg: msg})
+		}
+	}
+
+	return errs, nil
+}
+
+func checkValue(field models.Field, v *models.Value) string {
+	switch field.Kind {
+	case models.KindBool:
+		if v.Kind != models.KindBool {
+			return fmt.Sprintf("%s must be Yes or No, got %q", field.Label, v.Text)
+		}
+	case models.KindNumber:
+		if v.Kind != models.KindNumber && v.Kind != models.KindRange && v.Kind != models.KindTuple {
+			return fmt.Sprintf("%s must be a number in %s, got %q", field.Label, field.Unit, v.Text)
+		}
+
+		if v.Unit != "" && v.Unit != field.Unit {
+			return fmt.Sprintf("%s must be in %s, got %s", field.Label, field.Unit, v.Unit)
+		}
+
+		for _, f := range magnitudes(v) {
+			if math.IsInf(f, 0) || math.IsNaN(f) {
+				return fmt.Sprintf("%s must be a finite number, got %q", field.Label, v.Text)
+			}
+		}
+
+		if field.Limits == nil {
+			return ""
+		}
+
+		for _, f := range magnitudes(v) {
+			if !field.Limits.Contains(f) {
+				return fmt.Sprintf("%s must be within %s %s, got %g", field.Label, field.Limits, field.Unit, f)
+			}
+		}
+	}
+
+	return ""
+}
+
+// Category checks category before it is stored. It returns one FieldError
+// per problem found, or nil if category is valid.
+func Category(category *models.Category) []lib.FieldError {
+	var errs []lib.FieldError
+
+	// The slug goes into URLs and query strings, so it has the form of the
+	// weapon slugs.
+	switch {
+	case category.Slug == "":
+		errs = append(errs, lib.FieldError{Field: "slug", Msg: "slug is required"})
+	case models.Slug(category.Slug) != category.Slug:
+		errs = append(errs, lib.FieldError{Field: "slug", Msg: fmt.Sprintf("slug must be lowercase letters and digits separated by dashes, e.g. %q", models.Slug(category.Slug))})
+	}
+
+	if category.Name == "" {
+		errs = append(errs, lib.FieldError{Field: "name", Msg: "name is required"})
+	}
+
+	return errs
+}
+
+// magnitudes returns every number held by v.
+func magnitudes(v *models.Value) []float64 {
+	switch v.Kind {
+	case models.KindRange:
+		return v.Range
+	case models.KindTuple:
+		return v.Tuple
+	default:
+		return []float64{v.Magnitude}
+	}
+}
+
+// serverKeys are the keys of a weapon the store sets. They are accepted so a
+// weapon read from the API can be sent back as it is, and otherwise ignored.
+var serverKeys = map[string]bool{"slug": true, "deleted": true}
+
+// Keys rejects keys of a weapon document that don't map onto a parameter,
+// which decoding into models.Params would otherwise drop silently.
+func Keys(doc []byte) error {
+	var weapon map[string]json.RawMessage
+	if err := json.Unmarshal(doc, &weapon); err != nil {
+		return err
+	}
+
+	groups := map[string]bool{}
+	for _, field := range models.Fields() {
+		groups[field.Key[:strings.Index(field.Key, ".")]] = true
+	}
+
+	for key, raw := range weapon {
+		if key == "name" || key == "category" || serverKeys[key] {
+			continue
+		}
+
+		var group map[string]json.RawMessage
+		if err := json.Unmarshal(raw, &group); err != nil || !groups[key] {
+			return fmt.Errorf("unknown field %s", key)
+		}
+
+		for name := range group {
+			if _, ok := models.FieldByKey(key + "." + name); !ok {
+				return fmt.Errorf("unknown field %s.%s", key, name)
+			}
+		}
+	}
+
+	return nil
+}
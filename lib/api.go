@@ -8,8 +8,15 @@ import (
 )
 
 type APIError struct {
-	StatusCode int    `json:"statusCode"`
-	Msg        string `json:"msg"`
+	StatusCode int          `json:"statusCode"`
+	Msg        string       `json:"msg"`
+	Errors     []FieldError `json:"errors,omitempty"`
+}
+
+// FieldError describes a problem with a single field of a request.
+type FieldError struct {
+	Field string `json:"field"`
+	Msg   string `json:"msg"`
 }
 
 func (e APIError) Error() string {
@@ -27,12 +34,24 @@ func InvalidRequest(s string) APIError {
 	return NewApiError(http.StatusBadRequest, fmt.Errorf("nothing found for %s", s))
 }
 
-func InvalidInsertData(s string) APIError {
-	return NewApiError(http.StatusBadRequest, fmt.Errorf("%s already exists", s))
+func NotFound(s string) APIError {
+	return NewApiError(http.StatusNotFound, fmt.Errorf("%s doesn't exist", s))
+}
+
+func Conflict(s string) APIError {
+	return NewApiError(http.StatusConflict, fmt.Errorf("%s already exists", s))
 }
 
-func InvalidUpdateData(s string) APIError {
-	return NewApiError(http.StatusBadRequest, fmt.Errorf("%s doesn't exist", s))
+func MethodNotAllowed(method string) APIError {
+	return NewApiError(http.StatusMethodNotAllowed, fmt.Errorf("method not allowed: %s", method))
+}
+
+func InvalidFields(errs []FieldError) APIError {
+	return APIError{
+		StatusCode: http.StatusBadRequest,
+		Msg:        "invalid fields",
+		Errors:     errs,
+	}
 }
 
 type APIFunc func(w http.ResponseWriter, r *http.Request) error
@@ -40,20 +59,25 @@ type APIFunc func(w http.ResponseWriter, r *http.Request) error
 func MakeHTTP(fn APIFunc) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		if err := fn(w, r); err != nil {
-			if apiErr, ok := err.(APIError); ok {
-				WriteJSON(w, apiErr.StatusCode, apiErr)
-			} else {
-				errResp := map[string]any{
-					"statusCode": http.StatusInternalServerError,
-					"msg":        "internal server error",
-				}
-				WriteJSON(w, http.StatusInternalServerError, errResp)
-			}
+			apiErr := toAPIError(err)
+			WriteJSON(w, apiErr.StatusCode, apiErr)
 			slog.Error("API error", "err", err.Error(), "path", r.URL.Path)
 		}
 	}
 }
 
+// toAPIError hides errors that aren't an APIError behind a generic 500.
+func toAPIError(err error) APIError {
+	if apiErr, ok := err.(APIError); ok {
+		return apiErr
+	}
+
+	return APIError{
+		StatusCode: http.StatusInternalServerError,
+		Msg:        "internal server error",
+	}
+}
+
 func WriteJSON(w http.ResponseWriter, status int, v any) error {
 	w.Header().Set("Content-Type", "application/json; charset=UTF-8")
 	w.WriteHeader(status)
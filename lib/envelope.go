@@ -0,0 +1,30 @@
+package lib
+
+import (
+	"log/slog"
+	"net/http"
+)
+
+// Envelope is the body of every versioned API response. Successful responses
+// carry Data, failed ones carry Error.
+type Envelope struct {
+	Data  any       `json:"data,omitempty"`
+	Meta  any       `json:"meta,omitempty"`
+	Error *APIError `json:"error,omitempty"`
+}
+
+// WriteData writes data wrapped in an Envelope.
+func WriteData(w http.ResponseWriter, status int, data any) error {
+	return WriteJSON(w, status, Envelope{Data: data})
+}
+
+// MakeAPI is MakeHTTP for the versioned API: errors are wrapped in an Envelope.
+func MakeAPI(fn APIFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if err := fn(w, r); err != nil {
+			apiErr := toAPIError(err)
+			WriteJSON(w, apiErr.StatusCode, Envelope{Error: &apiErr})
+			slog.Error("API error", "err", err.Error(), "path", r.URL.Path)
+		}
+	}
+}
@@ -0,0 +1,359 @@
+package lib
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+const (
+	MergePatchType = "application/merge-patch+json"
+	JSONPatchType  = "application/json-patch+json"
+)
+
+// MergePatch applies an RFC 7386 merge patch to doc and returns the result.
+func MergePatch(doc, patch []byte) ([]byte, error) {
+	var target, p any
+
+	if err := json.Unmarshal(doc, &target); err != nil {
+		return nil, err
+	}
+
+	if err := json.Unmarshal(patch, &p); err != nil {
+		return nil, fmt.Errorf("invalid merge patch: %s", err)
+	}
+
+	return json.Marshal(mergePatch(target, p))
+}
+
+func mergePatch(target, patch any) any {
+	p, ok := patch.(map[string]any)
+	if !ok {
+		return patch
+	}
+
+	t, ok := target.(map[string]any)
+	if !ok {
+		t = map[string]any{}
+	}
+
+	for k, v := range p {
+		if v == nil {
+			delete(t, k)
+			continue
+		}
+
+		t[k] = mergePatch(t[k], v)
+	}
+
+	return t
+}
+
+// PatchOperation is a single RFC 6902 JSON Patch operation.
+type PatchOperation struct {
+	Op    string          `json:"op"`
+	Path  string          `json:"path"`
+	From  string          `json:"from,omitempty"`
+	Value json.RawMessage `json:"value,omitempty"`
+}
+
+// JSONPatch applies an RFC 6902 JSON Patch to doc and returns the result.
+// Operations are applied in order and the whole patch fails if any of them does.
+func JSONPatch(doc, patch []byte) ([]byte, error) {
+	var target any
+	if err := json.Unmarshal(doc, &target); err != nil {
+		return nil, err
+	}
+
+	var ops []PatchOperation
+	if err := json.Unmarshal(patch, &ops); err != nil {
+		return nil, fmt.Errorf("invalid json patch: %s", err)
+	}
+
+	for i, op := range ops {
+		var err error
+
+		target, err = applyOperation(target, op)
+		if err != nil {
+			return nil, fmt.Errorf("operation %d (%s %s): %s", i, op.Op, op.Path, err)
+		}
+	}
+
+	return json.Marshal(target)
+}
+
+func applyOperation(doc any, op PatchOperation) (any, error) {
+	switch op.Op {
+	case "add", "replace", "test":
+		if op.Value == nil {
+			return nil, fmt.Errorf("missing value")
+		}
+
+		var value any
+		if err := json.Unmarshal(op.Value, &value); err != nil {
+			return nil, err
+		}
+
+		switch op.Op {
+		case "add":
+			return addValue(doc, op.Path, value)
+		case "replace":
+			if _, err := getValue(doc, op.Path); err != nil {
+				return nil, err
+			}
+
+			if op.Path == "" {
+				return value, nil
+			}
+
+			doc, _, err := removeValue(doc, op.Path)
+			if err != nil {
+				return nil, err
+			}
+
+			return addValue(doc, op.Path, value)
+		default:
+			current, err := getValue(doc, op.Path)
+			if err != nil {
+				return nil, err
+			}
+
+			if !reflect.DeepEqual(current, value) {
+				return nil, fmt.Errorf("test failed")
+			}
+
+			return doc, nil
+		}
+	case "remove":
+		doc, _, err := removeValue(doc, op.Path)
+		return doc, err
+	case "move":
+		if op.Path == op.From || strings.HasPrefix(op.Path, op.From+"/") {
+			return nil, fmt.Errorf("can't move a value into itself")
+		}
+
+		doc, value, err := removeValue(doc, op.From)
+		if err != nil {
+			return nil, err
+		}
+
+		return addValue(doc, op.Path, value)
+	case "copy":
+		value, err := getValue(doc, op.From)
+		if err != nil {
+			return nil, err
+		}
+
+		return addValue(doc, op.Path, deepCopy(value))
+	default:
+		return nil, fmt.Errorf("unknown operation")
+	}
+}
+
+// splitPointer splits an RFC 6901 JSON pointer into its unescaped tokens.
+func splitPointer(pointer string) ([]string, error) {
+	if pointer == "" {
+		return nil, nil
+	}
+
+	if !strings.HasPrefix(pointer, "/") {
+		return nil, fmt.Errorf("invalid pointer %q", pointer)
+	}
+
+	tokens := strings.Split(pointer[1:], "/")
+	for i, token := range tokens {
+		tokens[i] = strings.NewReplacer("~1", "/", "~0", "~").Replace(token)
+	}
+
+	return tokens, nil
+}
+
+func arrayIndex(token string, length int, appending bool) (int, error) {
+	if appending && token == "-" {
+		return length, nil
+	}
+
+	i, err := strconv.Atoi(token)
+	if err != nil || i < 0 || (token != "0" && strings.HasPrefix(token, "0")) {
+		return 0, fmt.Errorf("invalid array index %q", token)
+	}
+
+	if i > length || (!appending && i == length) {
+		return 0, fmt.Errorf("array index %d out of range", i)
+	}
+
+	return i, nil
+}
+
+func getValue(doc any, pointer string) (any, error) {
+	tokens, err := splitPointer(pointer)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, token := range tokens {
+		switch node := doc.(type) {
+		case map[string]any:
+			v, ok := node[token]
+			if !ok {
+				return nil, fmt.Errorf("%s doesn't exist", pointer)
+			}
+			doc = v
+		case []any:
+			i, err := arrayIndex(token, len(node), false)
+			if err != nil {
+				return nil, err
+			}
+			doc = node[i]
+		default:
+			return nil, fmt.Errorf("%s doesn't exist", pointer)
+		}
+	}
+
+	return doc, nil
+}
+
+// parentOf resolves every token of pointer but the last one.
+func parentOf(doc any, pointer string) (any, string, error) {
+	tokens, err := splitPointer(pointer)
+	if err != nil {
+		return nil, "", err
+	}
+
+	if len(tokens) == 0 {
+		return nil, "", nil
+	}
+
+	parent := "/" + strings.Join(escapeTokens(tokens[:len(tokens)-1]), "/")
+	if len(tokens) == 1 {
+		parent = ""
+	}
+
+	node, err := getValue(doc, parent)
+	if err != nil {
+		return nil, "", err
+	}
+
+	return node, tokens[len(tokens)-1], nil
+}
+
+func escapeTokens(tokens []string) []string {
+	escaped := make([]string, len(tokens))
+	for i, token := range tokens {
+		escaped[i] = strings.NewReplacer("~", "~0", "/", "~1").Replace(token)
+	}
+
+	return escaped
+}
+
+// setValue replaces the node at pointer, which must be a container already
+// present in doc, and returns the new root.
+func setValue(doc any, pointer string, value any) (any, error) {
+	parent, last, err := parentOf(doc, pointer)
+	if err != nil {
+		return nil, err
+	}
+
+	if pointer == "" {
+		return value, nil
+	}
+
+	switch node := parent.(type) {
+	case map[string]any:
+		node[last] = value
+	case []any:
+		i, err := arrayIndex(last, len(node), false)
+		if err != nil {
+			return nil, err
+		}
+		node[i] = value
+	}
+
+	return doc, nil
+}
+
+func addValue(doc any, pointer string, value any) (any, error) {
+	parent, last, err := parentOf(doc, pointer)
+	if err != nil {
+		return nil, err
+	}
+
+	if pointer == "" {
+		return value, nil
+	}
+
+	switch node := parent.(type) {
+	case map[string]any:
+		node[last] = value
+		return doc, nil
+	case []any:
+		i, err := arrayIndex(last, len(node), true)
+		if err != nil {
+			return nil, err
+		}
+
+		grown := append(node[:i:i], append([]any{value}, node[i:]...)...)
+
+		return setValue(doc, pointer[:strings.LastIndex(pointer, "/")], grown)
+	default:
+		return nil, fmt.Errorf("%s has no parent", pointer)
+	}
+}
+
+func removeValue(doc any, pointer string) (any, any, error) {
+	if pointer == "" {
+		return nil, nil, fmt.Errorf("can't remove the whole document")
+	}
+
+	parent, last, err := parentOf(doc, pointer)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	switch node := parent.(type) {
+	case map[string]any:
+		value, ok := node[last]
+		if !ok {
+			return nil, nil, fmt.Errorf("%s doesn't exist", pointer)
+		}
+
+		delete(node, last)
+
+		return doc, value, nil
+	case []any:
+		i, err := arrayIndex(last, len(node), false)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		value := node[i]
+		shrunk := append(node[:i:i], node[i+1:]...)
+
+		doc, err := setValue(doc, pointer[:strings.LastIndex(pointer, "/")], shrunk)
+
+		return doc, value, err
+	default:
+		return nil, nil, fmt.Errorf("%s doesn't exist", pointer)
+	}
+}
+
+func deepCopy(v any) any {
+	switch node := v.(type) {
+	case map[string]any:
+		c := make(map[string]any, len(node))
+		for k, v := range node {
+			c[k] = deepCopy(v)
+		}
+		return c
+	case []any:
+		c := make([]any, len(node))
+		for i, v := range node {
+			c[i] = deepCopy(v)
+		}
+		return c
+	default:
+		return v
+	}
+}
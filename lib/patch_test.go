@@ -0,0 +1,172 @@
+package lib
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+// sameJSON reports whether a and b hold the same JSON value.
+func sameJSON(t *testing.T, a, b string) bool {
+	t.Helper()
+
+	var va, vb any
+	if err := json.Unmarshal([]byte(a), &va); err != nil {
+		t.Fatalf("%s: %v", a, err)
+	}
+	if err := json.Unmarshal([]byte(b), &vb); err != nil {
+		t.Fatalf("%s: %v", b, err)
+	}
+
+	return reflect.DeepEqual(va, vb)
+}
+
+// TestMergePatch runs the examples of RFC 7386, appendix A.
+func TestMergePatch(t *testing.T) {
+	tests := []struct {
+		doc, patch, want string
+	}{
+		{`{"a":"b"}`, `{"a":"c"}`, `{"a":"c"}`},
+		{`{"a":"b"}`, `{"b":"c"}`, `{"a":"b","b":"c"}`},
+		{`{"a":"b"}`, `{"a":null}`, `{}`},
+		{`{"a":"b","b":"c"}`, `{"a":null}`, `{"b":"c"}`},
+		{`{"a":["b"]}`, `{"a":"c"}`, `{"a":"c"}`},
+		{`{"a":"c"}`, `{"a":["b"]}`, `{"a":["b"]}`},
+		{`{"a":{"b":"c"}}`, `{"a":{"b":"d","c":null}}`, `{"a":{"b":"d"}}`},
+		{`{"a":[{"b":"c"}]}`, `{"a":[1]}`, `{"a":[1]}`},
+		{`["a","b"]`, `["c","d"]`, `["c","d"]`},
+		{`{"a":"b"}`, `["c"]`, `["c"]`},
+		{`{"a":"foo"}`, `null`, `null`},
+		{`{"a":"foo"}`, `"bar"`, `"bar"`},
+		{`{"e":null}`, `{"a":1}`, `{"e":null,"a":1}`},
+		{`[1,2]`, `{"a":"b","c":null}`, `{"a":"b"}`},
+		{`{}`, `{"a":{"bb":{"ccc":null}}}`, `{"a":{"bb":{}}}`},
+	}
+
+	for _, tt := range tests {
+		got, err := MergePatch([]byte(tt.doc), []byte(tt.patch))
+		if err != nil {
+			t.Errorf("MergePatch(%s, %s): %v", tt.doc, tt.patch, err)
+			continue
+		}
+
+		if !sameJSON(t, string(got), tt.want) {
+			t.Errorf("MergePatch(%s, %s) = %s, want %s", tt.doc, tt.patch, got, tt.want)
+		}
+	}
+
+	if _, err := MergePatch([]byte(`{}`), []byte(`{`)); err == nil {
+		t.Error("MergePatch accepted an invalid patch")
+	}
+}
+
+// TestJSONPatch runs the examples of RFC 6902, appendix A, and a few
+// more edge cases.
+func TestJSONPatch(t *testing.T) {
+	tests := []struct {
+		name, doc, patch, want string
+	}{
+		{"A.1 add an object member", `{"foo":"bar"}`,
+			`[{"op":"add","path":"/baz","value":"qux"}]`,
+			`{"baz":"qux","foo":"bar"}`},
+		{"A.2 add an array element", `{"foo":["bar","baz"]}`,
+			`[{"op":"add","path":"/foo/1","value":"qux"}]`,
+			`{"foo":["bar","qux","baz"]}`},
+		{"A.3 remove an object member", `{"baz":"qux","foo":"bar"}`,
+			`[{"op":"remove","path":"/baz"}]`,
+			`{"foo":"bar"}`},
+		{"A.4 remove an array element", `{"foo":["bar","qux","baz"]}`,
+			`[{"op":"remove","path":"/foo/1"}]`,
+			`{"foo":["bar","baz"]}`},
+		{"A.5 replace a value", `{"baz":"qux","foo":"bar"}`,
+			`[{"op":"replace","path":"/baz","value":"boo"}]`,
+			`{"baz":"boo","foo":"bar"}`},
+		{"A.6 move a value", `{"foo":{"bar":"baz","waldo":"fred"},"qux":{"corge":"grault"}}`,
+			`[{"op":"move","from":"/foo/waldo","path":"/qux/thud"}]`,
+			`{"foo":{"bar":"baz"},"qux":{"corge":"grault","thud":"fred"}}`},
+		{"A.7 move an array element", `{"foo":["all","grass","cows","eat"]}`,
+			`[{"op":"move","from":"/foo/1","path":"/foo/3"}]`,
+			`{"foo":["all","cows","eat","grass"]}`},
+		{"A.8 test a value", `{"baz":"qux","foo":["a",2,"c"]}`,
+			`[{"op":"test","path":"/baz","value":"qux"},{"op":"test","path":"/foo/1","value":2}]`,
+			`{"baz":"qux","foo":["a",2,"c"]}`},
+		{"A.10 add a nested member object", `{"foo":"bar"}`,
+			`[{"op":"add","path":"/child","value":{"grandchild":{}}}]`,
+			`{"foo":"bar","child":{"grandchild":{}}}`},
+		{"A.11 ignore unrecognized elements", `{"foo":"bar"}`,
+			`[{"op":"add","path":"/baz","value":"qux","xyz":123}]`,
+			`{"foo":"bar","baz":"qux"}`},
+		{"A.14 ~ escape ordering", `{"/":9,"~1":10}`,
+			`[{"op":"test","path":"/~01","value":10}]`,
+			`{"/":9,"~1":10}`},
+		{"A.16 add an array value", `{"foo":["bar"]}`,
+			`[{"op":"add","path":"/foo/-","value":["abc","def"]}]`,
+			`{"foo":["bar",["abc","def"]]}`},
+		{"add a null value", `{"foo":"bar"}`,
+			`[{"op":"add","path":"/baz","value":null}]`,
+			`{"foo":"bar","baz":null}`},
+		{"add replaces the whole document", `{"foo":"bar"}`,
+			`[{"op":"add","path":"","value":[1]}]`,
+			`[1]`},
+		{"unescape / in a pointer", `{"a/b":1}`,
+			`[{"op":"replace","path":"/a~1b","value":2}]`,
+			`{"a/b":2}`},
+		{"copy a value", `{"foo":{"bar":[1]}}`,
+			`[{"op":"copy","from":"/foo","path":"/baz"},{"op":"add","path":"/baz/bar/-","value":2}]`,
+			`{"foo":{"bar":[1]},"baz":{"bar":[1,2]}}`},
+		{"move to a sibling with the same prefix", `{"foo":1}`,
+			`[{"op":"move","from":"/foo","path":"/foobar"}]`,
+			`{"foobar":1}`},
+	}
+
+	for _, tt := range tests {
+		got, err := JSONPatch([]byte(tt.doc), []byte(tt.patch))
+		if err != nil {
+			t.Errorf("%s: %v", tt.name, err)
+			continue
+		}
+
+		if !sameJSON(t, string(got), tt.want) {
+			t.Errorf("%s: got %s, want %s", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestJSONPatchErrors(t *testing.T) {
+	tests := []struct {
+		name, doc, patch string
+	}{
+		{"A.9 test a value error", `{"baz":"qux"}`,
+			`[{"op":"test","path":"/baz","value":"bar"}]`},
+		{"A.12 add to a nonexistent target", `{"foo":"bar"}`,
+			`[{"op":"add","path":"/baz/bat","value":"qux"}]`},
+		{"A.15 compare strings and numbers", `{"/":9,"~1":10}`,
+			`[{"op":"test","path":"/~01","value":"10"}]`},
+		{"move a value into itself", `{"foo":{"bar":1}}`,
+			`[{"op":"move","from":"/foo","path":"/foo/bar/baz"}]`},
+		{"remove a missing member", `{"foo":"bar"}`,
+			`[{"op":"remove","path":"/baz"}]`},
+		{"remove the whole document", `{"foo":"bar"}`,
+			`[{"op":"remove","path":""}]`},
+		{"replace a missing member", `{"foo":"bar"}`,
+			`[{"op":"replace","path":"/baz","value":1}]`},
+		{"add past the end of an array", `{"foo":[1]}`,
+			`[{"op":"add","path":"/foo/2","value":2}]`},
+		{"array index with a leading zero", `{"foo":[1,2]}`,
+			`[{"op":"remove","path":"/foo/01"}]`},
+		{"pointer without a leading slash", `{"foo":1}`,
+			`[{"op":"remove","path":"foo"}]`},
+		{"add without a value", `{"foo":1}`,
+			`[{"op":"add","path":"/bar"}]`},
+		{"unknown operation", `{"foo":1}`,
+			`[{"op":"merge","path":"/foo","value":1}]`},
+		{"not an array", `{"foo":1}`,
+			`{"op":"remove","path":"/foo"}`},
+	}
+
+	for _, tt := range tests {
+		if got, err := JSONPatch([]byte(tt.doc), []byte(tt.patch)); err == nil {
+			t.Errorf("%s: got %s, want an error", tt.name, got)
+		}
+	}
+}
@@ -0,0 +1,67 @@
+package models
+
+const (
+	CategoryIRRearAspect   = "ir-rear-aspect"
+	CategoryIRAllAspect    = "ir-all-aspect"
+	CategoryIRHeli         = "ir-heli"
+	CategoryAAMSARH        = "aam-sarh"
+	CategoryAAMARH         = "aam-arh"
+	CategoryAAMMCLOSLOSBR  = "aam-mclos-losbr"
+	CategoryAGMAutomatic   = "agm-automatic"
+	CategoryAGMSALH        = "agm-salh"
+	CategoryAGMSACLOS      = "agm-saclos"
+	CategoryAGMMCLOS       = "agm-mclos"
+	CategoryAGMLOSBR       = "agm-losbr"
+	CategoryGBU            = "gbu"
+	CategorySAMIR          = "sam-ir"
+	CategorySAMSACLOSLOSBR = "sam-saclos-losbr"
+	CategoryATGMMCLOS      = "atgm-mclos"
+	CategoryATGMSACLOS     = "atgm-saclos"
+	CategoryATGMLOSBR      = "atgm-losbr"
+	CategoryATGMAutomatic  = "atgm-automatic"
+	CategoryAShM           = "ashm"
+)
+
+type Class string
+
+const (
+	ClassAAM  Class = "AAM"
+	ClassAGM  Class = "AGM"
+	ClassSAM  Class = "SAM"
+	ClassATGM Class = "ATGM"
+	ClassGBU  Class = "GBU"
+	ClassAShM Class = "AShM"
+)
+
+// Category groups weapons that are compared in the same table.
+type Category struct {
+	Slug        string `json:"slug" bson:"slug"`
+	Name        string `json:"name" bson:"name"`
+	Class       Class  `json:"class" bson:"class"`
+	Guidance    string `json:"guidance" bson:"guidance"`
+	SortOrder   int    `json:"sortOrder" bson:"sortorder"`
+	Description string `json:"description" bson:"description"`
+}
+
+// DefaultCategories are the categories a fresh store is seeded with.
+var DefaultCategories = []Category{
+	{CategoryIRRearAspect, "AAM (IR rear-aspect)", ClassAAM, "IR", 10, "Infrared air-to-air missiles that only lock onto a target's exhaust."},
+	{CategoryIRAllAspect, "AAM (IR all-aspect)", ClassAAM, "IR", 20, "Infrared air-to-air missiles that lock onto a target from any aspect."},
+	{CategoryIRHeli, "AAM (IR heli)", ClassAAM, "IR", 30, "Infrared air-to-air missiles carried by helicopters."},
+	{CategoryAAMSARH, "AAM (SARH)", ClassAAM, "SARH", 40, "Air-to-air missiles homing on radar energy reflected from the target."},
+	{CategoryAAMARH, "AAM (ARH)", ClassAAM, "ARH", 50, "Air-to-air missiles with their own radar seeker."},
+	{CategoryAAMMCLOSLOSBR, "AAM (MCLOS/LOSBR)", ClassAAM, "MCLOS/LOSBR", 60, "Air-to-air missiles steered manually or riding a beam."},
+	{CategoryAGMAutomatic, "AGM (Automatic)", ClassAGM, "Automatic", 70, "Air-to-ground missiles that home on their own after launch."},
+	{CategoryAGMSALH, "AGM (SALH)", ClassAGM, "SALH", 80, "Air-to-ground missiles homing on a laser spot."},
+	{CategoryAGMSACLOS, "AGM (SACLOS)", ClassAGM, "SACLOS", 90, "Air-to-ground missiles guided to the crosshair by the launcher."},
+	{CategoryAGMMCLOS, "AGM (MCLOS)", ClassAGM, "MCLOS", 100, "Air-to-ground missiles steered manually."},
+	{CategoryAGMLOSBR, "AGM (LOSBR)", ClassAGM, "LOSBR", 110, "Air-to-ground missiles riding a beam."},
+	{CategoryGBU, "GBU", ClassGBU, "Various", 120, "Guided bombs."},
+	{CategorySAMIR, "SAM (IR)", ClassSAM, "IR", 130, "Infrared surface-to-air missiles."},
+	{CategorySAMSACLOSLOSBR, "SAM (SACLOS/LOSBR)", ClassSAM, "SACLOS/LOSBR", 140, "Surface-to-air missiles guided by the launcher or riding a beam."},
+	{CategoryATGMMCLOS, "ATGM (MCLOS)", ClassATGM, "MCLOS", 150, "Anti-tank missiles steered manually."},
+	{CategoryATGMSACLOS, "ATGM (SACLOS)", ClassATGM, "SACLOS", 160, "Anti-tank missiles guided to the crosshair by the launcher."},
+	{CategoryATGMLOSBR, "ATGM (LOSBR)", ClassATGM, "LOSBR", 170, "Anti-tank missiles riding a beam."},
+	{CategoryATGMAutomatic, "ATGM (Automatic)", ClassATGM, "Automatic", 180, "Anti-tank missiles that home on their own after launch."},
+	{CategoryAShM, "AShM", ClassAShM, "Various", 190, "Anti-ship missiles."},
+}
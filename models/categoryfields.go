@@ -0,0 +1,977 @@
+package models
+
+// categoryFields lists the parameters shown for each category, in display order.
+var categoryFields = map[string][]string{
+	CategoryIRRearAspect: {
+		"physicalProp.mass",
+		"physicalProp.massAtEndOfBoosterBurn",
+		"physicalProp.massAtEndOfSustainerBurn",
+		"physicalProp.calibre",
+		"physicalProp.length",
+		"engineProp.forceExertedByBooster",
+		"engineProp.burnTimeOfBooster",
+		"engineProp.rawAccelerationAtIgnition",
+		"engineProp.specificImpulseOfBooster",
+		"engineProp.deltaSpeedOfBooster",
+		"engineProp.boosterStartDelay",
+		"engineProp.forceExertedBySustainer",
+		"engineProp.burnTimeOfSustainer",
+		"engineProp.specificImpulseOfSustainer",
+		"engineProp.deltaSpeedOfSustainer",
+		"engineProp.totalDeltaSpeed",
+		"fuseAndWarheadProp.explosiveMass",
+		"fuseAndWarheadProp.proximityFuse",
+		"fuseAndWarheadProp.proximityFuseRange",
+		"fuseAndWarheadProp.proximityFuseShellDetection",
+		"fuseAndWarheadProp.proximityFuseDelay",
+		"guidanceProp.guidanceType",
+		"guidanceProp.guidanceStartDelay",
+		"guidanceProp.guidanceDuration",
+		"guidanceProp.seekerWarmUpTime",
+		"guidanceProp.seekerSearchDuration",
+		"guidanceProp.fieldOfView",
+		"guidanceProp.gimbalLimit",
+		"guidanceProp.trackRate",
+		"guidanceProp.uncageSeekerBeforeLaunch",
+		"guidanceProp.maximumLockAngleBeforeLaunch",
+		"guidanceProp.minimumAngleBetweenSeekerAndSunForNotCapture",
+		"guidanceProp.lockOnRangeFromRearAspect",
+		"guidanceProp.flareDetectionRange",
+		"guidanceProp.DIRCMDetectionRange",
+		"guidanceProp.headOnLockOnRangeAgainstAfterburnerTarget",
+		"guidanceProp.maximumBreakLockTime",
+		"guidanceProp.canBeSlavedToRadar",
+		"guidanceProp.proportionalNavigationMultiplier",
+		"guidanceProp.baseIndicatedAirSpeed",
+		"guidanceProp.PIDProportionalTerm",
+		"guidanceProp.PIDIntegralTerm",
+		"guidanceProp.PIDIntegralTermLimit",
+		"guidanceProp.PIDDerivativeTerm",
+		"flightProp.maximumFinAngleOfAttack",
+		"flightProp.maximumFinLateralAcceleration",
+		"flightProp.wingAreaMultiplier",
+		"flightProp.startSpeed",
+		"flightProp.maximumSpeed",
+		"flightProp.flightTimeUntilGuidanceStarts",
+		"flightProp.flightTimeWhenPullLimit40%",
+		"flightProp.flightTimeWhenPullLimit100%",
+		"flightProp.minimumRange",
+		"flightProp.maximumFlightRange",
+		"flightProp.maximumOverLoad",
+		"flightProp.thrustVectoring",
+		"flightProp.thrustVectoringAngle",
+	},
+	CategoryIRAllAspect: {
+		"physicalProp.mass",
+		"physicalProp.massAtEndOfBoosterBurn",
+		"physicalProp.massAtEndOfSustainerBurn",
+		"physicalProp.calibre",
+		"physicalProp.length",
+		"engineProp.forceExertedByBooster",
+		"engineProp.burnTimeOfBooster",
+		"engineProp.rawAccelerationAtIgnition",
+		"engineProp.specificImpulseOfBooster",
+		"engineProp.deltaSpeedOfBooster",
+		"engineProp.boosterStartDelay",
+		"engineProp.forceExertedBySustainer",
+		"engineProp.burnTimeOfSustainer",
+		"engineProp.specificImpulseOfSustainer",
+		"engineProp.deltaSpeedOfSustainer",
+		"engineProp.totalDeltaSpeed",
+		"fuseAndWarheadProp.explosiveMass",
+		"fuseAndWarheadProp.proximityFuse",
+		"fuseAndWarheadProp.proximityFuseRange",
+		"fuseAndWarheadProp.proximityFuseShellDetection",
+		"fuseAndWarheadProp.proximityFuseDelay",
+		"guidanceProp.guidanceType",
+		"guidanceProp.guidanceStartDelay",
+		"guidanceProp.guidanceDuration",
+		"guidanceProp.seekerWarmUpTime",
+		"guidanceProp.seekerSearchDuration",
+		"guidanceProp.fieldOfView",
+		"guidanceProp.gimbalLimit",
+		"guidanceProp.trackRate",
+		"guidanceProp.uncageSeekerBeforeLaunch",
+		"guidanceProp.maximumLockAngleBeforeLaunch",
+		"guidanceProp.minimumAngleBetweenSeekerAndSunForNotCapture",
+		"guidanceProp.lockOnRangeFromRearAspect",
+		"guidanceProp.lockOnRangeFromAllAspect",
+		"guidanceProp.flareDetectionRange",
+		"guidanceProp.IRCMDetectionRange",
+		"guidanceProp.DIRCMDetectionRange",
+		"guidanceProp.headOnLockOnRangeAgainstAfterburnerTarget",
+		"guidanceProp.IRCCM",
+		"guidanceProp.IRCCMType",
+		"guidanceProp.IRCCMFieldOfView",
+		"guidanceProp.IRCCMRejectionThreshold",
+		"guidanceProp.IRCCMReactionTime",
+		"guidanceProp.maximumBreakLockTime",
+		"guidanceProp.canBeSlavedToRadar",
+		"guidanceProp.proportionalNavigationMultiplier",
+		"guidanceProp.baseIndicatedAirSpeed",
+		"guidanceProp.PIDProportionalTerm",
+		"guidanceProp.PIDIntegralTerm",
+		"guidanceProp.PIDIntegralTermLimit",
+		"guidanceProp.PIDDerivativeTerm",
+		"flightProp.maximumFinAngleOfAttack",
+		"flightProp.maximumFinLateralAcceleration",
+		"flightProp.wingAreaMultiplier",
+		"flightProp.startSpeed",
+		"flightProp.maximumSpeed",
+		"flightProp.flightTimeUntilGuidanceStarts",
+		"flightProp.flightTimeWhenPullLimit40%",
+		"flightProp.flightTimeWhenPullLimit100%",
+		"flightProp.minimumRange",
+		"flightProp.maximumFlightRange",
+		"flightProp.maximumOverLoad",
+		"flightProp.thrustVectoring",
+		"flightProp.thrustVectoringAngle",
+	},
+	CategoryIRHeli: {
+		"physicalProp.mass",
+		"physicalProp.massAtEndOfBoosterBurn",
+		"physicalProp.massAtEndOfSustainerBurn",
+		"physicalProp.calibre",
+		"physicalProp.length",
+		"engineProp.forceExertedByBooster",
+		"engineProp.burnTimeOfBooster",
+		"engineProp.rawAccelerationAtIgnition",
+		"engineProp.specificImpulseOfBooster",
+		"engineProp.deltaSpeedOfBooster",
+		"engineProp.boosterStartDelay",
+		"engineProp.forceExertedBySustainer",
+		"engineProp.burnTimeOfSustainer",
+		"engineProp.specificImpulseOfSustainer",
+		"engineProp.deltaSpeedOfSustainer",
+		"engineProp.totalDeltaSpeed",
+		"fuseAndWarheadProp.explosiveMass",
+		"fuseAndWarheadProp.proximityFuse",
+		"fuseAndWarheadProp.proximityFuseRange",
+		"fuseAndWarheadProp.proximityFuseShellDetection",
+		"fuseAndWarheadProp.proximityFuseDelay",
+		"guidanceProp.guidanceType",
+		"guidanceProp.guidanceStartDelay",
+		"guidanceProp.guidanceDuration",
+		"guidanceProp.seekerWarmUpTime",
+		"guidanceProp.seekerSearchDuration",
+		"guidanceProp.fieldOfView",
+		"guidanceProp.gimbalLimit",
+		"guidanceProp.trackRate",
+		"guidanceProp.uncageSeekerBeforeLaunch",
+		"guidanceProp.maximumLockAngleBeforeLaunch",
+		"guidanceProp.minimumAngleBetweenSeekerAndSunForNotCapture",
+		"guidanceProp.lockOnRangeFromRearAspect",
+		"guidanceProp.lockOnRangeFromAllAspect",
+		"guidanceProp.flareDetectionRange",
+		"guidanceProp.IRCMDetectionRange",
+		"guidanceProp.DIRCMDetectionRange",
+		"guidanceProp.IRCCM",
+		"guidanceProp.IRCCMType",
+		"guidanceProp.IRCCMFieldOfView",
+		"guidanceProp.headOnLockOnRangeAgainstAfterburnerTarget",
+		"guidanceProp.maximumBreakLockTime",
+		"guidanceProp.canBeSlavedToRadar",
+		"guidanceProp.proportionalNavigationMultiplier",
+		"guidanceProp.baseIndicatedAirSpeed",
+		"guidanceProp.PIDProportionalTerm",
+		"guidanceProp.PIDIntegralTerm",
+		"guidanceProp.PIDIntegralTermLimit",
+		"guidanceProp.PIDDerivativeTerm",
+		"flightProp.maximumFinAngleOfAttack",
+		"flightProp.maximumFinLateralAcceleration",
+		"flightProp.wingAreaMultiplier",
+		"flightProp.startSpeed",
+		"flightProp.maximumSpeed",
+		"flightProp.minimumRange",
+		"flightProp.maximumFlightRange",
+		"flightProp.maximumOverLoad",
+		"flightProp.thrustVectoring",
+		"flightProp.thrustVectoringAngle",
+	},
+	CategoryAAMSARH: {
+		"physicalProp.mass",
+		"physicalProp.massAtEndOfBoosterBurn",
+		"physicalProp.massAtEndOfSustainerBurn",
+		"physicalProp.calibre",
+		"physicalProp.length",
+		"engineProp.forceExertedByBooster",
+		"engineProp.burnTimeOfBooster",
+		"engineProp.rawAccelerationAtIgnition",
+		"engineProp.specificImpulseOfBooster",
+		"engineProp.deltaSpeedOfBooster",
+		"engineProp.boosterStartDelay",
+		"engineProp.forceExertedBySustainer",
+		"engineProp.burnTimeOfSustainer",
+		"engineProp.specificImpulseOfSustainer",
+		"engineProp.deltaSpeedOfSustainer",
+		"engineProp.totalDeltaSpeed",
+		"fuseAndWarheadProp.explosiveMass",
+		"fuseAndWarheadProp.proximityFuse",
+		"fuseAndWarheadProp.proximityFuseRange",
+		"fuseAndWarheadProp.proximityFuseShellDetection",
+		"fuseAndWarheadProp.proximityFuseDelay",
+		"guidanceProp.guidanceType",
+		"guidanceProp.guidanceStartDelay",
+		"guidanceProp.guidanceDuration",
+		"guidanceProp.seekerWarmUpTime",
+		"guidanceProp.seekerSearchDuration",
+		"guidanceProp.gimbalLimit",
+		"guidanceProp.trackRate",
+		"guidanceProp.uncageSeekerBeforeLaunch",
+		"guidanceProp.maximumLockAngleBeforeLaunch",
+		"guidanceProp.lockOnRangeFromAllAspect",
+		"guidanceProp.maximumBreakLockTime",
+		"guidanceProp.canLockAfterLaunch",
+		"guidanceProp.band",
+		"guidanceProp.angularSpeedRejectionThreshold",
+		"guidanceProp.angularRejectionThresholdRange",
+		"guidanceProp.sidelobeAttenuation",
+		"guidanceProp.transmitterPower",
+		"guidanceProp.transmitterAngleOfHalfSensitivity",
+		"guidanceProp.transmitterSidelobeSensitivity",
+		"guidanceProp.receiverAngleOfHalfSensitivity",
+		"guidanceProp.receiverSidelobeSensitivity",
+		"guidanceProp.distanceMinimumValue",
+		"guidanceProp.distanceMaximumValue",
+		"guidanceProp.distanceWidth",
+		"guidanceProp.distanceMinimumSignalGate",
+		"guidanceProp.distanceRefWidth",
+		"guidanceProp.distanceGateAlphaFilter",
+		"guidanceProp.distanceGateBetaFilter",
+		"guidanceProp.dopplerSpeedMinimumValue",
+		"guidanceProp.dopplerSpeedMaximumValue",
+		"guidanceProp.dopplerSpeedWidth",
+		"guidanceProp.dopplerSpeedRefWidth",
+		"guidanceProp.dopplerSpeedMinimumSignalGate",
+		"guidanceProp.dopplerSpeedGateSearchRange",
+		"guidanceProp.dopplerSpeedGateAlphaFilter",
+		"guidanceProp.dopplerSpeedGateBetaFilter",
+		"guidanceProp.proportionalNavigationMultiplier",
+		"guidanceProp.baseIndicatedAirSpeed",
+		"guidanceProp.PIDProportionalTerm",
+		"guidanceProp.PIDIntegralTerm",
+		"guidanceProp.PIDIntegralTermLimit",
+		"guidanceProp.PIDDerivativeTerm",
+		"flightProp.maximumFinAngleOfAttack",
+		"flightProp.maximumFinLateralAcceleration",
+		"flightProp.wingAreaMultiplier",
+		"flightProp.startSpeed",
+		"flightProp.maximumSpeed",
+		"flightProp.minimumRange",
+		"flightProp.maximumFlightRange",
+		"flightProp.maximumOverLoad",
+		"flightProp.flightTimeUntilGuidanceStarts",
+		"flightProp.flightTimeWhenPullLimit40%",
+		"flightProp.flightTimeWhenPullLimit100%",
+		"flightProp.ETAToImpactWhenPropMultiplierReachesXPercentage30%",
+		"flightProp.ETAToImpactWhenPropMultiplierReachesXPercentage50%",
+		"flightProp.ETAToImpactWhenPropMultiplierReachesXPercentage80%",
+		"flightProp.ETAToImpactWhenPropMultiplierReachesXPercentage90%",
+		"flightProp.ETAToImpactWhenPropMultiplierReachesXPercentage100%",
+		"flightProp.loft",
+		"flightProp.loftAngle",
+		"flightProp.targetElevation",
+		"flightProp.maximumTargetAngularChange",
+	},
+	CategoryAAMARH: {
+		"physicalProp.mass",
+		"physicalProp.massAtEndOfBoosterBurn",
+		"physicalProp.massAtEndOfSustainerBurn",
+		"physicalProp.calibre",
+		"physicalProp.length",
+		"engineProp.forceExertedByBooster",
+		"engineProp.burnTimeOfBooster",
+		"engineProp.rawAccelerationAtIgnition",
+		"engineProp.specificImpulseOfBooster",
+		"engineProp.deltaSpeedOfBooster",
+		"engineProp.boosterStartDelay",
+		"engineProp.forceExertedBySustainer",
+		"engineProp.burnTimeOfSustainer",
+		"engineProp.specificImpulseOfSustainer",
+		"engineProp.deltaSpeedOfSustainer",
+		"engineProp.totalDeltaSpeed",
+		"fuseAndWarheadProp.explosiveMass",
+		"fuseAndWarheadProp.proximityFuse",
+		"fuseAndWarheadProp.proximityFuseRange",
+		"fuseAndWarheadProp.proximityFuseShellDetection",
+		"fuseAndWarheadProp.proximityFuseDelay",
+		"guidanceProp.guidanceType",
+		"guidanceProp.guidanceStartDelay",
+		"guidanceProp.guidanceDuration",
+		"guidanceProp.seekerWarmUpTime",
+		"guidanceProp.seekerSearchDuration",
+		"guidanceProp.gimbalLimit",
+		"guidanceProp.trackRate",
+		"guidanceProp.uncageSeekerBeforeLaunch",
+		"guidanceProp.maximumLockAngleBeforeLaunch",
+		"guidanceProp.lockOnRangeFromAllAspect",
+		"guidanceProp.maximumBreakLockTime",
+		"guidanceProp.canLockAfterLaunch",
+		"guidanceProp.band",
+		"guidanceProp.angularSpeedRejectionThreshold",
+		"guidanceProp.angularRejectionThresholdRange",
+		"guidanceProp.sidelobeAttenuation",
+		"guidanceProp.transmitterPower",
+		"guidanceProp.transmitterAngleOfHalfSensitivity",
+		"guidanceProp.transmitterSidelobeSensitivity",
+		"guidanceProp.receiverAngleOfHalfSensitivity",
+		"guidanceProp.receiverSidelobeSensitivity",
+		"guidanceProp.distanceMinimumValue",
+		"guidanceProp.distanceMaximumValue",
+		"guidanceProp.distanceWidth",
+		"guidanceProp.distanceMinimumSignalGate",
+		"guidanceProp.distanceRefWidth",
+		"guidanceProp.distanceGateSearchRange",
+		"guidanceProp.distanceGateAlphaFilter",
+		"guidanceProp.distanceGateBetaFilter",
+		"guidanceProp.dopplerSpeedMinimumValue",
+		"guidanceProp.dopplerSpeedMaximumValue",
+		"guidanceProp.dopplerSpeedWidth",
+		"guidanceProp.dopplerSpeedRefWidth",
+		"guidanceProp.dopplerSpeedMinimumSignalGate",
+		"guidanceProp.dopplerSpeedGateSearchRange",
+		"guidanceProp.dopplerSpeedGateAlphaFilter",
+		"guidanceProp.dopplerSpeedGateBetaFilter",
+		"guidanceProp.proportionalNavigationMultiplier",
+		"guidanceProp.baseIndicatedAirSpeed",
+		"guidanceProp.PIDProportionalTerm",
+		"guidanceProp.PIDIntegralTerm",
+		"guidanceProp.PIDIntegralTermLimit",
+		"guidanceProp.PIDDerivativeTerm",
+		"guidanceProp.inertialNavigationDriftSpeed",
+		"flightProp.maximumFinAngleOfAttack",
+		"flightProp.maximumFinLateralAcceleration",
+		"flightProp.wingAreaMultiplier",
+		"flightProp.startSpeed",
+		"flightProp.maximumSpeed",
+		"flightProp.minimumRange",
+		"flightProp.maximumFlightRange",
+		"flightProp.maximumOverLoad",
+		"flightProp.flightTimeUntilGuidanceStarts",
+		"flightProp.flightTimeWhenPullLimit40%",
+		"flightProp.flightTimeWhenPullLimit100%",
+		"flightProp.loft",
+		"flightProp.loftAngle",
+		"flightProp.targetElevation",
+		"flightProp.maximumTargetAngularChange",
+		"flightProp.thrustVectoring",
+		"flightProp.thrustVectoringAngle",
+		"flightProp.ETAToImpactWhenPropMultiplierReachesXPercentageX%",
+	},
+	CategoryAAMMCLOSLOSBR: {
+		"physicalProp.mass",
+		"physicalProp.massAtEndOfBoosterBurn",
+		"physicalProp.massAtEndOfSustainerBurn",
+		"physicalProp.calibre",
+		"physicalProp.length",
+		"engineProp.forceExertedByBooster",
+		"engineProp.burnTimeOfBooster",
+		"engineProp.rawAccelerationAtIgnition",
+		"engineProp.specificImpulseOfBooster",
+		"engineProp.deltaSpeedOfBooster",
+		"engineProp.forceExertedBySustainer",
+		"engineProp.burnTimeOfSustainer",
+		"engineProp.specificImpulseOfSustainer",
+		"engineProp.deltaSpeedOfSustainer",
+		"engineProp.totalDeltaSpeed",
+		"fuseAndWarheadProp.explosiveMass",
+		"fuseAndWarheadProp.proximityFuse",
+		"fuseAndWarheadProp.proximityFuseRange",
+		"fuseAndWarheadProp.proximityFuseShellDetection",
+		"fuseAndWarheadProp.proximityFuseDelay",
+		"guidanceProp.guidanceType",
+		"guidanceProp.guidanceDuration",
+		"guidanceProp.maximumAngleAllowedBetweenMissileAndCrosshair",
+		"flightProp.aimSensitivity",
+		"flightProp.maximumAxisValues",
+		"flightProp.wingAreaMultiplier",
+		"flightProp.startSpeed",
+		"flightProp.maximumSpeed",
+		"flightProp.minimumRange",
+		"flightProp.maximumFlightRange",
+		"flightProp.maximumOverLoad",
+		"flightProp.thrustVectoring",
+		"flightProp.thrustVectoringAngle",
+	},
+	CategoryAGMAutomatic: {
+		"physicalProp.mass",
+		"physicalProp.massAtEndOfBoosterBurn",
+		"physicalProp.massAtEndOfSustainerBurn",
+		"physicalProp.calibre",
+		"physicalProp.length",
+		"engineProp.forceExertedByBooster",
+		"engineProp.burnTimeOfBooster",
+		"engineProp.rawAccelerationAtIgnition",
+		"engineProp.specificImpulseOfBooster",
+		"engineProp.deltaSpeedOfBooster",
+		"engineProp.forceExertedBySustainer",
+		"engineProp.burnTimeOfSustainer",
+		"engineProp.specificImpulseOfSustainer",
+		"engineProp.deltaSpeedOfSustainer",
+		"engineProp.totalDeltaSpeed",
+		"fuseAndWarheadProp.explosiveMass",
+		"fuseAndWarheadProp.tandemCharge",
+		"fuseAndWarheadProp.penetration",
+		"fuseAndWarheadProp.proximityFuse",
+		"fuseAndWarheadProp.proximityFuseRange",
+		"fuseAndWarheadProp.proximityFuseDelay",
+		"guidanceProp.zoom",
+		"guidanceProp.guidanceType",
+		"guidanceProp.guidanceStartDelay",
+		"guidanceProp.guidanceDuration",
+		"guidanceProp.seekerWarmUpTime",
+		"guidanceProp.seekerSearchDuration",
+		"guidanceProp.fieldOfView",
+		"guidanceProp.gimbalLimit",
+		"guidanceProp.trackRate",
+		"guidanceProp.uncageSeekerBeforeLaunch",
+		"guidanceProp.maximumLockAngleBeforeLaunch",
+		"guidanceProp.minimumAngleBetweenSeekerAndSunForNotCapture",
+		"guidanceProp.canLockGround",
+		"guidanceProp.lockOnRangeGround",
+		"guidanceProp.lockOnRangeFromRearAspect",
+		"guidanceProp.lockOnRangeFromAllAspect",
+		"guidanceProp.canLockAfterLaunch",
+		"guidanceProp.inertialNavigationDriftSpeed",
+		"flightProp.maximumFinAngleOfAttack",
+		"flightProp.maximumFinLateralAcceleration",
+		"flightProp.wingAreaMultiplier",
+		"flightProp.startSpeed",
+		"flightProp.maximumSpeed",
+		"flightProp.minimumRange",
+		"flightProp.maximumFlightRange",
+		"flightProp.loadFactorLimitAtLaunch",
+		"flightProp.maximumOverLoad",
+		"flightProp.thrustVectoring",
+		"flightProp.thrustVectoringAngle",
+		"flightProp.loft",
+		"flightProp.loftAngle",
+		"flightProp.targetElevation",
+		"flightProp.maximumTargetAngularChange",
+	},
+	CategoryAGMSALH: {
+		"physicalProp.mass",
+		"physicalProp.massAtEndOfBoosterBurn",
+		"physicalProp.massAtEndOfSustainerBurn",
+		"physicalProp.calibre",
+		"physicalProp.length",
+		"engineProp.forceExertedByBooster",
+		"engineProp.burnTimeOfBooster",
+		"engineProp.rawAccelerationAtIgnition",
+		"engineProp.specificImpulseOfBooster",
+		"engineProp.deltaSpeedOfBooster",
+		"engineProp.forceExertedBySustainer",
+		"engineProp.burnTimeOfSustainer",
+		"engineProp.specificImpulseOfSustainer",
+		"engineProp.deltaSpeedOfSustainer",
+		"engineProp.totalDeltaSpeed",
+		"fuseAndWarheadProp.explosiveMass",
+		"fuseAndWarheadProp.tandemCharge",
+		"fuseAndWarheadProp.penetration",
+		"fuseAndWarheadProp.proximityFuse",
+		"fuseAndWarheadProp.proximityFuseRange",
+		"fuseAndWarheadProp.proximityFuseDelay",
+		"guidanceProp.guidanceType",
+		"guidanceProp.guidanceStartDelay",
+		"guidanceProp.guidanceDuration",
+		"guidanceProp.guidanceRange",
+		"guidanceProp.seekerWarmUpTime",
+		"guidanceProp.seekerSearchDuration",
+		"guidanceProp.fieldOfView",
+		"guidanceProp.gimbalLimit",
+		"guidanceProp.trackRate",
+		"guidanceProp.uncageSeekerBeforeLaunch",
+		"guidanceProp.maximumLockAngleBeforeLaunch",
+		"guidanceProp.maximumBreakLockTime",
+		"guidanceProp.inertialNavigationDriftSpeed",
+		"flightProp.maximumLaunchAngleHorizontalVertical",
+		"flightProp.maximumFinAngleOfAttack",
+		"flightProp.wingAreaMultiplier",
+		"flightProp.startSpeed",
+		"flightProp.maximumSpeed",
+		"flightProp.minimumRange",
+		"flightProp.maximumFlightRange",
+		"flightProp.loadFactorLimitAtLaunch",
+		"flightProp.maximumOverLoad",
+		"flightProp.thrustVectoring",
+		"flightProp.thrustVectoringAngle",
+		"flightProp.loft",
+		"flightProp.loftAngle",
+		"flightProp.targetElevation",
+		"flightProp.maximumTargetAngularChange",
+	},
+	CategoryAGMSACLOS: {
+		"physicalProp.mass",
+		"physicalProp.massAtEndOfBoosterBurn",
+		"physicalProp.massAtEndOfSustainerBurn",
+		"physicalProp.calibre",
+		"physicalProp.length",
+		"engineProp.forceExertedByBooster",
+		"engineProp.burnTimeOfBooster",
+		"engineProp.rawAccelerationAtIgnition",
+		"engineProp.specificImpulseOfBooster",
+		"engineProp.deltaSpeedOfBooster",
+		"engineProp.forceExertedBySustainer",
+		"engineProp.burnTimeOfSustainer",
+		"engineProp.specificImpulseOfSustainer",
+		"engineProp.deltaSpeedOfSustainer",
+		"engineProp.totalDeltaSpeed",
+		"fuseAndWarheadProp.explosiveMass",
+		"fuseAndWarheadProp.tandemCharge",
+		"fuseAndWarheadProp.penetration",
+		"fuseAndWarheadProp.proximityFuse",
+		"fuseAndWarheadProp.proximityFuseRange",
+		"fuseAndWarheadProp.proximityFuseArmingDistance",
+		"fuseAndWarheadProp.proximityFuseShellDetection",
+		"fuseAndWarheadProp.proximityFuseMinimumAltitude",
+		"fuseAndWarheadProp.proximityFuseDelay",
+		"guidanceProp.guidanceType",
+		"guidanceProp.guidanceStartDelay",
+		"guidanceProp.guidanceDuration",
+		"guidanceProp.guidanceRange",
+		"guidanceProp.launchSector",
+		"guidanceProp.controlConeFOV",
+		"guidanceProp.aimTrackingSensitivity",
+		"flightProp.maximumAxisValues",
+		"flightProp.maximumFinAngleOfAttack",
+		"flightProp.maximumLateralAcceleration",
+		"flightProp.wingAreaMultiplier",
+		"flightProp.startSpeed",
+		"flightProp.maximumSpeed",
+		"flightProp.minimumRange",
+		"flightProp.maximumFlightRange",
+		"flightProp.loadFactorLimitAtLaunch",
+		"flightProp.maximumOverLoad",
+		"flightProp.thrustVectoring",
+		"flightProp.thrustVectoringAngle",
+		"flightProp.flightTimeUntilGuidanceStarts",
+		"flightProp.startingGLimit",
+		"flightProp.flightTimeWhenPullLimit30%",
+		"flightProp.flightTimeWhenPullLimit100%",
+	},
+	CategoryAGMMCLOS: {
+		"physicalProp.mass",
+		"physicalProp.massAtEndOfBoosterBurn",
+		"physicalProp.massAtEndOfSustainerBurn",
+		"physicalProp.calibre",
+		"physicalProp.length",
+		"engineProp.forceExertedByBooster",
+		"engineProp.burnTimeOfBooster",
+		"engineProp.rawAccelerationAtIgnition",
+		"engineProp.specificImpulseOfBooster",
+		"engineProp.deltaSpeedOfBooster",
+		"engineProp.forceExertedBySustainer",
+		"engineProp.burnTimeOfSustainer",
+		"engineProp.specificImpulseOfSustainer",
+		"engineProp.deltaSpeedOfSustainer",
+		"engineProp.totalDeltaSpeed",
+		"fuseAndWarheadProp.explosiveMass",
+		"fuseAndWarheadProp.tandemCharge",
+		"fuseAndWarheadProp.penetration",
+		"fuseAndWarheadProp.proximityFuse",
+		"fuseAndWarheadProp.proximityFuseRange",
+		"fuseAndWarheadProp.proximityFuseArmingDistance",
+		"fuseAndWarheadProp.proximityFuseShellDetection",
+		"fuseAndWarheadProp.proximityFuseMinimumAltitude",
+		"fuseAndWarheadProp.proximityFuseDelay",
+		"guidanceProp.guidanceType",
+		"guidanceProp.guidanceDuration",
+		"guidanceProp.guidanceRange",
+		"flightProp.maximumFinAngleOfAttack",
+		"flightProp.maximumLateralAcceleration",
+		"flightProp.wingAreaMultiplier",
+		"flightProp.startSpeed",
+		"flightProp.maximumSpeed",
+		"flightProp.minimumRange",
+		"flightProp.maximumFlightRange",
+	},
+	CategoryAGMLOSBR: {
+		"physicalProp.mass",
+		"physicalProp.massAtEndOfBoosterBurn",
+		"physicalProp.massAtEndOfSustainerBurn",
+		"physicalProp.calibre",
+		"physicalProp.length",
+		"engineProp.forceExertedByBooster",
+		"engineProp.burnTimeOfBooster",
+		"engineProp.rawAccelerationAtIgnition",
+		"engineProp.specificImpulseOfBooster",
+		"engineProp.deltaSpeedOfBooster",
+		"engineProp.forceExertedBySustainer",
+		"engineProp.burnTimeOfSustainer",
+		"engineProp.specificImpulseOfSustainer",
+		"engineProp.deltaSpeedOfSustainer",
+		"engineProp.totalDeltaSpeed",
+		"fuseAndWarheadProp.explosiveMass",
+		"fuseAndWarheadProp.tandemCharge",
+		"fuseAndWarheadProp.penetration",
+		"fuseAndWarheadProp.proximityFuse",
+		"fuseAndWarheadProp.proximityFuseRange",
+		"fuseAndWarheadProp.proximityFuseArmingDistance",
+		"fuseAndWarheadProp.proximityFuseShellDetection",
+		"fuseAndWarheadProp.proximityFuseMinimumAltitude",
+		"fuseAndWarheadProp.proximityFuseDelay",
+		"guidanceProp.guidanceType",
+		"guidanceProp.guidanceDuration",
+		"guidanceProp.guidanceRange",
+		"guidanceProp.aimTrackingSensitivity",
+		"flightProp.maximumAxisValues",
+		"flightProp.maximumFinAngleOfAttack",
+		"flightProp.maximumFinLateralAcceleration",
+		"flightProp.maximumLateralAcceleration",
+		"flightProp.wingAreaMultiplier",
+		"flightProp.startSpeed",
+		"flightProp.maximumSpeed",
+		"flightProp.minimumRange",
+		"flightProp.maximumFlightRange",
+		"flightProp.loadFactorLimitAtLaunch",
+		"flightProp.maximumOverLoad",
+	},
+	CategoryGBU: {
+		"physicalProp.mass",
+		"physicalProp.calibre",
+		"physicalProp.length",
+		"fuseAndWarheadProp.explosiveMass",
+		"guidanceProp.zoom",
+		"guidanceProp.guidanceType",
+		"guidanceProp.guidanceDuration",
+		"guidanceProp.seekerWarmUpTime",
+		"guidanceProp.seekerSearchDuration",
+		"guidanceProp.fieldOfView",
+		"guidanceProp.opticSightFieldOfView",
+		"guidanceProp.gimbalLimit",
+		"guidanceProp.trackRate",
+		"guidanceProp.uncageSeekerBeforeLaunch",
+		"guidanceProp.maximumLockAngleBeforeLaunch",
+		"guidanceProp.minimumAngleBetweenSeekerAndSunForNotCapture",
+		"guidanceProp.lockOnRangeGround",
+		"guidanceProp.lockOnRangeVehicle",
+		"guidanceProp.canLockAfterLaunch",
+		"guidanceProp.PIDProportionalTerm",
+		"guidanceProp.PIDIntegralTerm",
+		"guidanceProp.PIDIntegralTermLimit",
+		"guidanceProp.PIDDerivativeTerm",
+		"guidanceProp.inertialNavigation",
+		"guidanceProp.inertialGuidanceDriftSpeed",
+		"flightProp.maximumFinAngleOfAttack",
+		"flightProp.wingAreaMultiplier",
+		"flightProp.maximumOverLoad",
+		"flightProp.loft",
+		"flightProp.loftAngle",
+		"flightProp.targetElevation",
+		"flightProp.maximumTargetAngularChange",
+	},
+	CategorySAMIR: {
+		"physicalProp.mass",
+		"physicalProp.massAtEndOfBoosterBurn",
+		"physicalProp.massAtEndOfSustainerBurn",
+		"physicalProp.calibre",
+		"physicalProp.length",
+		"engineProp.forceExertedByBooster",
+		"engineProp.burnTimeOfBooster",
+		"engineProp.rawAccelerationAtIgnition",
+		"engineProp.specificImpulseOfBooster",
+		"engineProp.deltaSpeedOfBooster",
+		"engineProp.boosterStartDelay",
+		"engineProp.forceExertedBySustainer",
+		"engineProp.burnTimeOfSustainer",
+		"engineProp.specificImpulseOfSustainer",
+		"engineProp.deltaSpeedOfSustainer",
+		"engineProp.totalDeltaSpeed",
+		"fuseAndWarheadProp.explosiveMass",
+		"fuseAndWarheadProp.proximityFuse",
+		"fuseAndWarheadProp.proximityFuseRange",
+		"fuseAndWarheadProp.proximityFuseArmingDistance",
+		"fuseAndWarheadProp.proximityFuseShellDetection",
+		"fuseAndWarheadProp.proximityFuseMinimumAltitude",
+		"fuseAndWarheadProp.proximityFuseDelay",
+		"guidanceProp.guidanceType",
+		"guidanceProp.guidanceStartDelay",
+		"guidanceProp.guidanceDuration",
+		"guidanceProp.seekerWarmUpTime",
+		"guidanceProp.seekerSearchDuration",
+		"guidanceProp.fieldOfView",
+		"guidanceProp.gimbalLimit",
+		"guidanceProp.trackRate",
+		"guidanceProp.uncageSeekerBeforeLaunch",
+		"guidanceProp.maximumLockAngleBeforeLaunch",
+		"guidanceProp.minimumAngleBetweenSeekerAndSunForNotCapture",
+		"guidanceProp.lockOnRangeFromRearAspect",
+		"guidanceProp.lockOnRangeFromAllAspect",
+		"guidanceProp.countermeasureDetectionRange",
+		"guidanceProp.IRCMDetectionRange",
+		"guidanceProp.DIRCMDetectionRange",
+		"guidanceProp.IRCCM",
+		"guidanceProp.IRCCMType",
+		"guidanceProp.IRCCMFieldOfView",
+		"guidanceProp.IRCCMRejectionThreshold",
+		"guidanceProp.IRCCMReactionTime",
+		"guidanceProp.headOnLockOnRangeAgainstAfterburnerTarget",
+		"guidanceProp.maximumBreakLockTime",
+		"guidanceProp.canBeSlavedToRadar",
+		"guidanceProp.canLockAfterLaunch",
+		"guidanceProp.proportionalNavigationMultiplier",
+		"guidanceProp.baseIndicatedAirSpeed",
+		"guidanceProp.PIDProportionalTerm",
+		"guidanceProp.PIDIntegralTerm",
+		"guidanceProp.PIDIntegralTermLimit",
+		"guidanceProp.PIDDerivativeTerm",
+		"flightProp.maximumFinAngleOfAttack",
+		"flightProp.finsLateralAcceleration",
+		"flightProp.wingAreaMultiplier",
+		"flightProp.startSpeed",
+		"flightProp.maximumSpeed",
+		"flightProp.maximumFlightRange",
+		"flightProp.maximumOverLoad",
+		"flightProp.thrustVectoring",
+		"flightProp.thrustVectoringAngle",
+	},
+	CategorySAMSACLOSLOSBR: {
+		"physicalProp.mass",
+		"physicalProp.massAtEndOfBoosterBurn",
+		"physicalProp.massAtEndOfSustainerBurn",
+		"physicalProp.calibre",
+		"physicalProp.length",
+		"engineProp.forceExertedByBooster",
+		"engineProp.burnTimeOfBooster",
+		"engineProp.rawAccelerationAtIgnition",
+		"engineProp.specificImpulseOfBooster",
+		"engineProp.deltaSpeedOfBooster",
+		"engineProp.forceExertedBySustainer",
+		"engineProp.burnTimeOfSustainer",
+		"engineProp.specificImpulseOfSustainer",
+		"engineProp.deltaSpeedOfSustainer",
+		"engineProp.totalDeltaSpeed",
+		"fuseAndWarheadProp.explosiveMass",
+		"fuseAndWarheadProp.proximityFuse",
+		"fuseAndWarheadProp.proximityFuseRange",
+		"fuseAndWarheadProp.proximityFuseArmingDistance",
+		"fuseAndWarheadProp.proximityFuseMinimumAltitude",
+		"fuseAndWarheadProp.proximityFuseShellDetection",
+		"fuseAndWarheadProp.proximityFuseDelay",
+		"fuseAndWarheadProp.penetration",
+		"guidanceProp.guidanceType",
+		"guidanceProp.guidanceDuration",
+		"guidanceProp.guidanceRange",
+		"flightProp.maximumAxisValues",
+		"flightProp.maximumFinAngleOfAttack",
+		"flightProp.maximumFinLateralAcceleration",
+		"flightProp.maximumLateralAcceleration",
+		"flightProp.wingAreaMultiplier",
+		"flightProp.startSpeed",
+		"flightProp.maximumSpeed",
+		"flightProp.maximumFlightRange",
+		"flightProp.tracer",
+	},
+	CategoryATGMMCLOS: {
+		"physicalProp.mass",
+		"physicalProp.massAtEndOfBoosterBurn",
+		"physicalProp.massAtEndOfSustainerBurn",
+		"physicalProp.calibre",
+		"physicalProp.length",
+		"engineProp.forceExertedByBooster",
+		"engineProp.burnTimeOfBooster",
+		"engineProp.rawAccelerationAtIgnition",
+		"engineProp.specificImpulseOfBooster",
+		"engineProp.deltaSpeedOfBooster",
+		"engineProp.forceExertedBySustainer",
+		"engineProp.burnTimeOfSustainer",
+		"engineProp.specificImpulseOfSustainer",
+		"engineProp.deltaSpeedOfSustainer",
+		"engineProp.totalDeltaSpeed",
+		"fuseAndWarheadProp.explosiveMass",
+		"fuseAndWarheadProp.tandemCharge",
+		"fuseAndWarheadProp.penetration",
+		"guidanceProp.guidanceType",
+		"guidanceProp.guidanceDuration",
+		"guidanceProp.guidanceRange",
+		"flightProp.maximumAxisValues",
+		"flightProp.maximumLateralAcceleration",
+		"flightProp.wingAreaMultiplier",
+		"flightProp.startSpeed",
+		"flightProp.maximumSpeed",
+		"flightProp.maximumFlightRange",
+	},
+	CategoryATGMSACLOS: {
+		"physicalProp.mass",
+		"physicalProp.massAtEndOfBoosterBurn",
+		"physicalProp.massAtEndOfSustainerBurn",
+		"physicalProp.calibre",
+		"physicalProp.length",
+		"engineProp.forceExertedByBooster",
+		"engineProp.burnTimeOfBooster",
+		"engineProp.rawAccelerationAtIgnition",
+		"engineProp.specificImpulseOfBooster",
+		"engineProp.deltaSpeedOfBooster",
+		"engineProp.forceExertedBySustainer",
+		"engineProp.burnTimeOfSustainer",
+		"engineProp.specificImpulseOfSustainer",
+		"engineProp.deltaSpeedOfSustainer",
+		"engineProp.totalDeltaSpeed",
+		"fuseAndWarheadProp.explosiveMass",
+		"fuseAndWarheadProp.tandemCharge",
+		"fuseAndWarheadProp.penetration",
+		"guidanceProp.guidanceType",
+		"guidanceProp.guidanceDuration",
+		"guidanceProp.guidanceRange",
+		"flightProp.maximumAxisValues",
+		"flightProp.maximumFinAngleOfAttack",
+		"flightProp.wingAreaMultiplier",
+		"flightProp.startSpeed",
+		"flightProp.maximumSpeed",
+		"flightProp.maximumFlightRange",
+		"flightProp.maximumOverLoad",
+		"flightProp.thrustVectoring",
+		"flightProp.thrustVectoringAngle",
+	},
+	CategoryATGMLOSBR: {
+		"physicalProp.mass",
+		"physicalProp.massAtEndOfBoosterBurn",
+		"physicalProp.massAtEndOfSustainerBurn",
+		"physicalProp.calibre",
+		"physicalProp.length",
+		"engineProp.forceExertedByBooster",
+		"engineProp.burnTimeOfBooster",
+		"engineProp.rawAccelerationAtIgnition",
+		"engineProp.specificImpulseOfBooster",
+		"engineProp.deltaSpeedOfBooster",
+		"engineProp.forceExertedBySustainer",
+		"engineProp.burnTimeOfSustainer",
+		"engineProp.specificImpulseOfSustainer",
+		"engineProp.deltaSpeedOfSustainer",
+		"engineProp.totalDeltaSpeed",
+		"fuseAndWarheadProp.explosiveMass",
+		"fuseAndWarheadProp.tandemCharge",
+		"fuseAndWarheadProp.penetration",
+		"guidanceProp.guidanceType",
+		"guidanceProp.guidanceDuration",
+		"guidanceProp.guidanceRange",
+		"flightProp.maximumFinAngleOfAttack",
+		"flightProp.wingAreaMultiplier",
+		"flightProp.startSpeed",
+		"flightProp.maximumSpeed",
+		"flightProp.maximumFlightRange",
+		"flightProp.maximumOverLoad",
+	},
+	CategoryATGMAutomatic: {
+		"physicalProp.mass",
+		"physicalProp.massAtEndOfBoosterBurn",
+		"physicalProp.massAtEndOfSustainerBurn",
+		"physicalProp.calibre",
+		"physicalProp.length",
+		"engineProp.forceExertedByBooster",
+		"engineProp.burnTimeOfBooster",
+		"engineProp.rawAccelerationAtIgnition",
+		"engineProp.specificImpulseOfBooster",
+		"engineProp.deltaSpeedOfBooster",
+		"engineProp.forceExertedBySustainer",
+		"engineProp.burnTimeOfSustainer",
+		"engineProp.specificImpulseOfSustainer",
+		"engineProp.deltaSpeedOfSustainer",
+		"engineProp.totalDeltaSpeed",
+		"fuseAndWarheadProp.explosiveMass",
+		"fuseAndWarheadProp.tandemCharge",
+		"fuseAndWarheadProp.penetration",
+		"fuseAndWarheadProp.proximityFuse",
+		"fuseAndWarheadProp.proximityFuseRange",
+		"fuseAndWarheadProp.proximityFuseDelay",
+		"guidanceProp.zoom",
+		"guidanceProp.guidanceType",
+		"guidanceProp.guidanceStartDelay",
+		"guidanceProp.guidanceDuration",
+		"guidanceProp.seekerWarmUpTime",
+		"guidanceProp.seekerSearchDuration",
+		"guidanceProp.fieldOfView",
+		"guidanceProp.IRCCMFieldOfView",
+		"guidanceProp.gimbalLimit",
+		"guidanceProp.trackRate",
+		"guidanceProp.uncageSeekerBeforeLaunch",
+		"guidanceProp.maximumLockAngleBeforeLaunch",
+		"guidanceProp.minimumAngleBetweenSeekerAndSunForNotCapture",
+		"guidanceProp.canLockGround",
+		"guidanceProp.lockOnRangeGround",
+		"guidanceProp.lockOnRangeFromRearAspect",
+		"guidanceProp.lockOnRangeFromAllAspect",
+		"guidanceProp.canLockAfterLaunch",
+		"flightProp.maximumFinAngleOfAttack",
+		"flightProp.wingAreaMultiplier",
+		"flightProp.startSpeed",
+		"flightProp.maximumSpeed",
+		"flightProp.minimumRange",
+		"flightProp.maximumFlightRange",
+		"flightProp.loadFactorLimitAtLaunch",
+		"flightProp.maximumOverLoad",
+		"flightProp.thrustVectoring",
+		"flightProp.thrustVectoringAngle",
+		"flightProp.loft",
+		"flightProp.loftAngle",
+		"flightProp.targetElevation",
+		"flightProp.maximumTargetAngularChange",
+	},
+	CategoryAShM: {
+		"physicalProp.mass",
+		"physicalProp.massAtEndOfBoosterBurn",
+		"physicalProp.massAtEndOfSustainerBurn",
+		"physicalProp.calibre",
+		"physicalProp.length",
+		"engineProp.forceExertedByBooster",
+		"engineProp.burnTimeOfBooster",
+		"engineProp.rawAccelerationAtIgnition",
+		"engineProp.specificImpulseOfBooster",
+		"engineProp.deltaSpeedOfBooster",
+		"engineProp.boosterStartDelay",
+		"engineProp.forceExertedBySustainer",
+		"engineProp.burnTimeOfSustainer",
+		"engineProp.specificImpulseOfSustainer",
+		"engineProp.deltaSpeedOfSustainer",
+		"engineProp.totalDeltaSpeed",
+		"fuseAndWarheadProp.explosiveMass",
+		"fuseAndWarheadProp.tandemCharge",
+		"fuseAndWarheadProp.penetration",
+		"guidanceProp.guidanceType",
+		"guidanceProp.guidanceStartDelay",
+		"guidanceProp.guidanceDuration",
+		"guidanceProp.guidanceRange",
+		"guidanceProp.seekerWarmUpTime",
+		"guidanceProp.seekerSearchDuration",
+		"guidanceProp.gimbalLimit",
+		"guidanceProp.trackRate",
+		"guidanceProp.uncageSeekerBeforeLaunch",
+		"guidanceProp.maximumLockAngleBeforeLaunch",
+		"guidanceProp.lockOnRangeFromAllAspect",
+		"guidanceProp.maximumBreakLockTime",
+		"guidanceProp.canLockAfterLaunch",
+		"guidanceProp.band",
+		"guidanceProp.sidelobeAttenuation",
+		"guidanceProp.transmitterPower",
+		"guidanceProp.transmitterAngleOfHalfSensitivity",
+		"guidanceProp.transmitterSidelobeSensitivity",
+		"guidanceProp.receiverAngleOfHalfSensitivity",
+		"guidanceProp.receiverSidelobeSensitivity",
+		"guidanceProp.distanceMinimumValue",
+		"guidanceProp.distanceMaximumValue",
+		"guidanceProp.distanceGate",
+		"guidanceProp.distanceMinimumSignalGate",
+		"guidanceProp.distanceRefWidth",
+		"guidanceProp.proportionalNavigationMultiplier",
+		"guidanceProp.baseIndicatedAirSpeed",
+		"guidanceProp.PIDProportionalTerm",
+		"guidanceProp.PIDIntegralTerm",
+		"guidanceProp.PIDIntegralTermLimit",
+		"guidanceProp.PIDDerivativeTerm",
+		"guidanceProp.inertialNavigationDriftSpeed",
+		"flightProp.maximumAxisValues",
+		"flightProp.maximumFinAngleOfAttack",
+		"flightProp.maximumFinLateralAcceleration",
+		"flightProp.wingAreaMultiplier",
+		"flightProp.startSpeed",
+		"flightProp.maximumSpeed",
+		"flightProp.minimumRange",
+		"flightProp.maximumFlightRange",
+		"flightProp.maximumOverLoad",
+		"flightProp.seaSkimming",
+		"flightProp.loft",
+		"flightProp.loftAngle",
+		"flightProp.targetElevation",
+		"flightProp.maximumTargetAngularChange",
+	},
+}
@@ -0,0 +1,137 @@
+package models
+
+// fieldDocs describes every weapon parameter, in the order of the Params
+// struct. Units and groups are taken from the struct definitions.
+var fieldDocs = []fieldDoc{
+	{"physicalProp.mass", "Mass", "Launch mass of the weapon."},
+	{"physicalProp.massAtEndOfBoosterBurn", "Mass at end of booster burn", "Mass once the booster propellant is spent."},
+	{"physicalProp.massAtEndOfSustainerBurn", "Mass at end of sustainer burn", "Mass once the sustainer propellant is spent."},
+	{"physicalProp.calibre", "Calibre", "Body diameter."},
+	{"physicalProp.length", "Length", "Overall length."},
+	{"engineProp.forceExertedByBooster", "Force exerted by booster", "Thrust of the booster stage."},
+	{"engineProp.burnTimeOfBooster", "Burn time of booster", "How long the booster stage burns."},
+	{"engineProp.rawAccelerationAtIgnition", "Raw acceleration at ignition", "Booster thrust divided by launch mass, ignoring drag and gravity."},
+	{"engineProp.specificImpulseOfBooster", "Specific impulse of booster", "Efficiency of the booster propellant."},
+	{"engineProp.deltaSpeedOfBooster", "ΔV of booster", "Ideal speed gained during the booster burn."},
+	{"engineProp.boosterStartDelay", "Booster start delay", "Time between launch and booster ignition."},
+	{"engineProp.forceExertedBySustainer", "Force exerted by sustainer", "Thrust of the sustainer stage."},
+	{"engineProp.burnTimeOfSustainer", "Burn time of sustainer", "How long the sustainer stage burns."},
+	{"engineProp.specificImpulseOfSustainer", "Specific impulse of sustainer", "Efficiency of the sustainer propellant."},
+	{"engineProp.deltaSpeedOfSustainer", "ΔV of sustainer", "Ideal speed gained during the sustainer burn."},
+	{"engineProp.totalDeltaSpeed", "Total ΔV", "Ideal speed gained over both burns."},
+	{"fuseAndWarheadProp.explosiveMass", "Explosive mass", "Warhead filler as TNT equivalent."},
+	{"fuseAndWarheadProp.tandemCharge", "Tandem charge", "Whether the warhead has a precursor charge against ERA."},
+	{"fuseAndWarheadProp.penetration", "Penetration", "Armour penetration of the warhead."},
+	{"fuseAndWarheadProp.proximityFuse", "Proximity fuse", "Whether the weapon has a proximity fuse."},
+	{"fuseAndWarheadProp.proximityFuseRange", "Proximity fuse range", "Distance at which the proximity fuse triggers."},
+	{"fuseAndWarheadProp.proximityFuseArmingDistance", "Proximity fuse arming distance", "Distance flown before the proximity fuse arms."},
+	{"fuseAndWarheadProp.proximityFuseShellDetection", "Proximity fuse shell detection (80-200 mm)", "Whether the proximity fuse triggers on artillery shells."},
+	{"fuseAndWarheadProp.proximityFuseMinimumAltitude", "Proximity fuse minimum altitude", "Altitude below which the proximity fuse is disabled."},
+	{"fuseAndWarheadProp.proximityFuseDelay", "Proximity fuse delay", "Delay between detection and detonation."},
+	{"guidanceProp.zoom", "Zoom", "Magnification of the guidance camera."},
+	{"guidanceProp.guidanceType", "Guidance type", "How the weapon is guided to its target."},
+	{"guidanceProp.guidanceStartDelay", "Guidance start delay", "Time after launch before guidance begins."},
+	{"guidanceProp.guidanceDuration", "Guidance duration", "How long the weapon keeps guiding after launch."},
+	{"guidanceProp.guidanceRange", "Guidance range", "Maximum distance at which the weapon can be guided."},
+	{"guidanceProp.launchSector", "Launch sector", "Angle off boresight within which the weapon can be launched."},
+	{"guidanceProp.controlConeFOV", "Control cone FOV", "Field of view of the command guidance tracker."},
+	{"guidanceProp.aimTrackingSensitivity", "Aim tracking sensitivity", "How quickly the weapon follows the aim point."},
+	{"guidanceProp.maximumAngleAllowedBetweenMissileAndCrosshair", "Maximum angle allowed between the missile and the crosshair", "Guidance is lost beyond this angle."},
+	{"guidanceProp.seekerWarmUpTime", "Seeker warm up time", "Time needed before the seeker can be used."},
+	{"guidanceProp.seekerSearchDuration", "Seeker search duration", "How long the seeker stays active once warmed up."},
+	{"guidanceProp.fieldOfView", "Field of view", "Field of view of the seeker."},
+	{"guidanceProp.opticSightFieldOfView", "Optic sight field of view", "Field of view of the launcher optics."},
+	{"guidanceProp.gimbalLimit", "Gimbal limit", "Maximum angle the seeker can turn off the missile axis."},
+	{"guidanceProp.trackRate", "Track rate", "Maximum angular speed the seeker can follow."},
+	{"guidanceProp.uncageSeekerBeforeLaunch", "Uncaged seeker before launch", "Whether the seeker can be uncaged to track before launch."},
+	{"guidanceProp.maximumLockAngleBeforeLaunch", "Maximum lock angle before launch", "Maximum off-boresight angle for a lock before launch."},
+	{"guidanceProp.minimumAngleBetweenSeekerAndSunForNotCapture", "Minimum angle of incidence of the seeker to the Sun for it to not capture the Sun", "The seeker locks onto the Sun when pointed closer to it than this."},
+	{"guidanceProp.canLockGround", "Can lock the ground", "Whether the seeker can lock onto ground targets."},
+	{"guidanceProp.lockOnRangeGround", "Lock-on range (ground)", "Lock-on range against ground targets."},
+	{"guidanceProp.lockOnRangeVehicle", "Lock-on range (vehicle)", "Lock-on range against vehicles."},
+	{"guidanceProp.lockOnRangeFromRearAspect", "Lock-on range from rear-aspect", "Lock-on range against a target's exhaust."},
+	{"guidanceProp.flareDetectionRange", "Flare detection range", "Distance at which the seeker sees flares."},
+	{"guidanceProp.IRCMDetectionRange", "IRCM detection range", "Distance at which the seeker sees IR countermeasures."},
+	{"guidanceProp.DIRCMDetectionRange", "DIRCM detection range", "Distance at which the seeker sees directional IR countermeasures."},
+	{"guidanceProp.headOnLockOnRangeAgainstAfterburnerTarget", "Head-on lock-on range against afterburning target", "Frontal lock-on range against a target using afterburner."},
+	{"guidanceProp.IRCCM", "IRCCM", "Whether the seeker has IR counter-countermeasures."},
+	{"guidanceProp.IRCCMType", "IRCCM type", "Kind of IR counter-countermeasures."},
+	{"guidanceProp.IRCCMFieldOfView", "IRCCM field of view", "Field of view used to reject countermeasures."},
+	{"guidanceProp.IRCCMRejectionThreshold", "IRCCM rejection threshold", "Threshold at which countermeasures are rejected."},
+	{"guidanceProp.IRCCMReactionTime", "IRCCM reaction time", "Time the IRCCM needs to react to a countermeasure."},
+	{"guidanceProp.lockOnRangeFromAllAspect", "Lock-on range from all-aspect", "Lock-on range against a target from any aspect."},
+	{"guidanceProp.countermeasureDetectionRange", "Countermeasure detection range", "Distance at which the seeker sees countermeasures."},
+	{"guidanceProp.maximumBreakLockTime", "Maximum break lock time", "How long the seeker may lose the target before the lock breaks."},
+	{"guidanceProp.canBeSlavedToRadar", "Can be slaved to radar", "Whether the seeker can be pointed by the aircraft radar."},
+	{"guidanceProp.canLockAfterLaunch", "Can lock after launch", "Whether the seeker can acquire a target after launch."},
+	{"guidanceProp.band", "Band", "Radar frequency band of the seeker."},
+	{"guidanceProp.angularSpeedRejectionThreshold", "Angular speed rejection threshold", "Targets moving faster than this across the seeker are rejected."},
+	{"guidanceProp.angularRejectionThresholdRange", "Angular rejection threshold range", "Range of angular rejection thresholds of the seeker."},
+	{"guidanceProp.accelerationRejectionThresholdRange", "Acceleration rejection threshold range", "Range of acceleration rejection thresholds of the seeker."},
+	{"guidanceProp.sidelobeAttenuation", "Sidelobe attenuation", "Suppression of returns outside the main lobe."},
+	{"guidanceProp.transmitterPower", "Transmitter power", "Power of the seeker transmitter."},
+	{"guidanceProp.transmitterAngleOfHalfSensitivity", "Transmitter angle of half sensitivity", "Beam angle at which transmitter sensitivity halves."},
+	{"guidanceProp.transmitterSidelobeSensitivity", "Transmitter sidelobe sensitivity", "Sensitivity of the transmitter sidelobes."},
+	{"guidanceProp.receiverAngleOfHalfSensitivity", "Receiver angle of half sensitivity", "Beam angle at which receiver sensitivity halves."},
+	{"guidanceProp.receiverSidelobeSensitivity", "Receiver sidelobe sensitivity", "Sensitivity of the receiver sidelobes."},
+	{"guidanceProp.distanceMinimumValue", "Distance minimum value", "Shortest distance the range gate tracks."},
+	{"guidanceProp.distanceMaximumValue", "Distance maximum value", "Longest distance the range gate tracks."},
+	{"guidanceProp.distanceWidth", "Distance width", "Width of the range gate."},
+	{"guidanceProp.distanceMinimumSignalGate", "Distance minimum signal gate", "Minimum signal the range gate accepts."},
+	{"guidanceProp.distanceRefWidth", "Distance ref width", "Reference width of the range gate."},
+	{"guidanceProp.distanceGateSearchRange", "Distance gate search range", "Range searched by the range gate."},
+	{"guidanceProp.distanceGateAlphaFilter", "Distance gate alpha filter", "Alpha coefficient of the range gate tracking filter."},
+	{"guidanceProp.distanceGateBetaFilter", "Distance gate beta filter", "Beta coefficient of the range gate tracking filter."},
+	{"guidanceProp.dopplerSpeedMinimumValue", "Doppler speed minimum value", "Lowest closure speed the Doppler gate tracks."},
+	{"guidanceProp.dopplerSpeedMaximumValue", "Doppler speed maximum value", "Highest closure speed the Doppler gate tracks."},
+	{"guidanceProp.dopplerSpeedWidth", "Doppler speed width", "Width of the Doppler gate."},
+	{"guidanceProp.dopplerSpeedRefWidth", "Doppler speed ref width", "Reference width of the Doppler gate."},
+	{"guidanceProp.dopplerSpeedMinimumSignalGate", "Doppler speed minimum signal gate", "Minimum signal the Doppler gate accepts."},
+	{"guidanceProp.dopplerSpeedGateSearchRange", "Doppler speed gate search range", "Speed range searched by the Doppler gate."},
+	{"guidanceProp.dopplerSpeedGateAlphaFilter", "Doppler speed gate alpha filter", "Alpha coefficient of the Doppler gate tracking filter."},
+	{"guidanceProp.dopplerSpeedGateBetaFilter", "Doppler speed gate beta filter", "Beta coefficient of the Doppler gate tracking filter."},
+	{"guidanceProp.proportionalNavigationMultiplier", "Proportional navigation multiplier", "Affects how far ahead the weapon attempts to lead."},
+	{"guidanceProp.baseIndicatedAirSpeed", "Base indicated air speed", "Speed the control gains are tuned for."},
+	{"guidanceProp.PIDProportionalTerm", "PID proportional term", "Proportional gain of the autopilot."},
+	{"guidanceProp.PIDIntegralTerm", "PID integral term", "Integral gain of the autopilot."},
+	{"guidanceProp.PIDIntegralTermLimit", "PID integral term limit", "Limit of the autopilot integral term."},
+	{"guidanceProp.PIDDerivativeTerm", "PID derivative term", "Derivative gain of the autopilot."},
+	{"guidanceProp.inertialGuidanceDriftSpeed", "Inertial guidance drift speed", "Error accumulated by inertial guidance over time."},
+	{"guidanceProp.inertialNavigation", "Inertial navigation", "Whether the weapon can fly on inertial navigation."},
+	{"guidanceProp.distanceGate", "Distance gate", "Width of the distance gate."},
+	{"guidanceProp.inertialNavigationDriftSpeed", "Inertial navigation drift speed", "Error accumulated by inertial navigation over time."},
+	{"flightProp.maximumLaunchAngleHorizontalVertical", "Maximum launch angle (horizontally / vertically)", "Launch limits off the launcher axis."},
+	{"flightProp.aimSensitivity", "Aim sensitivity", "How strongly the weapon responds to aiming input."},
+	{"flightProp.maximumAxisValues", "Maximum axis values", "Limits of the control inputs."},
+	{"flightProp.maximumFinAngleOfAttack", "Maximum fin angle of attack", "Maximum deflection of the control fins."},
+	{"flightProp.finsLateralAcceleration", "Fins lateral acceleration", "Lateral acceleration produced by the fins."},
+	{"flightProp.maximumAOA", "Maximum angle of attack", "Maximum angle of attack of the body."},
+	{"flightProp.maximumFinLateralAcceleration", "Maximum fin lateral acceleration", "Maximum lateral acceleration produced by the fins."},
+	{"flightProp.wingAreaMultiplier", "Wing area multiplier", "Scales the lift generated by the wings."},
+	{"flightProp.maximumLateralAcceleration", "Max lateral acceleration", "Maximum lateral acceleration of the weapon."},
+	{"flightProp.startSpeed", "Start speed", "Speed when leaving the launcher."},
+	{"flightProp.maximumSpeed", "Maximum speed", "Top speed."},
+	{"flightProp.minimumRange", "Minimum range", "Shortest distance at which the weapon can be used."},
+	{"flightProp.maximumFlightRange", "Maximum flight range", "Distance flown before self-destruction."},
+	{"flightProp.tracer", "Has a tracer in its tail", "Whether the weapon carries a tracer."},
+	{"flightProp.loadFactorLimitAtLaunch", "Load factor limit at launch", "Maximum load factor right after launch."},
+	{"flightProp.maximumOverLoad", "Maximum G-load", "Maximum load factor the weapon can pull."},
+	{"flightProp.seaSkimming", "Sea skimming", "Whether the weapon flies close above the sea."},
+	{"flightProp.flightTimeUntilGuidanceStarts", "Flight time until guidance starts (delay)", "Time after launch before guidance begins."},
+	{"flightProp.flightTimeWhenPullLimit30%", "Flight time when pull limit reaches 30%", "Time after launch when 30% of the load factor is available."},
+	{"flightProp.flightTimeWhenPullLimit40%", "Flight time when pull limit reaches 40%", "Time after launch when 40% of the load factor is available."},
+	{"flightProp.flightTimeWhenPullLimit100%", "Flight time when pull limit reaches 100%", "Time after launch when the full load factor is available."},
+	{"flightProp.loft", "Loft", "Whether the weapon climbs before diving on the target."},
+	{"flightProp.loftAngle", "Loft angle", "Climb angle when lofting."},
+	{"flightProp.targetElevation", "Target elevation", "Elevation to the target at which lofting stops."},
+	{"flightProp.maximumTargetAngularChange", "Maximum target angular change", "Maximum line-of-sight rate the guidance follows."},
+	{"flightProp.thrustVectoring", "Thrust vectoring", "Whether the weapon steers with its exhaust."},
+	{"flightProp.thrustVectoringAngle", "Thrust vectoring angle", "Maximum deflection of the thrust."},
+	{"flightProp.startingGLimit", "Starting G-limit", "Load factor limit right after launch."},
+	{"flightProp.ETAToImpactWhenPropMultiplierReachesXPercentage30%", "ETA to impact when prop multiplier reaches 30%", "Time to impact at which the navigation constant reaches 30%."},
+	{"flightProp.ETAToImpactWhenPropMultiplierReachesXPercentage50%", "ETA to impact when prop multiplier reaches 50%", "Time to impact at which the navigation constant reaches 50%."},
+	{"flightProp.ETAToImpactWhenPropMultiplierReachesXPercentage80%", "ETA to impact when prop multiplier reaches 80%", "Time to impact at which the navigation constant reaches 80%."},
+	{"flightProp.ETAToImpactWhenPropMultiplierReachesXPercentage90%", "ETA to impact when prop multiplier reaches 90%", "Time to impact at which the navigation constant reaches 90%."},
+	{"flightProp.ETAToImpactWhenPropMultiplierReachesXPercentage100%", "ETA to impact when prop multiplier reaches 100%", "Time to impact at which the navigation constant reaches 100%."},
+	{"flightProp.ETAToImpactWhenPropMultiplierReachesXPercentageX%", "ETA to impact when prop multiplier reaches x%", "Time to impact per percent of the navigation constant."},
+}
@@ -0,0 +1,250 @@
+package models
+
+import (
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
+)
+
+type Group string
+
+const (
+	GroupPhysical Group = "physical"
+	GroupEngine   Group = "engine"
+	GroupFuse     Group = "fuse"
+	GroupGuidance Group = "guidance"
+	GroupFlight   Group = "flight"
+)
+
+// Groups lists the parameter groups in display order.
+var Groups = []Group{GroupPhysical, GroupEngine, GroupFuse, GroupGuidance, GroupFlight}
+
+var groupTitles = map[Group]string{
+	GroupPhysical: "Physical props",
+	GroupEngine:   "Engine props",
+	GroupFuse:     "Fuse & Warhead props",
+	GroupGuidance: "Guidance props",
+	GroupFlight:   "Flight props",
+}
+
+// groupKeys maps the JSON keys of the Params sub-structs to their group.
+var groupKeys = map[string]Group{
+	"physicalProp":       GroupPhysical,
+	"engineProp":         GroupEngine,
+	"fuseAndWarheadProp": GroupFuse,
+	"guidanceProp":       GroupGuidance,
+	"flightProp":         GroupFlight,
+}
+
+func (g Group) Title() string {
+	return groupTitles[g]
+}
+
+// Field describes a single weapon parameter.
+type Field struct {
+	Key         string   `json:"key"`
+	Label       string   `json:"label"`
+	Unit        string   `json:"unit,omitempty"`
+	Group       Group    `json:"group"`
+	Description string   `json:"description"`
+	Categories  []string `json:"categories"`
+
+	// Kind is the kind of value the field expects. Fields without a
+	// kind accept free text.
+	Kind   ValueKind `json:"kind,omitempty"`
+	Limits *Limits   `json:"limits,omitempty"`
+
+	// Better says whether higher or lower values are better. Weapons are
+	// ranked on the fields that have it.
+	Better Better `json:"better,omitempty"`
+
+	index []int
+}
+
+type fieldDoc struct {
+	key         string
+	label       string
+	description string
+}
+
+var (
+	fields      []Field
+	fieldsByKey = map[string]int{}
+)
+
+func init() {
+	docs := map[string]fieldDoc{}
+	for _, doc := range fieldDocs {
+		docs[doc.key] = doc
+	}
+
+	t := reflect.TypeOf(Params{})
+
+	for i := 0; i < t.NumField(); i++ {
+		group, ok := groupKeys[jsonName(t.Field(i))]
+		if !ok {
+			continue
+		}
+
+		gt := t.Field(i).Type
+
+		for j := 0; j < gt.NumField(); j++ {
+			sf := gt.Field(j)
+			key := jsonName(t.Field(i)) + "." + jsonName(sf)
+
+			doc, ok := docs[key]
+			if !ok {
+				panic(fmt.Sprintf("models: no field description for %s", key))
+			}
+
+			unit := sf.Tag.Get("unit")
+
+			kind := ValueKind(sf.Tag.Get("kind"))
+			if kind == "" && unit != "" {
+				kind = KindNumber
+			}
+
+			fieldsByKey[key] = len(fields)
+			fields = append(fields, Field{
+				Key:         key,
+				Label:       doc.label,
+				Unit:        unit,
+				Group:       group,
+				Description: doc.description,
+				Categories:  []string{},
+				Kind:        kind,
+				Limits:      limitsFor(key, unit),
+				Better:      fieldBetter[key],
+				index:       []int{i, j},
+			})
+		}
+	}
+
+	for category, keys := range categoryFields {
+		for _, key := range keys {
+			i, ok := fieldsByKey[key]
+			if !ok {
+				panic(fmt.Sprintf("models: unknown field %s in category %s", key, category))
+			}
+
+			fields[i].Categories = append(fields[i].Categories, category)
+		}
+	}
+
+	for i := range fields {
+		slices.Sort(fields[i].Categories)
+	}
+
+	for key := range fieldBetter {
+		if _, ok := fieldsByKey[key]; !ok {
+			panic(fmt.Sprintf("models: unknown ranked field %s", key))
+		}
+	}
+
+	for category, keys := range requiredFields {
+		for _, key := range keys {
+			if _, ok := fieldsByKey[key]; !ok {
+				panic(fmt.Sprintf("models: unknown required field %s in category %s", key, category))
+			}
+		}
+	}
+}
+
+func jsonName(sf reflect.StructField) string {
+	name, _, _ := strings.Cut(sf.Tag.Get("json"), ",")
+	return name
+}
+
+// Fields returns every weapon parameter in the order of the Params struct.
+func Fields() []Field {
+	return append([]Field(nil), fields...)
+}
+
+// FieldByKey looks up a parameter by its JSON path, e.g. "guidanceProp.gimbalLimit".
+func FieldByKey(key string) (Field, bool) {
+	i, ok := fieldsByKey[key]
+	if !ok {
+		return Field{}, false
+	}
+	return fields[i], true
+}
+
+// FieldsFor returns the parameters shown for category, in display order.
+func FieldsFor(category string) []Field {
+	var res []Field
+	for _, key := range categoryFields[category] {
+		res = append(res, fields[fieldsByKey[key]])
+	}
+	return res
+}
+
+// FieldsForAll returns the parameters shown for any of categories, in the
+// order of Fields. A category without a field list shows every parameter.
+func FieldsForAll(categories []string) []Field {
+	shown := map[string]bool{}
+
+	for _, category := range categories {
+		keys, ok := categoryFields[category]
+		if !ok {
+			return Fields()
+		}
+
+		for _, key := range keys {
+			shown[key] = true
+		}
+	}
+
+	var res []Field
+	for _, field := range fields {
+		if shown[field.Key] {
+			res = append(res, field)
+		}
+	}
+	return res
+}
+
+// Value returns the value of the field in params.
+func (f Field) Value(params *Params) *Value {
+	return reflect.ValueOf(params).Elem().FieldByIndex(f.index).Interface().(*Value)
+}
+
+// SetValue sets the value of the field in params.
+func (f Field) SetValue(params *Params, v *Value) {
+	reflect.ValueOf(params).Elem().FieldByIndex(f.index).Set(reflect.ValueOf(v))
+}
+
+// DisplayLabel returns the label as shown in the comparison tables, e.g. "Mass: [kg]".
+func (f Field) DisplayLabel() string {
+	if f.Unit == "" {
+		return f.Label + ":"
+	}
+	return fmt.Sprintf("%s: [%s]", f.Label, f.Unit)
+}
+
+// Project returns a copy of params holding only its name, its slug and the
+// listed keys, which are "category" or parameter keys. Unknown keys are
+// ignored.
+func (p *Params) Project(keys []string) *Params {
+	res := &Params{Name: p.Name, Slug: p.Slug}
+
+	src := reflect.ValueOf(p).Elem()
+	dst := reflect.ValueOf(res).Elem()
+
+	for _, key := range keys {
+		if key == "category" {
+			res.Category = p.Category
+			continue
+		}
+
+		i, ok := fieldsByKey[key]
+		if !ok {
+			continue
+		}
+
+		index := fields[i].index
+		dst.FieldByIndex(index).Set(src.FieldByIndex(index))
+	}
+
+	return res.Clone()
+}
@@ -0,0 +1,55 @@
+package models
+
+import "fmt"
+
+// Limits is the plausible range of a numeric parameter, inclusive.
+type Limits struct {
+	Min float64 `json:"min"`
+	Max float64 `json:"max"`
+}
+
+// unitLimits holds the default limits for each unit. They are deliberately
+// generous: the point is to catch typos and wrong units, not to judge values.
+var unitLimits = map[string]Limits{
+	"kg":   {Min: 0, Max: 10000},
+	"mm":   {Min: 0, Max: 3000},
+	"m":    {Min: 0, Max: 100000},
+	"km":   {Min: 0, Max: 1000},
+	"s":    {Min: 0, Max: 3600},
+	"N":    {Min: 0, Max: 2000000},
+	"m/s":  {Min: 0, Max: 5000},
+	"m/s²": {Min: 0, Max: 20000},
+	"°":    {Min: -360, Max: 360},
+	"°/s":  {Min: 0, Max: 3600},
+	"G":    {Min: 0, Max: 200},
+	"s/%":  {Min: 0, Max: 100},
+}
+
+// fieldLimits overrides unitLimits for fields whose range differs from
+// other fields in the same unit.
+var fieldLimits = map[string]Limits{
+	"physicalProp.length":                   {Min: 0, Max: 30},
+	"guidanceProp.dopplerSpeedMinimumValue": {Min: -5000, Max: 5000},
+	"guidanceProp.dopplerSpeedMaximumValue": {Min: -5000, Max: 5000},
+}
+
+func limitsFor(key, unit string) *Limits {
+	if l, ok := fieldLimits[key]; ok {
+		return &l
+	}
+
+	if l, ok := unitLimits[unit]; ok {
+		return &l
+	}
+
+	return nil
+}
+
+// Contains reports whether f lies within the limits.
+func (l Limits) Contains(f float64) bool {
+	return f >= l.Min && f <= l.Max
+}
+
+func (l Limits) String() string {
+	return fmt.Sprintf("%g–%g", l.Min, l.Max)
+}
@@ -1,176 +1,189 @@
 package models
 
-import "go.mongodb.org/mongo-driver/bson"
+import (
+	"time"
 
-type Name struct {
-	Name     string `json:"name"`
-	Category string `json:"category"`
-}
+	"go.mongodb.org/mongo-driver/bson"
+)
 
-type Category struct {
-	Category string `json:"category"`
+type Name struct {
+	Name     string `json:"name" bson:"name"`
+	Slug     string `json:"slug,omitempty" bson:"slug,omitempty"`
+	Category string `json:"category" bson:"category"`
 }
 
 type PhysicalProp struct {
-	Mass                     string `json:"mass,omitempty"`
-	MassAtEndOfBoosterBurn   string `json:"massAtEndOfBoosterBurn,omitempty"`
-	MassAtEndOfSustainerBurn string `json:"massAtEndOfSustainerBurn,omitempty"`
-	Calibre                  string `json:"calibre,omitempty"`
-	Length                   string `json:"length,omitempty"`
+	Mass                     *Value `json:"mass,omitempty" bson:"mass,omitempty" unit:"kg"`
+	MassAtEndOfBoosterBurn   *Value `json:"massAtEndOfBoosterBurn,omitempty" bson:"massAtEndOfBoosterBurn,omitempty" unit:"kg"`
+	MassAtEndOfSustainerBurn *Value `json:"massAtEndOfSustainerBurn,omitempty" bson:"massAtEndOfSustainerBurn,omitempty" unit:"kg"`
+	Calibre                  *Value `json:"calibre,omitempty" bson:"calibre,omitempty" unit:"mm"`
+	Length                   *Value `json:"length,omitempty" bson:"length,omitempty" unit:"m"`
 }
 
 type EngineProp struct {
-	ForceExertedByBooster      string `json:"forceExertedByBooster,omitempty"`
-	BurnTimeOfBooster          string `json:"burnTimeOfBooster,omitempty"`
-	RawAccelerationAtIgnition  string `json:"rawAccelerationAtIgnition,omitempty"`
-	SpecificImpulseOfBooster   string `json:"specificImpulseOfBooster,omitempty"`
-	DeltaSpeedOfBooster        string `json:"deltaSpeedOfBooster,omitempty"`
-	BoosterStartDelay          string `json:"boosterStartDelay,omitempty"`
-	ForceExertedBySustainer    string `json:"forceExertedBySustainer,omitempty"`
-	BurnTimeOfSustainer        string `json:"burnTimeOfSustainer,omitempty"`
-	SpecificImpulseOfSustainer string `json:"specificImpulseOfSustainer,omitempty"`
-	DeltaSpeedOfSustainer      string `json:"deltaSpeedOfSustainer,omitempty"`
-	TotalDeltaSpeed            string `json:"totalDeltaSpeed,omitempty"`
+	ForceExertedByBooster      *Value `json:"forceExertedByBooster,omitempty" bson:"forceExertedByBooster,omitempty" unit:"N"`
+	BurnTimeOfBooster          *Value `json:"burnTimeOfBooster,omitempty" bson:"burnTimeOfBooster,omitempty" unit:"s"`
+	RawAccelerationAtIgnition  *Value `json:"rawAccelerationAtIgnition,omitempty" bson:"rawAccelerationAtIgnition,omitempty" unit:"m/s²"`
+	SpecificImpulseOfBooster   *Value `json:"specificImpulseOfBooster,omitempty" bson:"specificImpulseOfBooster,omitempty" unit:"s"`
+	DeltaSpeedOfBooster        *Value `json:"deltaSpeedOfBooster,omitempty" bson:"deltaSpeedOfBooster,omitempty" unit:"m/s"`
+	BoosterStartDelay          *Value `json:"boosterStartDelay,omitempty" bson:"boosterStartDelay,omitempty" unit:"s"`
+	ForceExertedBySustainer    *Value `json:"forceExertedBySustainer,omitempty" bson:"forceExertedBySustainer,omitempty" unit:"N"`
+	BurnTimeOfSustainer        *Value `json:"burnTimeOfSustainer,omitempty" bson:"burnTimeOfSustainer,omitempty" unit:"s"`
+	SpecificImpulseOfSustainer *Value `json:"specificImpulseOfSustainer,omitempty" bson:"specificImpulseOfSustainer,omitempty" unit:"s"`
+	DeltaSpeedOfSustainer      *Value `json:"deltaSpeedOfSustainer,omitempty" bson:"deltaSpeedOfSustainer,omitempty" unit:"m/s"`
+	TotalDeltaSpeed            *Value `json:"totalDeltaSpeed,omitempty" bson:"totalDeltaSpeed,omitempty" unit:"m/s"`
 }
 
 type FuseAndWarheadProp struct {
-	ExplosiveMass                string `json:"explosiveMass,omitempty"`
-	TandemCharge                 string `json:"tandemCharge,omitempty"`
-	Penetration                  string `json:"penetration,omitempty"`
-	ProximityFuse                string `json:"proximityFuse,omitempty"`
-	ProximityFuseRange           string `json:"proximityFuseRange,omitempty"`
-	ProximityFuseArmingDistance  string `json:"proximityFuseArmingDistance,omitempty"`
-	ProximityFuseShellDetection  string `json:"proximityFuseShellDetection,omitempty"`
-	ProximityFuseMinimumAltitude string `json:"proximityFuseMinimumAltitude,omitempty"`
-	ProximityFuseDelay           string `json:"proximityFuseDelay,omitempty"`
+	ExplosiveMass                *Value `json:"explosiveMass,omitempty" bson:"explosiveMass,omitempty" unit:"kg"`
+	TandemCharge                 *Value `json:"tandemCharge,omitempty" bson:"tandemCharge,omitempty" kind:"bool"`
+	Penetration                  *Value `json:"penetration,omitempty" bson:"penetration,omitempty" unit:"mm"`
+	ProximityFuse                *Value `json:"proximityFuse,omitempty" bson:"proximityFuse,omitempty" kind:"bool"`
+	ProximityFuseRange           *Value `json:"proximityFuseRange,omitempty" bson:"proximityFuseRange,omitempty" unit:"m"`
+	ProximityFuseArmingDistance  *Value `json:"proximityFuseArmingDistance,omitempty" bson:"proximityFuseArmingDistance,omitempty" unit:"m"`
+	ProximityFuseShellDetection  *Value `json:"proximityFuseShellDetection,omitempty" bson:"proximityFuseShellDetection,omitempty" kind:"bool"`
+	ProximityFuseMinimumAltitude *Value `json:"proximityFuseMinimumAltitude,omitempty" bson:"proximityFuseMinimumAltitude,omitempty" unit:"m"`
+	ProximityFuseDelay           *Value `json:"proximityFuseDelay,omitempty" bson:"proximityFuseDelay,omitempty" unit:"s"`
 }
 
 type GuidanceProp struct {
-	Zoom                                          string `json:"zoom,omitempty"`
-	GuidanceType                                  string `json:"guidanceType,omitempty"`
-	GuidanceStartDelay                            string `json:"guidanceStartDelay,omitempty"`
-	GuidanceDuration                              string `json:"guidanceDuration,omitempty"`
-	GuidanceRange                                 string `json:"guidanceRange,omitempty"`
-	LaunchSector                                  string `json:"launchSector,omitempty"`
-	ControlConeFOV                                string `json:"controlConeFOV,omitempty"`
-	AimTrackingSensitivity                        string `json:"aimTrackingSensitivity,omitempty"`
-	MaximumAngleAllowedBetweenMissileAndCrosshair string `json:"maximumAngleAllowedBetweenMissileAndCrosshair,omitempty"`
-	SeekerWarmUpTime                              string `json:"seekerWarmUpTime,omitempty"`
-	SeekerSearchDuration                          string `json:"seekerSearchDuration,omitempty"`
-	FieldOfView                                   string `json:"fieldOfView,omitempty"`
-	OpticSightFieldOfView                         string `json:"opticSightFieldOfView,omitempty"`
-	GimbalLimit                                   string `json:"gimbalLimit,omitempty"`
-	TrackRate                                     string `json:"trackRate,omitempty"`
-	UncageSeekerBeforeLaunch                      string `json:"uncageSeekerBeforeLaunch,omitempty"`
-	MaximumLockAngleBeforeLaunch                  string `json:"maximumLockAngleBeforeLaunch,omitempty"`
-	MinimumAngleBetweenSeekerAndSunForNotCapture  string `json:"minimumAngleBetweenSeekerAndSunForNotCapture,omitempty"`
-	CanLockGround                                 string `json:"canLockGround,omitempty"`
-	LockOnRangeGround                             string `json:"lockOnRangeGround,omitempty"`
-	LockOnRangeVehicle                            string `json:"lockOnRangeVehicle,omitempty"`
-	LockOnRangeFromRearAspect                     string `json:"lockOnRangeFromRearAspect,omitempty"`
-	FlareDetectionRange                           string `json:"flareDetectionRange,omitempty"`
-	IRCMDetectionRange                            string `json:"IRCMDetectionRange,omitempty"`
-	DIRCMDetectionRange                           string `json:"DIRCMDetectionRange,omitempty"`
-	HeadOnLockOnRangeAgainstAfterburnerTarget     string `json:"headOnLockOnRangeAgainstAfterburnerTarget,omitempty"`
-	IRCCM                                         string `json:"IRCCM,omitempty"`
-	IRCCMType                                     string `json:"IRCCMType,omitempty"`
-	IRCCMFieldOfView                              string `json:"IRCCMFieldOfView,omitempty"`
-	IRCCMRejectionThreshold                       string `json:"IRCCMRejectionThreshold,omitempty"`
-	IRCCMReactionTime                             string `json:"IRCCMReactionTime,omitempty"`
-	LockOnRangeFromAllAspect                      string `json:"lockOnRangeFromAllAspect,omitempty"`
-	CountermeasureDetectionRange                  string `json:"countermeasureDetectionRange,omitempty"`
-	MaximumBreakLockTime                          string `json:"maximumBreakLockTime,omitempty"`
-	CanBeSlavedToRadar                            string `json:"canBeSlavedToRadar,omitempty"`
-	CanLockAfterLaunch                            string `json:"canLockAfterLaunch,omitempty"`
-	Band                                          string `json:"band,omitempty"`
-	AngularSpeedRejectionThreshold                string `json:"angularSpeedRejectionThreshold,omitempty"`
-	AngularRejectionThresholdRange                string `json:"angularRejectionThresholdRange,omitempty"`
-	AccelerationRejectionThresholdRange           string `json:"accelerationRejectionThresholdRange,omitempty"`
-	SidelobeAttenuation                           string `json:"sidelobeAttenuation,omitempty"`
-	TransmitterPower                              string `json:"transmitterPower,omitempty"`
-	TransmitterAngleOfHalfSensitivity             string `json:"transmitterAngleOfHalfSensitivity,omitempty"`
-	TransmitterSidelobeSensitivity                string `json:"transmitterSidelobeSensitivity,omitempty"`
-	ReceiverAngleOfHalfSensitivity                string `json:"receiverAngleOfHalfSensitivity,omitempty"`
-	ReceiverSidelobeSensitivity                   string `json:"receiverSidelobeSensitivity,omitempty"`
-	DistanceMinimumValue                          string `json:"distanceMinimumValue,omitempty"`
-	DistanceMaximumValue                          string `json:"distanceMaximumValue,omitempty"`
-	DistanceWidth                                 string `json:"distanceWidth,omitempty"`
-	DistanceMinimumSignalGate                     string `json:"distanceMinimumSignalGate,omitempty"`
-	DistanceRefWidth                              string `json:"distanceRefWidth,omitempty"`
-	DistanceGateSearchRange                       string `json:"distanceGateSearchRange,omitempty"`
-	DistanceGateAlphaFilter                       string `json:"distanceGateAlphaFilter,omitempty"`
-	DistanceGateBetaFilter                        string `json:"distanceGateBetaFilter,omitempty"`
-	DopplerSpeedMinimumValue                      string `json:"dopplerSpeedMinimumValue,omitempty"`
-	DopplerSpeedMaximumValue                      string `json:"dopplerSpeedMaximumValue,omitempty"`
-	DopplerSpeedWidth                             string `json:"dopplerSpeedWidth,omitempty"`
-	DopplerSpeedRefWidth                          string `json:"dopplerSpeedRefWidth,omitempty"`
-	DopplerSpeedMinimumSignalGate                 string `json:"dopplerSpeedMinimumSignalGate,omitempty"`
-	DopplerSpeedGateSearchRange                   string `json:"dopplerSpeedGateSearchRange,omitempty"`
-	DopplerSpeedGateAlphaFilter                   string `json:"dopplerSpeedGateAlphaFilter,omitempty"`
-	DopplerSpeedGateBetaFilter                    string `json:"dopplerSpeedGateBetaFilter,omitempty"`
-	ProportionalNavigationMultiplier              string `json:"proportionalNavigationMultiplier,omitempty"`
-	BaseIndicatedAirSpeed                         string `json:"baseIndicatedAirSpeed,omitempty"`
-	PIDProportionalTerm                           string `json:"PIDProportionalTerm,omitempty"`
-	PIDIntegralTerm                               string `json:"PIDIntegralTerm,omitempty"`
-	PIDIntegralTermLimit                          string `json:"PIDIntegralTermLimit,omitempty"`
-	PIDDerivativeTerm                             string `json:"PIDDerivativeTerm,omitempty"`
-	InertialGuidanceDriftSpeed                    string `json:"inertialGuidanceDriftSpeed,omitempty"`
-	InertialNavigation                            string `json:"inertialNavigation,omitempty"`
-	DistanceGate                                  string `json:"distanceGate,omitempty"`
-	InertialNavigationDriftSpeed                  string `json:"inertialNavigationDriftSpeed,omitempty"`
+	Zoom                                          *Value `json:"zoom,omitempty" bson:"zoom,omitempty"`
+	GuidanceType                                  *Value `json:"guidanceType,omitempty" bson:"guidanceType,omitempty"`
+	GuidanceStartDelay                            *Value `json:"guidanceStartDelay,omitempty" bson:"guidanceStartDelay,omitempty" unit:"s"`
+	GuidanceDuration                              *Value `json:"guidanceDuration,omitempty" bson:"guidanceDuration,omitempty" unit:"s"`
+	GuidanceRange                                 *Value `json:"guidanceRange,omitempty" bson:"guidanceRange,omitempty" unit:"km"`
+	LaunchSector                                  *Value `json:"launchSector,omitempty" bson:"launchSector,omitempty" unit:"°"`
+	ControlConeFOV                                *Value `json:"controlConeFOV,omitempty" bson:"controlConeFOV,omitempty" unit:"°"`
+	AimTrackingSensitivity                        *Value `json:"aimTrackingSensitivity,omitempty" bson:"aimTrackingSensitivity,omitempty"`
+	MaximumAngleAllowedBetweenMissileAndCrosshair *Value `json:"maximumAngleAllowedBetweenMissileAndCrosshair,omitempty" bson:"maximumAngleAllowedBetweenMissileAndCrosshair,omitempty" unit:"°"`
+	SeekerWarmUpTime                              *Value `json:"seekerWarmUpTime,omitempty" bson:"seekerWarmUpTime,omitempty" unit:"s"`
+	SeekerSearchDuration                          *Value `json:"seekerSearchDuration,omitempty" bson:"seekerSearchDuration,omitempty" unit:"s"`
+	FieldOfView                                   *Value `json:"fieldOfView,omitempty" bson:"fieldOfView,omitempty" unit:"°"`
+	OpticSightFieldOfView                         *Value `json:"opticSightFieldOfView,omitempty" bson:"opticSightFieldOfView,omitempty" unit:"°"`
+	GimbalLimit                                   *Value `json:"gimbalLimit,omitempty" bson:"gimbalLimit,omitempty" unit:"°"`
+	TrackRate                                     *Value `json:"trackRate,omitempty" bson:"trackRate,omitempty" unit:"°/s"`
+	UncageSeekerBeforeLaunch                      *Value `json:"uncageSeekerBeforeLaunch,omitempty" bson:"uncageSeekerBeforeLaunch,omitempty" kind:"bool"`
+	MaximumLockAngleBeforeLaunch                  *Value `json:"maximumLockAngleBeforeLaunch,omitempty" bson:"maximumLockAngleBeforeLaunch,omitempty" unit:"°"`
+	MinimumAngleBetweenSeekerAndSunForNotCapture  *Value `json:"minimumAngleBetweenSeekerAndSunForNotCapture,omitempty" bson:"minimumAngleBetweenSeekerAndSunForNotCapture,omitempty" unit:"°"`
+	CanLockGround                                 *Value `json:"canLockGround,omitempty" bson:"canLockGround,omitempty" kind:"bool"`
+	LockOnRangeGround                             *Value `json:"lockOnRangeGround,omitempty" bson:"lockOnRangeGround,omitempty" unit:"km"`
+	LockOnRangeVehicle                            *Value `json:"lockOnRangeVehicle,omitempty" bson:"lockOnRangeVehicle,omitempty" unit:"km"`
+	LockOnRangeFromRearAspect                     *Value `json:"lockOnRangeFromRearAspect,omitempty" bson:"lockOnRangeFromRearAspect,omitempty" unit:"km"`
+	FlareDetectionRange                           *Value `json:"flareDetectionRange,omitempty" bson:"flareDetectionRange,omitempty" unit:"km"`
+	IRCMDetectionRange                            *Value `json:"IRCMDetectionRange,omitempty" bson:"IRCMDetectionRange,omitempty" unit:"km"`
+	DIRCMDetectionRange                           *Value `json:"DIRCMDetectionRange,omitempty" bson:"DIRCMDetectionRange,omitempty" unit:"km"`
+	HeadOnLockOnRangeAgainstAfterburnerTarget     *Value `json:"headOnLockOnRangeAgainstAfterburnerTarget,omitempty" bson:"headOnLockOnRangeAgainstAfterburnerTarget,omitempty" unit:"km"`
+	IRCCM                                         *Value `json:"IRCCM,omitempty" bson:"IRCCM,omitempty" kind:"bool"`
+	IRCCMType                                     *Value `json:"IRCCMType,omitempty" bson:"IRCCMType,omitempty"`
+	IRCCMFieldOfView                              *Value `json:"IRCCMFieldOfView,omitempty" bson:"IRCCMFieldOfView,omitempty" unit:"°"`
+	IRCCMRejectionThreshold                       *Value `json:"IRCCMRejectionThreshold,omitempty" bson:"IRCCMRejectionThreshold,omitempty"`
+	IRCCMReactionTime                             *Value `json:"IRCCMReactionTime,omitempty" bson:"IRCCMReactionTime,omitempty" unit:"s"`
+	LockOnRangeFromAllAspect                      *Value `json:"lockOnRangeFromAllAspect,omitempty" bson:"lockOnRangeFromAllAspect,omitempty" unit:"km"`
+	CountermeasureDetectionRange                  *Value `json:"countermeasureDetectionRange,omitempty" bson:"countermeasureDetectionRange,omitempty" unit:"km"`
+	MaximumBreakLockTime                          *Value `json:"maximumBreakLockTime,omitempty" bson:"maximumBreakLockTime,omitempty" unit:"s"`
+	CanBeSlavedToRadar                            *Value `json:"canBeSlavedToRadar,omitempty" bson:"canBeSlavedToRadar,omitempty" kind:"bool"`
+	CanLockAfterLaunch                            *Value `json:"canLockAfterLaunch,omitempty" bson:"canLockAfterLaunch,omitempty" kind:"bool"`
+	Band                                          *Value `json:"band,omitempty" bson:"band,omitempty"`
+	AngularSpeedRejectionThreshold                *Value `json:"angularSpeedRejectionThreshold,omitempty" bson:"angularSpeedRejectionThreshold,omitempty" unit:"°/s"`
+	AngularRejectionThresholdRange                *Value `json:"angularRejectionThresholdRange,omitempty" bson:"angularRejectionThresholdRange,omitempty" unit:"°"`
+	AccelerationRejectionThresholdRange           *Value `json:"accelerationRejectionThresholdRange,omitempty" bson:"accelerationRejectionThresholdRange,omitempty" unit:"m/s²"`
+	SidelobeAttenuation                           *Value `json:"sidelobeAttenuation,omitempty" bson:"sidelobeAttenuation,omitempty"`
+	TransmitterPower                              *Value `json:"transmitterPower,omitempty" bson:"transmitterPower,omitempty"`
+	TransmitterAngleOfHalfSensitivity             *Value `json:"transmitterAngleOfHalfSensitivity,omitempty" bson:"transmitterAngleOfHalfSensitivity,omitempty"`
+	TransmitterSidelobeSensitivity                *Value `json:"transmitterSidelobeSensitivity,omitempty" bson:"transmitterSidelobeSensitivity,omitempty"`
+	ReceiverAngleOfHalfSensitivity                *Value `json:"receiverAngleOfHalfSensitivity,omitempty" bson:"receiverAngleOfHalfSensitivity,omitempty"`
+	ReceiverSidelobeSensitivity                   *Value `json:"receiverSidelobeSensitivity,omitempty" bson:"receiverSidelobeSensitivity,omitempty"`
+	DistanceMinimumValue                          *Value `json:"distanceMinimumValue,omitempty" bson:"distanceMinimumValue,omitempty"`
+	DistanceMaximumValue                          *Value `json:"distanceMaximumValue,omitempty" bson:"distanceMaximumValue,omitempty"`
+	DistanceWidth                                 *Value `json:"distanceWidth,omitempty" bson:"distanceWidth,omitempty"`
+	DistanceMinimumSignalGate                     *Value `json:"distanceMinimumSignalGate,omitempty" bson:"distanceMinimumSignalGate,omitempty"`
+	DistanceRefWidth                              *Value `json:"distanceRefWidth,omitempty" bson:"distanceRefWidth,omitempty" unit:"m"`
+	DistanceGateSearchRange                       *Value `json:"distanceGateSearchRange,omitempty" bson:"distanceGateSearchRange,omitempty" unit:"m"`
+	DistanceGateAlphaFilter                       *Value `json:"distanceGateAlphaFilter,omitempty" bson:"distanceGateAlphaFilter,omitempty"`
+	DistanceGateBetaFilter                        *Value `json:"distanceGateBetaFilter,omitempty" bson:"distanceGateBetaFilter,omitempty"`
+	DopplerSpeedMinimumValue                      *Value `json:"dopplerSpeedMinimumValue,omitempty" bson:"dopplerSpeedMinimumValue,omitempty" unit:"m/s"`
+	DopplerSpeedMaximumValue                      *Value `json:"dopplerSpeedMaximumValue,omitempty" bson:"dopplerSpeedMaximumValue,omitempty" unit:"m/s"`
+	DopplerSpeedWidth                             *Value `json:"dopplerSpeedWidth,omitempty" bson:"dopplerSpeedWidth,omitempty" unit:"m/s"`
+	DopplerSpeedRefWidth                          *Value `json:"dopplerSpeedRefWidth,omitempty" bson:"dopplerSpeedRefWidth,omitempty" unit:"m/s"`
+	DopplerSpeedMinimumSignalGate                 *Value `json:"dopplerSpeedMinimumSignalGate,omitempty" bson:"dopplerSpeedMinimumSignalGate,omitempty" unit:"m/s"`
+	DopplerSpeedGateSearchRange                   *Value `json:"dopplerSpeedGateSearchRange,omitempty" bson:"dopplerSpeedGateSearchRange,omitempty" unit:"m/s"`
+	DopplerSpeedGateAlphaFilter                   *Value `json:"dopplerSpeedGateAlphaFilter,omitempty" bson:"dopplerSpeedGateAlphaFilter,omitempty"`
+	DopplerSpeedGateBetaFilter                    *Value `json:"dopplerSpeedGateBetaFilter,omitempty" bson:"dopplerSpeedGateBetaFilter,omitempty"`
+	ProportionalNavigationMultiplier              *Value `json:"proportionalNavigationMultiplier,omitempty" bson:"proportionalNavigationMultiplier,omitempty"`
+	BaseIndicatedAirSpeed                         *Value `json:"baseIndicatedAirSpeed,omitempty" bson:"baseIndicatedAirSpeed,omitempty" unit:"m/s"`
+	PIDProportionalTerm                           *Value `json:"PIDProportionalTerm,omitempty" bson:"PIDProportionalTerm,omitempty"`
+	PIDIntegralTerm                               *Value `json:"PIDIntegralTerm,omitempty" bson:"PIDIntegralTerm,omitempty"`
+	PIDIntegralTermLimit                          *Value `json:"PIDIntegralTermLimit,omitempty" bson:"PIDIntegralTermLimit,omitempty"`
+	PIDDerivativeTerm                             *Value `json:"PIDDerivativeTerm,omitempty" bson:"PIDDerivativeTerm,omitempty"`
+	InertialGuidanceDriftSpeed                    *Value `json:"inertialGuidanceDriftSpeed,omitempty" bson:"inertialGuidanceDriftSpeed,omitempty"`
+	InertialNavigation                            *Value `json:"inertialNavigation,omitempty" bson:"inertialNavigation,omitempty" kind:"bool"`
+	DistanceGate                                  *Value `json:"distanceGate,omitempty" bson:"distanceGate,omitempty" unit:"m"`
+	InertialNavigationDriftSpeed                  *Value `json:"inertialNavigationDriftSpeed,omitempty" bson:"inertialNavigationDriftSpeed,omitempty"`
 }
 
 type FlightProp struct {
-	MaximumLaunchAngleHorizontalVertical               string `json:"maximumLaunchAngleHorizontalVertical,omitempty"`
-	AimSensitivity                                     string `json:"aimSensitivity,omitempty"`
-	MaximumAxisValues                                  string `json:"maximumAxisValues,omitempty"`
-	MaximumFinAngleOfAttack                            string `json:"maximumFinAngleOfAttack,omitempty"`
-	FinsLateralAcceleration                            string `json:"finsLateralAcceleration,omitempty"`
-	MaximumAOA                                         string `json:"maximumAOA,omitempty"`
-	MaximumFinLateralAcceleration                      string `json:"maximumFinLateralAcceleration,omitempty"`
-	WingAreaMultiplier                                 string `json:"wingAreaMultiplier,omitempty"`
-	MaximumLateralAcceleration                         string `json:"maximumLateralAcceleration,omitempty"`
-	StartSpeed                                         string `json:"startSpeed,omitempty"`
-	MaximumSpeed                                       string `json:"maximumSpeed,omitempty"`
-	MinimumRange                                       string `json:"minimumRange,omitempty"`
-	MaximumFlightRange                                 string `json:"maximumFlightRange,omitempty"`
-	Tracer                                             string `json:"tracer,omitempty"`
-	LoadFactorLimitAtLaunch                            string `json:"loadFactorLimitAtLaunch,omitempty"`
-	MaximumOverLoad                                    string `json:"maximumOverLoad,omitempty"`
-	SeaSkimming                                        string `json:"seaSkimming,omitempty"`
-	FlightTimeUntilGuidanceStarts                      string `json:"flightTimeUntilGuidanceStarts,omitempty"`
-	FlightTimeWhenPullLimit30                          string `json:"flightTimeWhenPullLimit30%,omitempty"`
-	FlightTimeWhenPullLimit40                          string `json:"flightTimeWhenPullLimit40%,omitempty"`
-	FlightTimeWhenPullLimit100                         string `json:"flightTimeWhenPullLimit100%,omitempty"`
-	Loft                                               string `json:"loft,omitempty"`
-	LoftAngle                                          string `json:"loftAngle,omitempty"`
-	TargetElevation                                    string `json:"targetElevation,omitempty"`
-	MaximumTargetAngularChange                         string `json:"maximumTargetAngularChange"`
-	ThrustVectoring                                    string `json:"thrustVectoring,omitempty"`
-	ThrustVectoringAngle                               string `json:"thrustVectoringAngle,omitempty"`
-	StartingGLimit                                     string `json:"startingGLimit,omitempty"`
-	ETAToImpactWhenPropMultiplierReachesXPercentage30  string `json:"ETAToImpactWhenPropMultiplierReachesXPercentage30%,omitempty"`
-	ETAToImpactWhenPropMultiplierReachesXPercentage50  string `json:"ETAToImpactWhenPropMultiplierReachesXPercentage50%,omitempty"`
-	ETAToImpactWhenPropMultiplierReachesXPercentage80  string `json:"ETAToImpactWhenPropMultiplierReachesXPercentage80%,omitempty"`
-	ETAToImpactWhenPropMultiplierReachesXPercentage90  string `json:"ETAToImpactWhenPropMultiplierReachesXPercentage90%,omitempty"`
-	ETAToImpactWhenPropMultiplierReachesXPercentage100 string `json:"ETAToImpactWhenPropMultiplierReachesXPercentage100%,omitempty"`
-	ETAToImpactWhenPropMultiplierReachesXPercentageX   string `json:"ETAToImpactWhenPropMultiplierReachesXPercentageX%,omitempty"`
+	MaximumLaunchAngleHorizontalVertical               *Value `json:"maximumLaunchAngleHorizontalVertical,omitempty" bson:"maximumLaunchAngleHorizontalVertical,omitempty" unit:"°"`
+	AimSensitivity                                     *Value `json:"aimSensitivity,omitempty" bson:"aimSensitivity,omitempty"`
+	MaximumAxisValues                                  *Value `json:"maximumAxisValues,omitempty" bson:"maximumAxisValues,omitempty"`
+	MaximumFinAngleOfAttack                            *Value `json:"maximumFinAngleOfAttack,omitempty" bson:"maximumFinAngleOfAttack,omitempty" unit:"°"`
+	FinsLateralAcceleration                            *Value `json:"finsLateralAcceleration,omitempty" bson:"finsLateralAcceleration,omitempty"`
+	MaximumAOA                                         *Value `json:"maximumAOA,omitempty" bson:"maximumAOA,omitempty" unit:"°"`
+	MaximumFinLateralAcceleration                      *Value `json:"maximumFinLateralAcceleration,omitempty" bson:"maximumFinLateralAcceleration,omitempty"`
+	WingAreaMultiplier                                 *Value `json:"wingAreaMultiplier,omitempty" bson:"wingAreaMultiplier,omitempty"`
+	MaximumLateralAcceleration                         *Value `json:"maximumLateralAcceleration,omitempty" bson:"maximumLateralAcceleration,omitempty" unit:"G"`
+	StartSpeed                                         *Value `json:"startSpeed,omitempty" bson:"startSpeed,omitempty" unit:"m/s"`
+	MaximumSpeed                                       *Value `json:"maximumSpeed,omitempty" bson:"maximumSpeed,omitempty" unit:"m/s"`
+	MinimumRange                                       *Value `json:"minimumRange,omitempty" bson:"minimumRange,omitempty" unit:"m"`
+	MaximumFlightRange                                 *Value `json:"maximumFlightRange,omitempty" bson:"maximumFlightRange,omitempty" unit:"km"`
+	Tracer                                             *Value `json:"tracer,omitempty" bson:"tracer,omitempty" kind:"bool"`
+	LoadFactorLimitAtLaunch                            *Value `json:"loadFactorLimitAtLaunch,omitempty" bson:"loadFactorLimitAtLaunch,omitempty" unit:"G"`
+	MaximumOverLoad                                    *Value `json:"maximumOverLoad,omitempty" bson:"maximumOverLoad,omitempty" unit:"G"`
+	SeaSkimming                                        *Value `json:"seaSkimming,omitempty" bson:"seaSkimming,omitempty" kind:"bool"`
+	FlightTimeUntilGuidanceStarts                      *Value `json:"flightTimeUntilGuidanceStarts,omitempty" bson:"flightTimeUntilGuidanceStarts,omitempty" unit:"s"`
+	FlightTimeWhenPullLimit30                          *Value `json:"flightTimeWhenPullLimit30%,omitempty" bson:"flightTimeWhenPullLimit30%,omitempty" unit:"s"`
+	FlightTimeWhenPullLimit40                          *Value `json:"flightTimeWhenPullLimit40%,omitempty" bson:"flightTimeWhenPullLimit40%,omitempty" unit:"s"`
+	FlightTimeWhenPullLimit100                         *Value `json:"flightTimeWhenPullLimit100%,omitempty" bson:"flightTimeWhenPullLimit100%,omitempty" unit:"s"`
+	Loft                                               *Value `json:"loft,omitempty" bson:"loft,omitempty" kind:"bool"`
+	LoftAngle                                          *Value `json:"loftAngle,omitempty" bson:"loftAngle,omitempty" unit:"°"`
+	TargetElevation                                    *Value `json:"targetElevation,omitempty" bson:"targetElevation,omitempty" unit:"°"`
+	MaximumTargetAngularChange                         *Value `json:"maximumTargetAngularChange,omitempty" bson:"maximumTargetAngularChange,omitempty" unit:"°/s"`
+	ThrustVectoring                                    *Value `json:"thrustVectoring,omitempty" bson:"thrustVectoring,omitempty" kind:"bool"`
+	ThrustVectoringAngle                               *Value `json:"thrustVectoringAngle,omitempty" bson:"thrustVectoringAngle,omitempty" unit:"°"`
+	StartingGLimit                                     *Value `json:"startingGLimit,omitempty" bson:"startingGLimit,omitempty" unit:"G"`
+	ETAToImpactWhenPropMultiplierReachesXPercentage30  *Value `json:"ETAToImpactWhenPropMultiplierReachesXPercentage30%,omitempty" bson:"ETAToImpactWhenPropMultiplierReachesXPercentage30%,omitempty" unit:"s"`
+	ETAToImpactWhenPropMultiplierReachesXPercentage50  *Value `json:"ETAToImpactWhenPropMultiplierReachesXPercentage50%,omitempty" bson:"ETAToImpactWhenPropMultiplierReachesXPercentage50%,omitempty" unit:"s"`
+	ETAToImpactWhenPropMultiplierReachesXPercentage80  *Value `json:"ETAToImpactWhenPropMultiplierReachesXPercentage80%,omitempty" bson:"ETAToImpactWhenPropMultiplierReachesXPercentage80%,omitempty" unit:"s"`
+	ETAToImpactWhenPropMultiplierReachesXPercentage90  *Value `json:"ETAToImpactWhenPropMultiplierReachesXPercentage90%,omitempty" bson:"ETAToImpactWhenPropMultiplierReachesXPercentage90%,omitempty" unit:"s"`
+	ETAToImpactWhenPropMultiplierReachesXPercentage100 *Value `json:"ETAToImpactWhenPropMultiplierReachesXPercentage100%,omitempty" bson:"ETAToImpactWhenPropMultiplierReachesXPercentage100%,omitempty" unit:"s"`
+	ETAToImpactWhenPropMultiplierReachesXPercentageX   *Value `json:"ETAToImpactWhenPropMultiplierReachesXPercentageX%,omitempty" bson:"ETAToImpactWhenPropMultiplierReachesXPercentageX%,omitempty" unit:"s/%"`
 }
 
 type Params struct {
-	Category           string `json:"category"`
-	Name               string `json:"name"`
-	PhysicalProp       `json:"physicalProp"`
-	EngineProp         `json:"engineProp"`
-	FuseAndWarheadProp `json:"fuseAndWarheadProp"`
-	GuidanceProp       `json:"guidanceProp"`
-	FlightProp         `json:"flightProp"`
+	Category string `json:"category" bson:"category"`
+	Name     string `json:"name" bson:"name"`
+	// Slug names the weapon in URLs. The store gives it on insert and keeps
+	// it when the weapon is renamed, so links stay valid.
+	Slug               string `json:"slug,omitempty" bson:"slug,omitempty"`
+	PhysicalProp       `json:"physicalProp" bson:"physicalProp"`
+	EngineProp         `json:"engineProp" bson:"engineProp"`
+	FuseAndWarheadProp `json:"fuseAndWarheadProp" bson:"fuseAndWarheadProp"`
+	GuidanceProp       `json:"guidanceProp" bson:"guidanceProp"`
+	FlightProp         `json:"flightProp" bson:"flightProp"`
+	Deleted            *Deletion `json:"deleted,omitempty" bson:"deleted,omitempty"`
+}
+
+// Deletion marks a soft-deleted weapon. Deleted weapons are hidden from
+// listings and searches until they are restored.
+type Deletion struct {
+	DeletedAt time.Time `json:"deletedAt" bson:"deletedAt"`
+	Reason    string    `json:"reason" bson:"reason"`
 }
 
 func NewWeapon(params *Params) *Params {
-	return &Params{
+	weapon := &Params{
 		Category: params.Category,
 		Name:     params.Name,
+		Slug:     params.Slug,
 		PhysicalProp: PhysicalProp{
 			Mass:                     params.Mass,
 			MassAtEndOfBoosterBurn:   params.MassAtEndOfBoosterBurn,
@@ -313,152 +326,25 @@ func NewWeapon(params *Params) *Params {
 			ETAToImpactWhenPropMultiplierReachesXPercentageX:   params.ETAToImpactWhenPropMultiplierReachesXPercentageX,
 		},
 	}
+
+	weapon.ApplyUnits()
+
+	return weapon
 }
 
+// UpdateWeaponParams returns the $set document for an update. It uses the
+// same keys as the bson tags on Params, so updated documents keep the layout
+// NewWeapon produces on insert.
 func UpdateWeaponParams(params *Params) bson.M {
+	params = NewWeapon(params)
+
 	return bson.M{
-		"Category": params.Category,
-		"Name":     params.Name,
-		"PhysicalProp": PhysicalProp{
-			Mass:                     params.Mass,
-			MassAtEndOfBoosterBurn:   params.MassAtEndOfBoosterBurn,
-			MassAtEndOfSustainerBurn: params.MassAtEndOfSustainerBurn,
-			Calibre:                  params.Calibre,
-			Length:                   params.Length,
-		},
-		"EngineProp": EngineProp{
-			ForceExertedByBooster:      params.ForceExertedByBooster,
-			BurnTimeOfBooster:          params.BurnTimeOfBooster,
-			RawAccelerationAtIgnition:  params.RawAccelerationAtIgnition,
-			SpecificImpulseOfBooster:   params.SpecificImpulseOfBooster,
-			DeltaSpeedOfBooster:        params.DeltaSpeedOfBooster,
-			BoosterStartDelay:          params.BoosterStartDelay,
-			ForceExertedBySustainer:    params.ForceExertedBySustainer,
-			BurnTimeOfSustainer:        params.BurnTimeOfSustainer,
-			SpecificImpulseOfSustainer: params.SpecificImpulseOfSustainer,
-			DeltaSpeedOfSustainer:      params.DeltaSpeedOfSustainer,
-			TotalDeltaSpeed:            params.TotalDeltaSpeed,
-		},
-		"FuseAndWarheadProp": FuseAndWarheadProp{
-			ExplosiveMass:                params.ExplosiveMass,
-			TandemCharge:                 params.TandemCharge,
-			Penetration:                  params.Penetration,
-			ProximityFuse:                params.ProximityFuse,
-			ProximityFuseRange:           params.ProximityFuseRange,
-			ProximityFuseArmingDistance:  params.ProximityFuseArmingDistance,
-			ProximityFuseShellDetection:  params.ProximityFuseShellDetection,
-			ProximityFuseMinimumAltitude: params.ProximityFuseMinimumAltitude,
-			ProximityFuseDelay:           params.ProximityFuseDelay,
-		},
-		"GuidanceProp": GuidanceProp{
-			Zoom:                   params.Zoom,
-			GuidanceType:           params.GuidanceType,
-			GuidanceStartDelay:     params.GuidanceStartDelay,
-			GuidanceDuration:       params.GuidanceDuration,
-			GuidanceRange:          params.GuidanceRange,
-			LaunchSector:           params.LaunchSector,
-			ControlConeFOV:         params.ControlConeFOV,
-			AimTrackingSensitivity: params.AimTrackingSensitivity,
-			MaximumAngleAllowedBetweenMissileAndCrosshair: params.MaximumAngleAllowedBetweenMissileAndCrosshair,
-			SeekerWarmUpTime:             params.SeekerWarmUpTime,
-			SeekerSearchDuration:         params.SeekerSearchDuration,
-			FieldOfView:                  params.FieldOfView,
-			OpticSightFieldOfView:        params.OpticSightFieldOfView,
-			GimbalLimit:                  params.GimbalLimit,
-			TrackRate:                    params.TrackRate,
-			UncageSeekerBeforeLaunch:     params.UncageSeekerBeforeLaunch,
-			MaximumLockAngleBeforeLaunch: params.MaximumLockAngleBeforeLaunch,
-			MinimumAngleBetweenSeekerAndSunForNotCapture: params.MinimumAngleBetweenSeekerAndSunForNotCapture,
-			CanLockGround:                             params.CanLockGround,
-			LockOnRangeGround:                         params.LockOnRangeGround,
-			LockOnRangeVehicle:                        params.LockOnRangeVehicle,
-			LockOnRangeFromRearAspect:                 params.LockOnRangeFromRearAspect,
-			FlareDetectionRange:                       params.FlareDetectionRange,
-			IRCMDetectionRange:                        params.IRCMDetectionRange,
-			DIRCMDetectionRange:                       params.DIRCMDetectionRange,
-			HeadOnLockOnRangeAgainstAfterburnerTarget: params.HeadOnLockOnRangeAgainstAfterburnerTarget,
-			IRCCM:                               params.IRCCM,
-			IRCCMType:                           params.IRCCMType,
-			IRCCMFieldOfView:                    params.IRCCMFieldOfView,
-			IRCCMRejectionThreshold:             params.IRCCMRejectionThreshold,
-			IRCCMReactionTime:                   params.IRCCMReactionTime,
-			LockOnRangeFromAllAspect:            params.LockOnRangeFromAllAspect,
-			CountermeasureDetectionRange:        params.CountermeasureDetectionRange,
-			MaximumBreakLockTime:                params.MaximumBreakLockTime,
-			CanBeSlavedToRadar:                  params.CanBeSlavedToRadar,
-			CanLockAfterLaunch:                  params.CanLockAfterLaunch,
-			Band:                                params.Band,
-			AngularSpeedRejectionThreshold:      params.AngularSpeedRejectionThreshold,
-			AngularRejectionThresholdRange:      params.AngularRejectionThresholdRange,
-			AccelerationRejectionThresholdRange: params.AccelerationRejectionThresholdRange,
-			SidelobeAttenuation:                 params.SidelobeAttenuation,
-			TransmitterPower:                    params.TransmitterPower,
-			TransmitterAngleOfHalfSensitivity:   params.TransmitterAngleOfHalfSensitivity,
-			TransmitterSidelobeSensitivity:      params.TransmitterSidelobeSensitivity,
-			ReceiverAngleOfHalfSensitivity:      params.ReceiverAngleOfHalfSensitivity,
-			ReceiverSidelobeSensitivity:         params.ReceiverSidelobeSensitivity,
-			DistanceMinimumValue:                params.DistanceMinimumValue,
-			DistanceMaximumValue:                params.DistanceMaximumValue,
-			DistanceWidth:                       params.DistanceWidth,
-			DistanceMinimumSignalGate:           params.DistanceMinimumSignalGate,
-			DistanceGateSearchRange:             params.DistanceGateSearchRange,
-			DistanceGateAlphaFilter:             params.DistanceGateAlphaFilter,
-			DistanceGateBetaFilter:              params.DistanceGateBetaFilter,
-			DistanceRefWidth:                    params.DistanceRefWidth,
-			DopplerSpeedMinimumValue:            params.DopplerSpeedMinimumValue,
-			DopplerSpeedMaximumValue:            params.DopplerSpeedMaximumValue,
-			DopplerSpeedWidth:                   params.DopplerSpeedWidth,
-			DopplerSpeedRefWidth:                params.DopplerSpeedRefWidth,
-			DopplerSpeedMinimumSignalGate:       params.DopplerSpeedMinimumSignalGate,
-			DistanceGate:                        params.DistanceGate,
-			DopplerSpeedGateSearchRange:         params.DopplerSpeedGateSearchRange,
-			DopplerSpeedGateAlphaFilter:         params.DopplerSpeedGateAlphaFilter,
-			DopplerSpeedGateBetaFilter:          params.DopplerSpeedGateBetaFilter,
-			ProportionalNavigationMultiplier:    params.ProportionalNavigationMultiplier,
-			BaseIndicatedAirSpeed:               params.BaseIndicatedAirSpeed,
-			PIDProportionalTerm:                 params.PIDProportionalTerm,
-			PIDIntegralTerm:                     params.PIDIntegralTerm,
-			PIDIntegralTermLimit:                params.PIDIntegralTermLimit,
-			PIDDerivativeTerm:                   params.PIDDerivativeTerm,
-			InertialGuidanceDriftSpeed:          params.InertialGuidanceDriftSpeed,
-			InertialNavigation:                  params.InertialNavigation,
-			InertialNavigationDriftSpeed:        params.InertialNavigationDriftSpeed,
-		},
-		"FlightProp": FlightProp{
-			MaximumLaunchAngleHorizontalVertical: params.MaximumLaunchAngleHorizontalVertical,
-			AimSensitivity:                       params.AimSensitivity,
-			MaximumAxisValues:                    params.MaximumAxisValues,
-			MaximumFinAngleOfAttack:              params.MaximumFinAngleOfAttack,
-			FinsLateralAcceleration:              params.FinsLateralAcceleration,
-			MaximumAOA:                           params.MaximumAOA,
-			MaximumFinLateralAcceleration:        params.MaximumFinLateralAcceleration,
-			WingAreaMultiplier:                   params.WingAreaMultiplier,
-			MaximumLateralAcceleration:           params.MaximumLateralAcceleration,
-			StartSpeed:                           params.StartSpeed,
-			MaximumSpeed:                         params.MaximumSpeed,
-			MinimumRange:                         params.MinimumRange,
-			MaximumFlightRange:                   params.MaximumFlightRange,
-			Tracer:                               params.Tracer,
-			LoadFactorLimitAtLaunch:              params.LoadFactorLimitAtLaunch,
-			MaximumOverLoad:                      params.MaximumOverLoad,
-			SeaSkimming:                          params.SeaSkimming,
-			FlightTimeUntilGuidanceStarts:        params.FlightTimeUntilGuidanceStarts,
-			FlightTimeWhenPullLimit30:            params.FlightTimeWhenPullLimit30,
-			FlightTimeWhenPullLimit40:            params.FlightTimeWhenPullLimit40,
-			FlightTimeWhenPullLimit100:           params.FlightTimeWhenPullLimit100,
-			Loft:                                 params.Loft,
-			LoftAngle:                            params.LoftAngle,
-			TargetElevation:                      params.TargetElevation,
-			MaximumTargetAngularChange:           params.MaximumTargetAngularChange,
-			ThrustVectoring:                      params.ThrustVectoring,
-			ThrustVectoringAngle:                 params.ThrustVectoringAngle,
-			StartingGLimit:                       params.StartingGLimit,
-			ETAToImpactWhenPropMultiplierReachesXPercentage30:  params.ETAToImpactWhenPropMultiplierReachesXPercentage30,
-			ETAToImpactWhenPropMultiplierReachesXPercentage50:  params.ETAToImpactWhenPropMultiplierReachesXPercentage50,
-			ETAToImpactWhenPropMultiplierReachesXPercentage80:  params.ETAToImpactWhenPropMultiplierReachesXPercentage80,
-			ETAToImpactWhenPropMultiplierReachesXPercentage90:  params.ETAToImpactWhenPropMultiplierReachesXPercentage90,
-			ETAToImpactWhenPropMultiplierReachesXPercentage100: params.ETAToImpactWhenPropMultiplierReachesXPercentage100,
-			ETAToImpactWhenPropMultiplierReachesXPercentageX:   params.ETAToImpactWhenPropMultiplierReachesXPercentageX,
-		},
+		"category":           params.Category,
+		"name":               params.Name,
+		"physicalProp":       params.PhysicalProp,
+		"engineProp":         params.EngineProp,
+		"fuseAndWarheadProp": params.FuseAndWarheadProp,
+		"guidanceProp":       params.GuidanceProp,
+		"flightProp":         params.FlightProp,
 	}
 }
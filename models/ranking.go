@@ -0,0 +1,158 @@
+package models
+
+import "slices"
+
+// Better says which way a parameter improves. Parameters without one, such
+// as the band or the PID terms, aren't ranked.
+type Better string
+
+const (
+	HigherIsBetter Better = "higher"
+	LowerIsBetter  Better = "lower"
+)
+
+// fieldBetter lists the parameters weapons are ranked on.
+var fieldBetter = map[string]Better{
+	"physicalProp.mass": LowerIsBetter,
+
+	"engineProp.rawAccelerationAtIgnition":  HigherIsBetter,
+	"engineProp.specificImpulseOfBooster":   HigherIsBetter,
+	"engineProp.deltaSpeedOfBooster":        HigherIsBetter,
+	"engineProp.boosterStartDelay":          LowerIsBetter,
+	"engineProp.burnTimeOfSustainer":        HigherIsBetter,
+	"engineProp.specificImpulseOfSustainer": HigherIsBetter,
+	"engineProp.deltaSpeedOfSustainer":      HigherIsBetter,
+	"engineProp.totalDeltaSpeed":            HigherIsBetter,
+
+	"fuseAndWarheadProp.explosiveMass":                HigherIsBetter,
+	"fuseAndWarheadProp.penetration":                  HigherIsBetter,
+	"fuseAndWarheadProp.proximityFuseRange":           HigherIsBetter,
+	"fuseAndWarheadProp.proximityFuseArmingDistance":  LowerIsBetter,
+	"fuseAndWarheadProp.proximityFuseMinimumAltitude": LowerIsBetter,
+
+	"guidanceProp.zoom":                                      HigherIsBetter,
+	"guidanceProp.guidanceStartDelay":                        LowerIsBetter,
+	"guidanceProp.guidanceDuration":                          HigherIsBetter,
+	"guidanceProp.guidanceRange":                             HigherIsBetter,
+	"guidanceProp.launchSector":                              HigherIsBetter,
+	"guidanceProp.seekerWarmUpTime":                          LowerIsBetter,
+	"guidanceProp.seekerSearchDuration":                      HigherIsBetter,
+	"guidanceProp.gimbalLimit":                               HigherIsBetter,
+	"guidanceProp.trackRate":                                 HigherIsBetter,
+	"guidanceProp.maximumLockAngleBeforeLaunch":              HigherIsBetter,
+	"guidanceProp.lockOnRangeGround":                         HigherIsBetter,
+	"guidanceProp.lockOnRangeVehicle":                        HigherIsBetter,
+	"guidanceProp.lockOnRangeFromRearAspect":                 HigherIsBetter,
+	"guidanceProp.lockOnRangeFromAllAspect":                  HigherIsBetter,
+	"guidanceProp.headOnLockOnRangeAgainstAfterburnerTarget": HigherIsBetter,
+	"guidanceProp.flareDetectionRange":                       LowerIsBetter,
+	"guidanceProp.countermeasureDetectionRange":              LowerIsBetter,
+	"guidanceProp.IRCCMReactionTime":                         LowerIsBetter,
+	"guidanceProp.maximumBreakLockTime":                      HigherIsBetter,
+	"guidanceProp.inertialGuidanceDriftSpeed":                LowerIsBetter,
+	"guidanceProp.inertialNavigationDriftSpeed":              LowerIsBetter,
+
+	"flightProp.maximumLateralAcceleration":    HigherIsBetter,
+	"flightProp.startSpeed":                    HigherIsBetter,
+	"flightProp.maximumSpeed":                  HigherIsBetter,
+	"flightProp.minimumRange":                  LowerIsBetter,
+	"flightProp.maximumFlightRange":            HigherIsBetter,
+	"flightProp.loadFactorLimitAtLaunch":       HigherIsBetter,
+	"flightProp.maximumOverLoad":               HigherIsBetter,
+	"flightProp.flightTimeUntilGuidanceStarts": LowerIsBetter,
+	"flightProp.thrustVectoringAngle":          HigherIsBetter,
+	"flightProp.startingGLimit":                HigherIsBetter,
+}
+
+// Ranking is how weapons place on a parameter.
+type Ranking struct {
+	// Places holds the place of each weapon, 1 for the best. Weapons that
+	// tie share a place, weapons without a comparable number get 0.
+	Places []int
+
+	// Ranked is how many weapons have a place, Best the best value.
+	Ranked int
+	Best   float64
+
+	// Better is the way the field improves.
+	Better Better
+}
+
+// Rank ranks weapons on the field. Numbers in another unit than the
+// field's can't be compared and are left out. The ranking is empty for
+// fields that aren't ranked.
+func (f Field) Rank(weapons []*Params) Ranking {
+	res := Ranking{Places: make([]int, len(weapons)), Better: f.Better}
+
+	if f.Better == "" {
+		return res
+	}
+
+	numbers := make([]float64, len(weapons))
+	ranked := make([]bool, len(weapons))
+
+	for i, weapon := range weapons {
+		v := f.Value(weapon)
+
+		n, ok := v.Float()
+		if !ok || v.Unit != "" && v.Unit != f.Unit {
+			continue
+		}
+
+		if f.Better == LowerIsBetter {
+			n = -n
+		}
+
+		numbers[i], ranked[i] = n, true
+		res.Ranked++
+	}
+
+	var sorted []float64
+	for i, n := range numbers {
+		if ranked[i] {
+			sorted = append(sorted, n)
+		}
+	}
+
+	slices.Sort(sorted)
+	slices.Reverse(sorted)
+
+	for i, n := range numbers {
+		if ranked[i] {
+			res.Places[i] = slices.Index(sorted, n) + 1
+		}
+	}
+
+	if len(sorted) > 0 {
+		res.Best = sorted[0]
+		if f.Better == LowerIsBetter {
+			res.Best = -res.Best
+		}
+	}
+
+	return res
+}
+
+// Worst reports whether place is the last of the ranking.
+func (r Ranking) Worst(place int) bool {
+	return place > 1 && !slices.ContainsFunc(r.Places, func(p int) bool { return p > place })
+}
+
+// Percent returns v, ranked at place, as a percentage of the best value,
+// which is 100%. When lower is better the ratio is inverted, so a weapon
+// twice as heavy as the lightest gets 50% rather than 200%.
+func (r Ranking) Percent(v *Value, place int) (float64, bool) {
+	n, ok := v.Float()
+	if !ok || place == 0 || r.Best == 0 {
+		return 0, false
+	}
+
+	if r.Better == LowerIsBetter {
+		if n == 0 {
+			return 0, false
+		}
+		return r.Best / n * 100, true
+	}
+
+	return n / r.Best * 100, true
+}
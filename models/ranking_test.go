@@ -0,0 +1,72 @@
+package models
+
+import (
+	"math"
+	"reflect"
+	"testing"
+)
+
+// ranked returns weapons with the values of the field, in order.
+func ranked(t *testing.T, key string, values ...string) (Field, []*Params) {
+	t.Helper()
+
+	f, ok := FieldByKey(key)
+	if !ok {
+		t.Fatalf("no field %s", key)
+	}
+
+	var weapons []*Params
+	for _, s := range values {
+		p := new(Params)
+		f.SetValue(p, ParseValue(s))
+		weapons = append(weapons, p)
+	}
+
+	return f, weapons
+}
+
+func TestRankPercent(t *testing.T) {
+	tests := []struct {
+		key     string
+		values  []string
+		places  []int
+		percent []float64 // -1 when there is none
+	}{
+		{
+			key:     "guidanceProp.gimbalLimit",
+			values:  []string{"30", "60", "45", "N/A"},
+			places:  []int{3, 1, 2, 0},
+			percent: []float64{50, 100, 75, -1},
+		},
+		{
+			key:     "physicalProp.mass",
+			values:  []string{"170", "85", "340", ""},
+			places:  []int{2, 1, 3, 0},
+			percent: []float64{50, 100, 25, -1},
+		},
+	}
+
+	for _, tt := range tests {
+		f, weapons := ranked(t, tt.key, tt.values...)
+		r := f.Rank(weapons)
+
+		if !reflect.DeepEqual(r.Places, tt.places) {
+			t.Errorf("%s: places %v, want %v", tt.key, r.Places, tt.places)
+		}
+
+		for i, weapon := range weapons {
+			p, ok := r.Percent(f.Value(weapon), r.Places[i])
+
+			switch {
+			case tt.percent[i] < 0 && ok:
+				t.Errorf("%s: %q is %v%% of the best, want none", tt.key, tt.values[i], p)
+			case tt.percent[i] >= 0 && (!ok || math.Abs(p-tt.percent[i]) > 1e-9):
+				t.Errorf("%s: %q is %v%% (%t) of the best, want %v%%", tt.key, tt.values[i], p, ok, tt.percent[i])
+			}
+
+			if ok && p > 100 {
+				t.Errorf("%s: %q is %v%% of the best", tt.key, tt.values[i], p)
+			}
+		}
+	}
+}
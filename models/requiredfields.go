@@ -0,0 +1,50 @@
+package models
+
+// baseRequired lists the parameters every weapon must have.
+var baseRequired = []string{
+	"physicalProp.mass",
+	"physicalProp.calibre",
+	"physicalProp.length",
+}
+
+// requiredFields lists the parameters a weapon must have in each category.
+var requiredFields = map[string][]string{
+	CategoryIRRearAspect:   required("guidanceProp.guidanceType", "guidanceProp.lockOnRangeFromRearAspect", "flightProp.maximumSpeed", "flightProp.maximumFlightRange"),
+	CategoryIRAllAspect:    required("guidanceProp.guidanceType", "guidanceProp.lockOnRangeFromRearAspect", "flightProp.maximumSpeed", "flightProp.maximumFlightRange"),
+	CategoryIRHeli:         required("guidanceProp.guidanceType", "guidanceProp.lockOnRangeFromRearAspect", "flightProp.maximumSpeed", "flightProp.maximumFlightRange"),
+	CategoryAAMSARH:        required("guidanceProp.guidanceType", "guidanceProp.band", "flightProp.maximumSpeed", "flightProp.maximumFlightRange"),
+	CategoryAAMARH:         required("guidanceProp.guidanceType", "guidanceProp.band", "flightProp.maximumSpeed", "flightProp.maximumFlightRange"),
+	CategoryAAMMCLOSLOSBR:  required("guidanceProp.guidanceType", "flightProp.maximumSpeed", "flightProp.maximumFlightRange"),
+	CategoryAGMAutomatic:   required("guidanceProp.guidanceType", "flightProp.maximumSpeed", "flightProp.maximumFlightRange"),
+	CategoryAGMSALH:        required("guidanceProp.guidanceType", "flightProp.maximumSpeed", "flightProp.maximumFlightRange"),
+	CategoryAGMSACLOS:      required("guidanceProp.guidanceType", "flightProp.maximumSpeed", "flightProp.maximumFlightRange"),
+	CategoryAGMMCLOS:       required("guidanceProp.guidanceType", "flightProp.maximumSpeed", "flightProp.maximumFlightRange"),
+	CategoryAGMLOSBR:       required("guidanceProp.guidanceType", "flightProp.maximumSpeed", "flightProp.maximumFlightRange"),
+	CategoryGBU:            required("guidanceProp.guidanceType"),
+	CategorySAMIR:          required("guidanceProp.guidanceType", "guidanceProp.lockOnRangeFromRearAspect", "flightProp.maximumSpeed", "flightProp.maximumFlightRange"),
+	CategorySAMSACLOSLOSBR: required("guidanceProp.guidanceType", "flightProp.maximumSpeed", "flightProp.maximumFlightRange"),
+	CategoryATGMMCLOS:      required("guidanceProp.guidanceType", "fuseAndWarheadProp.penetration", "flightProp.maximumSpeed", "flightProp.maximumFlightRange"),
+	CategoryATGMSACLOS:     required("guidanceProp.guidanceType", "fuseAndWarheadProp.penetration", "flightProp.maximumSpeed", "flightProp.maximumFlightRange"),
+	CategoryATGMLOSBR:      required("guidanceProp.guidanceType", "fuseAndWarheadProp.penetration", "flightProp.maximumSpeed", "flightProp.maximumFlightRange"),
+	CategoryATGMAutomatic:  required("guidanceProp.guidanceType", "fuseAndWarheadProp.penetration", "flightProp.maximumSpeed", "flightProp.maximumFlightRange"),
+	CategoryAShM:           required("guidanceProp.guidanceType", "flightProp.maximumSpeed", "flightProp.maximumFlightRange"),
+}
+
+func required(keys ...string) []string {
+	return append(append([]string(nil), baseRequired...), keys...)
+}
+
+// RequiredFor returns the parameters a weapon in category must have.
+// Categories without their own list only require the base parameters.
+func RequiredFor(category string) []Field {
+	keys, ok := requiredFields[category]
+	if !ok {
+		keys = baseRequired
+	}
+
+	var res []Field
+	for _, key := range keys {
+		res = append(res, fields[fieldsByKey[key]])
+	}
+	return res
+}
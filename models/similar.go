@@ -0,0 +1,73 @@
+package models
+
+import (
+	"math"
+	"slices"
+)
+
+// Similar returns up to n candidates of the weapon's category that are the
+// closest to it, the closest first. Closeness is the mean relative
+// difference over the numbers both weapons have for the parameters of the
+// category, so weapons sharing no number with it are left out.
+func Similar(weapon *Params, candidates []*Params, n int) []*Params {
+	type scored struct {
+		weapon   *Params
+		distance float64
+	}
+
+	fields := FieldsForAll([]string{weapon.Category})
+
+	var res []scored
+
+	for _, candidate := range candidates {
+		if candidate.Name == weapon.Name || candidate.Category != weapon.Category {
+			continue
+		}
+
+		var (
+			sum    float64
+			shared int
+		)
+
+		for _, f := range fields {
+			a, b := f.Value(weapon), f.Value(candidate)
+
+			x, ok := a.Float()
+			if !ok {
+				continue
+			}
+
+			y, ok := b.Float()
+			if !ok || a.Unit != b.Unit {
+				continue
+			}
+
+			shared++
+
+			if top := math.Max(math.Abs(x), math.Abs(y)); top > 0 {
+				sum += math.Abs(x-y) / top
+			}
+		}
+
+		if shared > 0 {
+			res = append(res, scored{candidate, sum / float64(shared)})
+		}
+	}
+
+	slices.SortStableFunc(res, func(a, b scored) int {
+		switch {
+		case a.distance < b.distance:
+			return -1
+		case a.distance > b.distance:
+			return 1
+		}
+		return 0
+	})
+
+	weapons := make([]*Params, 0, min(n, len(res)))
+	for i := 0; i < len(res) && i < n; i++ {
+		weapons = append(weapons, res[i].weapon)
+	}
+
+	return weapons
+}
@@ -0,0 +1,34 @@
+package models
+
+import (
+	"strings"
+	"unicode"
+)
+
+// Slug turns a weapon name into the last segment of its URL, e.g.
+// "AIM-9L" into "aim-9l" and "R-27ER (1)" into "r-27er-1".
+func Slug(name string) string {
+	var b strings.Builder
+
+	dash := false
+
+	for _, r := range strings.ToLower(name) {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			if dash && b.Len() > 0 {
+				b.WriteByte('-')
+			}
+
+			b.WriteRune(r)
+			dash = false
+			continue
+		}
+
+		dash = true
+	}
+
+	if b.Len() == 0 {
+		return "weapon"
+	}
+
+	return b.String()
+}
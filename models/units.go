@@ -0,0 +1,334 @@
+package models
+
+import (
+	"fmt"
+	"math"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// UnitSystem picks a unit for every quantity at once.
+type UnitSystem string
+
+const (
+	SI       UnitSystem = "si"
+	Imperial UnitSystem = "imperial"
+	Aviation UnitSystem = "aviation"
+)
+
+// UnitSystems lists the unit systems in display order.
+var UnitSystems = []UnitSystem{SI, Imperial, Aviation}
+
+// Unit is a unit a quantity can be shown in. Factor is the size of the unit
+// in the stored unit of its quantity.
+type Unit struct {
+	ID     string  `json:"id"`
+	Symbol string  `json:"symbol"`
+	Factor float64 `json:"factor"`
+}
+
+// Quantity is what the parameters stored in one unit measure. Parameters in
+// units without a quantity, such as seconds or degrees, aren't converted.
+type Quantity struct {
+	Name string `json:"name"`
+
+	// Units lists the units of the quantity, the stored one first.
+	Units []Unit `json:"units"`
+
+	systems map[UnitSystem]string
+}
+
+// Quantities lists the convertible quantities.
+var Quantities = []Quantity{
+	{
+		Name: "speed",
+		Units: []Unit{
+			{ID: "mps", Symbol: "m/s", Factor: 1},
+			{ID: "kmh", Symbol: "km/h", Factor: 1 / 3.6},
+			{ID: "mph", Symbol: "mph", Factor: 0.44704},
+			{ID: "kn", Symbol: "kn", Factor: 1852.0 / 3600},
+			{ID: "fps", Symbol: "ft/s", Factor: 0.3048},
+			// Mach at sea level in the standard atmosphere.
+			{ID: "mach", Symbol: "Mach", Factor: 340.29},
+		},
+		systems: map[UnitSystem]string{Imperial: "mph", Aviation: "kn"},
+	},
+	{
+		Name: "mass",
+		Units: []Unit{
+			{ID: "kg", Symbol: "kg", Factor: 1},
+			{ID: "lb", Symbol: "lb", Factor: 0.45359237},
+		},
+		systems: map[UnitSystem]string{Imperial: "lb", Aviation: "lb"},
+	},
+	{
+		Name: "distance",
+		Units: []Unit{
+			{ID: "km", Symbol: "km", Factor: 1},
+			{ID: "mi", Symbol: "mi", Factor: 1.609344},
+			{ID: "nmi", Symbol: "nmi", Factor: 1.852},
+		},
+		systems: map[UnitSystem]string{Imperial: "mi", Aviation: "nmi"},
+	},
+	{
+		Name: "length",
+		Units: []Unit{
+			{ID: "m", Symbol: "m", Factor: 1},
+			{ID: "ft", Symbol: "ft", Factor: 0.3048},
+		},
+		systems: map[UnitSystem]string{Imperial: "ft", Aviation: "ft"},
+	},
+	{
+		Name: "calibre",
+		Units: []Unit{
+			{ID: "mm", Symbol: "mm", Factor: 1},
+			{ID: "in", Symbol: "in", Factor: 25.4},
+		},
+		systems: map[UnitSystem]string{Imperial: "in"},
+	},
+	{
+		Name: "force",
+		Units: []Unit{
+			{ID: "n", Symbol: "N", Factor: 1},
+			{ID: "lbf", Symbol: "lbf", Factor: 4.4482216152605},
+			{ID: "kgf", Symbol: "kgf", Factor: 9.80665},
+		},
+		systems: map[UnitSystem]string{Imperial: "lbf", Aviation: "lbf"},
+	},
+	{
+		Name: "acceleration",
+		Units: []Unit{
+			{ID: "mps2", Symbol: "m/s²", Factor: 1},
+			{ID: "fps2", Symbol: "ft/s²", Factor: 0.3048},
+			{ID: "g", Symbol: "G", Factor: 9.80665},
+		},
+		systems: map[UnitSystem]string{Imperial: "fps2", Aviation: "g"},
+	},
+}
+
+func quantityOf(unit string) (*Quantity, bool) {
+	for i := range Quantities {
+		if Quantities[i].Units[0].Symbol == unit {
+			return &Quantities[i], true
+		}
+	}
+	return nil, false
+}
+
+// Unit looks up a unit of the quantity by its ID or symbol.
+func (q *Quantity) Unit(s string) (Unit, bool) {
+	for _, u := range q.Units {
+		if strings.EqualFold(u.ID, s) || u.Symbol == s {
+			return u, true
+		}
+	}
+	return Unit{}, false
+}
+
+// Units is the unit each quantity is shown in: the one picked for it, or
+// else the one of the system. The zero value shows the stored units.
+type Units struct {
+	System UnitSystem
+
+	// Picked maps quantity names to unit IDs.
+	Picked map[string]string
+}
+
+// ParseUnits parses preferences such as "aviation" or "si,speed:mach",
+// a unit system followed by units picked per quantity, all optional.
+func ParseUnits(s string) (Units, error) {
+	var res Units
+
+	for _, part := range strings.Split(s, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+
+		name, id, ok := strings.Cut(part, ":")
+		if !ok {
+			system := UnitSystem(strings.ToLower(part))
+			if !slices.Contains(UnitSystems, system) {
+				return Units{}, fmt.Errorf("unknown unit system %s", part)
+			}
+
+			res.System = system
+			continue
+		}
+
+		q, ok := quantityByName(name)
+		if !ok {
+			return Units{}, fmt.Errorf("unknown quantity %s", name)
+		}
+
+		u, ok := q.Unit(id)
+		if !ok {
+			return Units{}, fmt.Errorf("unknown unit %s of %s", id, name)
+		}
+
+		if res.Picked == nil {
+			res.Picked = map[string]string{}
+		}
+
+		res.Picked[q.Name] = u.ID
+	}
+
+	return res, nil
+}
+
+func quantityByName(name string) (*Quantity, bool) {
+	for i := range Quantities {
+		if strings.EqualFold(Quantities[i].Name, name) {
+			return &Quantities[i], true
+		}
+	}
+	return nil, false
+}
+
+// String formats the preferences the way ParseUnits reads them.
+func (u Units) String() string {
+	var parts []string
+
+	if u.System != "" {
+		parts = append(parts, string(u.System))
+	}
+
+	for _, q := range Quantities {
+		if id, ok := u.Picked[q.Name]; ok {
+			parts = append(parts, q.Name+":"+id)
+		}
+	}
+
+	return strings.Join(parts, ",")
+}
+
+// For returns the unit quantity q is shown in.
+func (u Units) For(q *Quantity) Unit {
+	id, ok := u.Picked[q.Name]
+	if !ok {
+		id = q.systems[u.System]
+	}
+
+	if unit, ok := q.Unit(id); ok {
+		return unit
+	}
+
+	return q.Units[0]
+}
+
+// Field returns f with the unit it's shown in. Its limits stay in the
+// stored unit.
+func (u Units) Field(f Field) Field {
+	if q, ok := quantityOf(f.Unit); ok {
+		f.Unit = u.For(q).Symbol
+	}
+	return f
+}
+
+// Fields returns fields with the units they're shown in.
+func (u Units) Fields(fields []Field) []Field {
+	res := make([]Field, 0, len(fields))
+	for _, f := range fields {
+		res = append(res, u.Field(f))
+	}
+	return res
+}
+
+// Params returns a copy of params with its values converted.
+func (u Units) Params(params *Params) *Params {
+	res := params.Clone()
+
+	for _, f := range fields {
+		if v := u.Value(f, f.Value(res)); v != nil {
+			f.SetValue(res, v)
+		}
+	}
+
+	return res
+}
+
+// Weapons converts the values of every weapon.
+func (u Units) Weapons(weapons []*Params) []*Params {
+	res := make([]*Params, 0, len(weapons))
+	for _, weapon := range weapons {
+		res = append(res, u.Params(weapon))
+	}
+	return res
+}
+
+// Value converts a value of the field. Values that aren't numbers, or are
+// written in a unit foreign to the field, are returned as they are.
+func (u Units) Value(f Field, v *Value) *Value {
+	q, ok := quantityOf(f.Unit)
+	if !ok || v == nil || v.Kind != KindNumber && v.Kind != KindRange && v.Kind != KindTuple {
+		return v
+	}
+
+	unit := v.Unit
+	if unit == "" {
+		unit = f.Unit
+	}
+
+	from, ok := q.Unit(unit)
+	if !ok {
+		return v
+	}
+
+	to := u.For(q)
+	if from == to {
+		return v
+	}
+
+	factor := from.Factor / to.Factor
+
+	res := v.Clone()
+	res.Unit = to.Symbol
+	res.Magnitude = round(v.Magnitude * factor)
+
+	for i := range res.Range {
+		res.Range[i] = round(res.Range[i] * factor)
+	}
+
+	for i := range res.Tuple {
+		res.Tuple[i] = round(res.Tuple[i] * factor)
+	}
+
+	switch {
+	case res.Kind == KindRange && res.Range[0] == -res.Range[1]:
+		res.Text = fmt.Sprintf("±%s %s", formatFloat(res.Range[1]), to.Symbol)
+	case res.Kind == KindRange:
+		res.Text = fmt.Sprintf("%s–%s %s", formatFloat(res.Range[0]), formatFloat(res.Range[1]), to.Symbol)
+	case res.Kind == KindTuple:
+		parts := make([]string, 0, len(res.Tuple))
+		for _, f := range res.Tuple {
+			parts = append(parts, formatFloat(f))
+		}
+		res.Text = fmt.Sprintf("%s %s", strings.Join(parts, "/"), to.Symbol)
+	default:
+		res.Text = fmt.Sprintf("%s %s", formatFloat(res.Magnitude), to.Symbol)
+	}
+
+	return res
+}
+
+// round keeps four significant digits, which is more than the stored
+// values have.
+func round(f float64) float64 {
+	if f == 0 {
+		return 0
+	}
+
+	decimals := 3 - int(math.Floor(math.Log10(math.Abs(f))))
+	if decimals < 0 {
+		decimals = 0
+	}
+
+	p := math.Pow(10, float64(decimals))
+
+	return math.Round(f*p) / p
+}
+
+func formatFloat(f float64) string {
+	return strconv.FormatFloat(f, 'f', -1, 64)
+}
@@ -0,0 +1,415 @@
+package models
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"reflect"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/bsontype"
+)
+
+type ValueKind string
+
+const (
+	KindNumber ValueKind = "number"
+	KindRange  ValueKind = "range"
+	KindTuple  ValueKind = "tuple"
+	KindBool   ValueKind = "bool"
+	KindText   ValueKind = "text"
+)
+
+// Value is a single weapon parameter. Text keeps the value as it was
+// entered ("30/20", "±45°", "Yes"), the remaining fields hold its parsed form.
+// Magnitude is the number itself, the upper bound of a range, the first
+// element of a tuple and 1 or 0 for Yes/No values.
+type Value struct {
+	Text      string    `json:"text"`
+	Kind      ValueKind `json:"kind"`
+	Magnitude float64   `json:"magnitude"`
+	Unit      string    `json:"unit,omitempty" bson:",omitempty"`
+	Range     []float64 `json:"range,omitempty" bson:",omitempty"`
+	Tuple     []float64 `json:"tuple,omitempty" bson:",omitempty"`
+}
+
+// A number groups its thousands with commas ("1,500.5") or uses a comma as
+// its decimal point ("1,5"), see parseFloat.
+const (
+	number = `[-+]?(?:[1-9]\d{0,2}(?:,\d{3})+(?:\.\d+)?|\d+(?:[.,]\d+)?)(?:[eE][-+]?\d+)?`
+	unit   = `[\pL°º%][^\d]*(?:\^\d)?`
+)
+
+var (
+	numberRe   = regexp.MustCompile(`^(` + number + `)\s*(` + unit + `)?$`)
+	symmetryRe = regexp.MustCompile(`^(?:±|\+/-|\+-)\s*(` + number + `)\s*(` + unit + `)?$`)
+	groupedRe  = regexp.MustCompile(`^[-+]?[1-9]\d{0,2}(?:,\d{3})+(?:\.\d+)?(?:[eE][-+]?\d+)?$`)
+	spanRe     = regexp.MustCompile(`^(` + number + `)\s*(?:\.\.|–|—|-|to)\s*(` + number + `)\s*(` + unit + `)?$`)
+)
+
+var unitAliases = map[string]string{
+	"º":              "°",
+	"deg":            "°",
+	"degree":         "°",
+	"degrees":        "°",
+	"deg/s":          "°/s",
+	"°/sec":          "°/s",
+	"degrees/s":      "°/s",
+	"degrees/second": "°/s",
+	"m/s^2":          "m/s²",
+	"sec":            "s",
+	"g":              "G",
+}
+
+// ParseValue parses the textual form of a parameter. It returns nil for an
+// empty string, so absent parameters stay absent.
+func ParseValue(s string) *Value {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return nil
+	}
+
+	switch strings.ToLower(s) {
+	case "yes", "true":
+		return &Value{Text: s, Kind: KindBool, Magnitude: 1}
+	case "no", "false":
+		return &Value{Text: s, Kind: KindBool, Magnitude: 0}
+	}
+
+	// Numbers out of the float64 range fall through to text, as
+	// they would be stored as ±Inf.
+	if m := numberRe.FindStringSubmatch(s); m != nil {
+		if f, ok := parseFloat(m[1]); ok {
+			return &Value{Text: s, Kind: KindNumber, Magnitude: f, Unit: normalizeUnit(m[2])}
+		}
+	}
+
+	if m := symmetryRe.FindStringSubmatch(s); m != nil {
+		if bound, ok := parseFloat(m[1]); ok {
+			return &Value{Text: s, Kind: KindRange, Magnitude: bound, Range: []float64{-bound, bound}, Unit: normalizeUnit(m[2])}
+		}
+	}
+
+	if m := spanRe.FindStringSubmatch(s); m != nil {
+		low, okLow := parseFloat(m[1])
+		high, okHigh := parseFloat(m[2])
+		if okLow && okHigh {
+			return &Value{Text: s, Kind: KindRange, Magnitude: high, Range: []float64{low, high}, Unit: normalizeUnit(m[3])}
+		}
+	}
+
+	if tuple, unit, ok := parseTuple(s); ok {
+		return &Value{Text: s, Kind: KindTuple, Magnitude: tuple[0], Tuple: tuple, Unit: unit}
+	}
+
+	return &Value{Text: s, Kind: KindText}
+}
+
+// NewNumber returns a numeric Value in the given unit.
+func NewNumber(f float64, unit string) *Value {
+	return &Value{
+		Text:      strconv.FormatFloat(f, 'f', -1, 64),
+		Kind:      KindNumber,
+		Magnitude: f,
+		Unit:      unit,
+	}
+}
+
+func parseTuple(s string) ([]float64, string, bool) {
+	parts := strings.Split(s, "/")
+	if len(parts) < 2 {
+		return nil, "", false
+	}
+
+	var (
+		tuple []float64
+		unit  string
+	)
+
+	for _, part := range parts {
+		m := numberRe.FindStringSubmatch(strings.TrimSpace(part))
+		if m == nil {
+			return nil, "", false
+		}
+
+		if u := normalizeUnit(m[2]); u != "" {
+			unit = u
+		}
+
+		f, ok := parseFloat(m[1])
+		if !ok {
+			return nil, "", false
+		}
+
+		tuple = append(tuple, f)
+	}
+
+	return tuple, unit, true
+}
+
+// parseFloat reads a number matched by the number pattern. Commas group
+// thousands when they split the digits in threes ("1,500") and are a
+// decimal point otherwise ("1,5"). It fails for numbers out of the float64
+// range, which can't be encoded as JSON.
+func parseFloat(s string) (float64, bool) {
+	if groupedRe.MatchString(s) {
+		s = strings.ReplaceAll(s, ",", "")
+	} else {
+		s = strings.Replace(s, ",", ".", 1)
+	}
+
+	f, err := strconv.ParseFloat(s, 64)
+	if err != nil || math.IsInf(f, 0) || math.IsNaN(f) {
+		return 0, false
+	}
+
+	return f, true
+}
+
+func normalizeUnit(unit string) string {
+	unit = strings.TrimSpace(unit)
+	if alias, ok := unitAliases[strings.ToLower(unit)]; ok {
+		return alias
+	}
+	return unit
+}
+
+// String returns the value as it was entered. It is safe to call on a nil Value.
+func (v *Value) String() string {
+	if v == nil {
+		return ""
+	}
+	return v.Text
+}
+
+// Float returns the magnitude of numeric values.
+func (v *Value) Float() (float64, bool) {
+	if v == nil {
+		return 0, false
+	}
+
+	switch v.Kind {
+	case KindNumber, KindRange, KindTuple:
+		return v.Magnitude, true
+	}
+
+	return 0, false
+}
+
+func (v *Value) Clone() *Value {
+	if v == nil {
+		return nil
+	}
+
+	c := *v
+	c.Range = append([]float64(nil), v.Range...)
+	c.Tuple = append([]float64(nil), v.Tuple...)
+
+	if len(c.Range) == 0 {
+		c.Range = nil
+	}
+
+	if len(c.Tuple) == 0 {
+		c.Tuple = nil
+	}
+
+	return &c
+}
+
+// value is Value without its custom decoders.
+type value Value
+
+// UnmarshalJSON accepts the typed object form as well as plain strings and
+// numbers, which are parsed with ParseValue.
+func (v *Value) UnmarshalJSON(data []byte) error {
+	var raw any
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+
+	switch raw := raw.(type) {
+	case string:
+		return v.set(ParseValue(raw))
+	case float64:
+		return v.set(ParseValue(string(data)))
+	case map[string]any:
+		return v.unmarshalObject(data)
+	}
+
+	return fmt.Errorf("cannot decode %s into a parameter value", data)
+}
+
+// unmarshalObject decodes the typed object form. The text is what counts:
+// the value is parsed from it again, and objects whose kind, magnitude,
+// unit, range or tuple say something else than their text are rejected,
+// so the parsed form can't drift from the text. A unit missing from the
+// text of a number is taken from the object, as ApplyUnits stores it.
+func (v *Value) unmarshalObject(data []byte) error {
+	var sent struct {
+		Text      *string    `json:"text"`
+		Kind      *ValueKind `json:"kind"`
+		Magnitude *float64   `json:"magnitude"`
+		Unit      *string    `json:"unit"`
+		Range     []float64  `json:"range"`
+		Tuple     []float64  `json:"tuple"`
+	}
+
+	if err := json.Unmarshal(data, &sent); err != nil {
+		return err
+	}
+
+	if sent.Text == nil {
+		return fmt.Errorf("parameter value %s has no text", data)
+	}
+
+	parsed := ParseValue(*sent.Text)
+	if parsed == nil {
+		return v.set(nil)
+	}
+
+	if _, ok := parsed.Float(); ok && parsed.Unit == "" && sent.Unit != nil {
+		parsed.Unit = *sent.Unit
+	}
+
+	switch {
+	case sent.Kind != nil && *sent.Kind != parsed.Kind,
+		sent.Magnitude != nil && *sent.Magnitude != parsed.Magnitude,
+		sent.Unit != nil && *sent.Unit != parsed.Unit,
+		sent.Range != nil && !slices.Equal(sent.Range, parsed.Range),
+		sent.Tuple != nil && !slices.Equal(sent.Tuple, parsed.Tuple):
+		return fmt.Errorf("parameter value %s doesn't match its text %q", data, *sent.Text)
+	}
+
+	return v.set(parsed)
+}
+
+// UnmarshalBSONValue decodes both the typed document form and the plain
+// strings stored by earlier versions.
+func (v *Value) UnmarshalBSONValue(t bsontype.Type, data []byte) error {
+	raw := bson.RawValue{Type: t, Value: data}
+
+	switch t {
+	case bsontype.String:
+		return v.set(ParseValue(raw.StringValue()))
+	case bsontype.Double:
+		return v.set(NewNumber(raw.Double(), ""))
+	case bsontype.Int32:
+		return v.set(NewNumber(float64(raw.Int32()), ""))
+	case bsontype.Int64:
+		return v.set(NewNumber(float64(raw.Int64()), ""))
+	case bsontype.EmbeddedDocument:
+		return raw.Unmarshal((*value)(v))
+	case bsontype.Null, bsontype.Undefined:
+		*v = Value{}
+		return nil
+	}
+
+	return fmt.Errorf("cannot decode BSON %s into a parameter value", t)
+}
+
+func (v *Value) set(parsed *Value) error {
+	if parsed == nil {
+		*v = Value{}
+		return nil
+	}
+
+	*v = *parsed
+	return nil
+}
+
+var valueType = reflect.TypeOf((*Value)(nil))
+
+// eachValue calls fn for every parameter field of params, together with its
+// struct field description.
+func eachValue(params *Params, fn func(field reflect.StructField, v **Value)) {
+	root := reflect.ValueOf(params).Elem()
+
+	for i := 0; i < root.NumField(); i++ {
+		group := root.Field(i)
+		if group.Kind() != reflect.Struct {
+			continue
+		}
+
+		for j := 0; j < group.NumField(); j++ {
+			if group.Field(j).Type() != valueType {
+				continue
+			}
+
+			fn(group.Type().Field(j), group.Field(j).Addr().Interface().(**Value))
+		}
+	}
+}
+
+// ApplyUnits fills in the unit of numeric values that were entered without
+// one, using the unit tag of their field.
+func (p *Params) ApplyUnits() {
+	eachValue(p, func(field reflect.StructField, v **Value) {
+		unit := field.Tag.Get("unit")
+		if *v == nil || (*v).Unit != "" || unit == "" {
+			return
+		}
+
+		if _, ok := (*v).Float(); !ok {
+			return
+		}
+
+		c := (*v).Clone()
+		c.Unit = unit
+		*v = c
+	})
+}
+
+// params is Params without its custom decoders.
+type params Params
+
+// UnmarshalJSON decodes a weapon. Parameters sent as empty strings are left
+// nil, the same as parameters that were not sent at all.
+func (p *Params) UnmarshalJSON(data []byte) error {
+	if err := json.Unmarshal(data, (*params)(p)); err != nil {
+		return err
+	}
+
+	p.dropEmpty()
+
+	return nil
+}
+
+// UnmarshalBSON decodes a weapon document. Parameters stored as null or as
+// empty strings are left nil, the same as parameters missing from the document.
+func (p *Params) UnmarshalBSON(data []byte) error {
+	if err := bson.Unmarshal(data, (*params)(p)); err != nil {
+		return err
+	}
+
+	p.dropEmpty()
+
+	return nil
+}
+
+func (p *Params) dropEmpty() {
+	eachValue(p, func(_ reflect.StructField, v **Value) {
+		if *v != nil && (*v).Kind == "" {
+			*v = nil
+		}
+	})
+}
+
+// Clone returns a deep copy of params.
+func (p *Params) Clone() *Params {
+	c := *p
+
+	eachValue(&c, func(_ reflect.StructField, v **Value) {
+		*v = (*v).Clone()
+	})
+
+	if p.Deleted != nil {
+		deleted := *p.Deleted
+		c.Deleted = &deleted
+	}
+
+	return &c
+}
@@ -0,0 +1,89 @@
+package models
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+func TestValueUnmarshalJSON(t *testing.T) {
+	tests := []struct {
+		json string
+		want *Value
+	}{
+		{`"85.5 kg"`, &Value{Text: "85.5 kg", Kind: KindNumber, Magnitude: 85.5, Unit: "kg"}},
+		{`85.5`, &Value{Text: "85.5", Kind: KindNumber, Magnitude: 85.5}},
+		{`{"text": "85.5 kg"}`, &Value{Text: "85.5 kg", Kind: KindNumber, Magnitude: 85.5, Unit: "kg"}},
+		{`{"text": "85.5", "kind": "number", "magnitude": 85.5, "unit": "kg"}`, &Value{Text: "85.5", Kind: KindNumber, Magnitude: 85.5, Unit: "kg"}},
+		{`{"text": "30/20", "kind": "tuple", "magnitude": 30, "tuple": [30, 20]}`, &Value{Text: "30/20", Kind: KindTuple, Magnitude: 30, Tuple: []float64{30, 20}}},
+		{`{"text": "abc"}`, &Value{Text: "abc", Kind: KindText}},
+		{`{"text": ""}`, &Value{}},
+	}
+
+	for _, tt := range tests {
+		got := new(Value)
+		if err := json.Unmarshal([]byte(tt.json), got); err != nil {
+			t.Errorf("Unmarshal(%s): %v", tt.json, err)
+			continue
+		}
+
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("Unmarshal(%s) = %+v, want %+v", tt.json, got, tt.want)
+		}
+	}
+}
+
+// TestValueUnmarshalJSONMismatch checks that the parsed fields of the object
+// form can't contradict its text.
+func TestValueUnmarshalJSONMismatch(t *testing.T) {
+	for _, data := range []string{
+		`{"text": "abc", "kind": "number", "magnitude": 5}`,
+		`{"text": "2.87 m", "kind": "number", "magnitude": 3, "unit": "m"}`,
+		`{"magnitude": 3}`,
+		`{"text": "85.5 kg", "unit": "lb"}`,
+		`{"text": "20-30", "range": [0, 30]}`,
+		`{"text": "30/20", "tuple": [30]}`,
+	} {
+		if err := json.Unmarshal([]byte(data), new(Value)); err == nil {
+			t.Errorf("Unmarshal(%s) accepted a value that doesn't match its text", data)
+		}
+	}
+}
+
+func TestParseValue(t *testing.T) {
+	tests := []struct {
+		text string
+		want *Value
+	}{
+		{"", nil},
+		{"  ", nil},
+		{"Yes", &Value{Text: "Yes", Kind: KindBool, Magnitude: 1}},
+		{"no", &Value{Text: "no", Kind: KindBool}},
+		{"85.5 kg", &Value{Text: "85.5 kg", Kind: KindNumber, Magnitude: 85.5, Unit: "kg"}},
+		{"-3", &Value{Text: "-3", Kind: KindNumber, Magnitude: -3}},
+		{"1,5 m", &Value{Text: "1,5 m", Kind: KindNumber, Magnitude: 1.5, Unit: "m"}},
+		{"0,125", &Value{Text: "0,125", Kind: KindNumber, Magnitude: 0.125}},
+		{"1,500", &Value{Text: "1,500", Kind: KindNumber, Magnitude: 1500}},
+		{"12,000,000.5 m", &Value{Text: "12,000,000.5 m", Kind: KindNumber, Magnitude: 12000000.5, Unit: "m"}},
+		{"2e3 m/s", &Value{Text: "2e3 m/s", Kind: KindNumber, Magnitude: 2000, Unit: "m/s"}},
+		{"30 deg", &Value{Text: "30 deg", Kind: KindNumber, Magnitude: 30, Unit: "°"}},
+		{"±45°", &Value{Text: "±45°", Kind: KindRange, Magnitude: 45, Range: []float64{-45, 45}, Unit: "°"}},
+		{"1,000-3,000 m", &Value{Text: "1,000-3,000 m", Kind: KindRange, Magnitude: 3000, Range: []float64{1000, 3000}, Unit: "m"}},
+		{"0.5 to 4 km", &Value{Text: "0.5 to 4 km", Kind: KindRange, Magnitude: 4, Range: []float64{0.5, 4}, Unit: "km"}},
+		{"30/20", &Value{Text: "30/20", Kind: KindTuple, Magnitude: 30, Tuple: []float64{30, 20}}},
+		{"1e999", &Value{Text: "1e999", Kind: KindText}},
+		{"-1e999 kg", &Value{Text: "-1e999 kg", Kind: KindText}},
+		{"±1e999", &Value{Text: "±1e999", Kind: KindText}},
+		{"1-1e999", &Value{Text: "1-1e999", Kind: KindText}},
+		{"1e999/2", &Value{Text: "1e999/2", Kind: KindText}},
+		{"NaN", &Value{Text: "NaN", Kind: KindText}},
+		{"Inf", &Value{Text: "Inf", Kind: KindText}},
+		{"IR", &Value{Text: "IR", Kind: KindText}},
+	}
+
+	for _, tt := range tests {
+		if got := ParseValue(tt.text); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("ParseValue(%q) = %+v, want %+v", tt.text, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,63 @@
+package models
+
+import (
+	"strings"
+	"time"
+)
+
+// GameVersion is a War Thunder release, e.g. "2.35". Weapons written after
+// a version was added are tagged with it until a later one is added.
+type GameVersion struct {
+	Version string    `json:"version" bson:"version"`
+	Date    time.Time `json:"date" bson:"date"`
+}
+
+// CompareGameVersions orders game versions by release date, then by name.
+func CompareGameVersions(a, b GameVersion) int {
+	if c := a.Date.Compare(b.Date); c != 0 {
+		return c
+	}
+	return strings.Compare(a.Version, b.Version)
+}
+
+// Snapshot is a weapon as it was in a game version. A weapon has at most
+// one snapshot per version, the last one written in it. Params.Deleted is
+// set when the weapon was deleted in that version.
+type Snapshot struct {
+	Version string `json:"version" bson:"version"`
+
+	// Date is when the snapshot was written.
+	Date   time.Time `json:"date" bson:"date"`
+	Params *Params   `json:"params" bson:"params"`
+}
+
+// SnapshotAt returns the snapshot of history that was current in version,
+// the one of version itself or else of the latest version before it.
+// versions must be sorted with CompareGameVersions. It returns nil when
+// the weapon has no snapshot that old.
+func SnapshotAt(history []Snapshot, versions []GameVersion, version string) *Snapshot {
+	rank := make(map[string]int, len(versions))
+	for i, v := range versions {
+		rank[v.Version] = i
+	}
+
+	limit, ok := rank[version]
+	if !ok {
+		return nil
+	}
+
+	var res *Snapshot
+
+	for i := range history {
+		r, ok := rank[history[i].Version]
+		if !ok || r > limit {
+			continue
+		}
+
+		if res == nil || r > rank[res.Version] {
+			res = &history[i]
+		}
+	}
+
+	return res
+}
@@ -0,0 +1,57 @@
+// Sends the requests described by the forms of the API docs page and shows
+// the responses below them.
+document.addEventListener("submit", async (event) => {
+  const form = event.target.closest("form[data-path]");
+  if (!form) {
+    return;
+  }
+
+  event.preventDefault();
+
+  let path = form.dataset.path;
+  const query = new URLSearchParams();
+
+  for (const input of form.querySelectorAll("input[data-in]")) {
+    if (input.value === "") {
+      continue;
+    }
+
+    if (input.dataset.in === "path") {
+      path = path.replace(`{${input.name}}`, encodeURIComponent(input.value));
+    } else {
+      query.append(input.name, input.value);
+    }
+  }
+
+  if ([...query].length > 0) {
+    path += "?" + query;
+  }
+
+  const init = { method: form.dataset.method, headers: {} };
+
+  const body = form.elements["body"];
+  if (body && body.value.trim() !== "") {
+    init.body = body.value;
+    init.headers["Content-Type"] = form.elements["content-type"].value;
+  }
+
+  const result = form.querySelector(".result");
+  result.hidden = false;
+  result.textContent = `${init.method} ${path}\n\n…`;
+
+  try {
+    const response = await fetch(path, init);
+    const text = await response.text();
+
+    let pretty = text;
+    try {
+      pretty = JSON.stringify(JSON.parse(text), null, 2);
+    } catch {
+      // Not JSON, e.g. an empty 204 body.
+    }
+
+    result.textContent = `${init.method} ${path}\n\n${response.status} ${response.statusText}\n\n${pretty}`;
+  } catch (err) {
+    result.textContent = `${init.method} ${path}\n\n${err}`;
+  }
+});